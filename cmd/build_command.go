@@ -1,20 +1,30 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
 	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/reference"
 	"github.com/kwford18/MKDIRagons/template"
 	"github.com/spf13/cobra"
 )
 
 var (
-	buildFile string
-	printChar bool
-	rollHP    bool
-	output    string
+	buildFile  string
+	printChar  bool
+	rollHP     bool
+	seed       uint64
+	output     string
+	rollGold   bool
+	budget     bool
+	chooseGear bool
 )
 
 var buildCmd = &cobra.Command{
@@ -35,11 +45,31 @@ var buildCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		char, err := character.BuildCharacter(&base, rollHP)
+		if chooseGear {
+			if err := promptStartingEquipment(cmd, &base); err != nil {
+				return err
+			}
+		}
+
+		char, err := character.BuildCharacter(&base, hpRoller(cmd, rollHP, seed))
 		if err != nil {
 			return fmt.Errorf("error building character: %w", err)
 		}
 
+		if rollGold {
+			gold, err := char.RollStartingGold(goldRoller(cmd))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Rolled %d gp of starting wealth\n", gold)
+		}
+
+		if budget {
+			if err := char.Inventory.PayForGear(); err != nil {
+				return err
+			}
+		}
+
 		if printChar {
 			char.Print()
 		}
@@ -52,6 +82,90 @@ var buildCmd = &cobra.Command{
 	},
 }
 
+// hpRoller returns the dice roller for HP, seeded by --seed when given, or nil when using hit die averages
+func hpRoller(cmd *cobra.Command, roll bool, seed uint64) *dice.Roller {
+	if !roll {
+		return nil
+	}
+	if cmd.Flags().Changed("seed") {
+		return dice.NewRoller(seed)
+	}
+	return dice.NewRandomRoller()
+}
+
+// promptStartingEquipment asks for each of the class's starting equipment choices,
+// filling in the template's starting_equipment_choices and starting_equipment_picks
+func promptStartingEquipment(cmd *cobra.Command, base *template.Character) error {
+	var c class.Class
+	if err := class.FetchClass(base, &c); err != nil {
+		return fmt.Errorf("error fetching class: %w", err)
+	}
+	reader := bufio.NewReader(cmd.InOrStdin())
+	base.StartingEquipmentChoices, base.StartingEquipmentPicks = nil, nil
+
+	for _, group := range c.StartingEquipmentOptions {
+		choice := 0
+		var categories []reference.Reference
+		if group.From.EquipmentCategory != nil {
+			for range max(1, group.Choose) {
+				categories = append(categories, *group.From.EquipmentCategory)
+			}
+		} else {
+			fmt.Printf("Starting equipment: %s\n", group.Desc)
+			for i, option := range group.From.Options {
+				fmt.Printf("  %d) %s\n", i, option)
+			}
+			answer, err := strconv.Atoi(prompt(reader, "Choose an option: "))
+			if err != nil || answer < 0 || answer >= len(group.From.Options) {
+				return fmt.Errorf("invalid starting equipment choice for %q", group.Desc)
+			}
+			choice = answer
+			categories = optionCategories(group.From.Options[choice])
+		}
+		base.StartingEquipmentChoices = append(base.StartingEquipmentChoices, choice)
+
+		// Category choices such as "any martial weapon" need a piece of equipment picked
+		for _, ref := range categories {
+			var category inventory.EquipmentCategory
+			if err := inventory.FetchEquipmentCategory(ref.Index, &category); err != nil {
+				return fmt.Errorf("error fetching %s: %w", ref.Name, err)
+			}
+			names := make([]string, len(category.Equipment))
+			for i, equipment := range category.Equipment {
+				names[i] = equipment.Name
+			}
+			fmt.Printf("%s: %s\n", category.Name, strings.Join(names, ", "))
+			base.StartingEquipmentPicks = append(base.StartingEquipmentPicks, prompt(reader, "Pick one: "))
+		}
+	}
+	return nil
+}
+
+// optionCategories lists the equipment categories an option needs a pick from, once per pick
+func optionCategories(o class.Option) []reference.Reference {
+	var categories []reference.Reference
+	switch o.OptionType {
+	case "choice":
+		for range max(1, o.Choice.Choose) {
+			categories = append(categories, o.Choice.From.EquipmentCategory)
+		}
+	case "multiple":
+		for _, item := range o.Items {
+			categories = append(categories, optionCategories(item)...)
+		}
+	}
+	return categories
+}
+
+// goldRoller returns the dice roller for starting wealth. With --seed it's its own stream derived
+// from the seed, so the gold dice don't repeat the HP dice.
+func goldRoller(cmd *cobra.Command) *dice.Roller {
+	if cmd.Flags().Changed("seed") {
+		return dice.NewRoller(dice.SubSeed(seed, "starting gold"))
+	}
+	return dice.NewRandomRoller()
+}
+
 func init() {
 	// Add the build command to the root
 	rootCmd.AddCommand(buildCmd)
@@ -65,6 +179,18 @@ func init() {
 	// --rollHP -r flag for whether a character should roll for HP or use the average of hit die
 	buildCmd.Flags().BoolVarP(&rollHP, "rollHP", "r", false, "Roll for character's HP instead of using hit die average")
 
+	// --seed flag for reproducible HP rolls
+	buildCmd.Flags().Uint64Var(&seed, "seed", 0, "Seed for rolling HP and starting gold, recorded in the JSON so rolls can be reproduced (random if not set)")
+
+	// --gold -g flag for rolling the class's starting wealth into the wallet
+	buildCmd.Flags().BoolVarP(&rollGold, "gold", "g", false, "Roll the class's starting wealth and add it to the wallet")
+
+	// --choose-equipment flag for prompting for the class's starting equipment
+	buildCmd.Flags().BoolVar(&chooseGear, "choose-equipment", false, "Prompt for the class's starting equipment choices instead of reading them from the TOML")
+
+	// --budget flag for paying for the listed gear from the wallet
+	buildCmd.Flags().BoolVar(&budget, "budget", false, "Pay for the listed gear from the wallet, failing if it costs more than the character has")
+
 	// --output -o flag for providing a path to the directory to save json
 	buildCmd.Flags().StringVarP(&output, "output", "o", "characters/", "Path to desired output directory")
 
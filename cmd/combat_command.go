@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/kwford18/MKDIRagons/internal/combat"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/encounter"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/spf13/cobra"
+)
+
+var (
+	combatParty      string
+	combatCharacters []string
+	combatSeed       uint64
+)
+
+// combatPath resolves a fight's name to its JSON, e.g. "goblin-ambush" to combats/goblin-ambush.json
+func combatPath(path string) string {
+	return jsonPath("combats/", path)
+}
+
+// combatRoller returns the roller for initiative and death saves, seeded with --seed when set
+func combatRoller(cmd *cobra.Command) *dice.Roller {
+	if cmd.Flags().Changed("seed") {
+		return dice.NewRoller(combatSeed)
+	}
+	return dice.NewRandomRoller()
+}
+
+// runCombat runs the combat prompt on the terminal, saving the fight after every change
+func runCombat(c *combat.Combat, path string, roller *dice.Roller) error {
+	session := &combat.Session{
+		Combat:  c,
+		Fetcher: core.DefaultFetcher,
+		Roller:  roller,
+		Save:    func(c *combat.Combat) error { return io.WriteCombat(c, path) },
+		Out:     os.Stdout,
+	}
+	return session.Run(os.Stdin)
+}
+
+var combatCmd = &cobra.Command{
+	Use:   "combat",
+	Short: "Track initiative, HP, conditions and concentration through a fight",
+	Long: `Runs a fight as an interactive prompt. Characters bring their play state, so damage taken in the fight
+shows up in hp and rests afterwards, and monsters get their own HP from their stat block. The fight is saved
+in combats/ after every change and can be resumed next session.`,
+}
+
+var combatStartCmd = &cobra.Command{
+	Use:   "start <name> [monsters...]",
+	Short: "Start a fight between characters and SRD monsters and roll initiative",
+	Example: `  MKDIRagons combat start goblin-ambush --party tuesday "goblin x4" bugbear
+  MKDIRagons combat start duel -c leki -c vex`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := combatPath(args[0])
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("fight %s already exists, continue it with combat resume", path)
+		}
+
+		paths := combatCharacters
+		if combatParty != "" {
+			p, err := io.LoadParty(partyPath(combatParty))
+			if err != nil {
+				return fmt.Errorf("failed to load party: %w", err)
+			}
+			paths = append(paths, p.Members...)
+		}
+
+		c := &combat.Combat{Name: args[0]}
+		roller := combatRoller(cmd)
+		for _, charPath := range paths {
+			charPath = characterPath(charPath)
+			char, err := io.LoadCharacter(charPath)
+			if err != nil {
+				return fmt.Errorf("failed to load character: %w", err)
+			}
+			state, err := io.LoadState(io.StatePath(charPath), char)
+			if err != nil {
+				return fmt.Errorf("failed to load play state: %w", err)
+			}
+			if _, err := c.Add(roller, combat.NewCharacterCombatant(charPath, char, state)); err != nil {
+				return err
+			}
+		}
+
+		e, err := encounter.BuildEncounter(args[1:])
+		if err != nil {
+			return err
+		}
+		for _, group := range e.Groups {
+			for range group.Count {
+				if _, err := c.Add(roller, combat.NewMonsterCombatant(c.MonsterName(&group.Monster), &group.Monster)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(c.Combatants) > 0 {
+			lines, err := c.RollInitiative(roller)
+			if err != nil {
+				return err
+			}
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+		}
+		if err := io.WriteCombat(c, path); err != nil {
+			return fmt.Errorf("failed to save combat: %w", err)
+		}
+		fmt.Printf("✓ Combat saved to: %s\n", path)
+
+		return runCombat(c, path, roller)
+	},
+}
+
+var combatResumeCmd = &cobra.Command{
+	Use:     "resume <name>",
+	Short:   "Continue a saved fight",
+	Example: `  MKDIRagons combat resume goblin-ambush`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := combatPath(args[0])
+		c, err := io.LoadCombat(path)
+		if err != nil {
+			return fmt.Errorf("failed to load combat: %w", err)
+		}
+		return runCombat(c, path, combatRoller(cmd))
+	},
+}
+
+func init() {
+	// Add the combat commands to the root
+	combatCmd.AddCommand(combatStartCmd, combatResumeCmd)
+	rootCmd.AddCommand(combatCmd)
+
+	// The characters joining the fight
+	combatStartCmd.Flags().StringVarP(&combatParty, "party", "p", "", "Party joining the fight (looked up in parties/)")
+	combatStartCmd.Flags().StringArrayVarP(&combatCharacters, "character", "c", nil, "JSON character joining the fight, can be repeated")
+
+	// --seed flag for initiative and death saves
+	for _, c := range []*cobra.Command{combatStartCmd, combatResumeCmd} {
+		c.Flags().Uint64Var(&combatSeed, "seed", 0, "Seed for initiative and death saves (random if not set)")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/spf13/cobra"
+)
+
+var (
+	companionCharacter string
+	companionKind      string
+	companionName      string
+)
+
+var companionCmd = &cobra.Command{
+	Use:   "companion [add <monster> | remove <name>]",
+	Short: "Show, add or dismiss a character's companions, familiars and steeds",
+	Long: `Companions are SRD creatures that belong to a character: a Beast Master ranger's companion,
+a familiar from Find Familiar, a steed from Find Steed or any other summon. A Ranger's Companion adds the
+ranger's proficiency bonus to its AC, attacks, damage, saves and skills. Without arguments every companion's
+stat block is shown. Their hit points are tracked with hp --companion.`,
+	Example: `  MKDIRagons companion -c leki
+  MKDIRagons companion -c vex add wolf --kind beast --name Trinket
+  MKDIRagons companion -c leki add owl --kind familiar
+  MKDIRagons companion -c leki remove owl`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if companionCharacter == "" {
+			return fmt.Errorf("please provide a JSON character with --character")
+		}
+		charPath := characterPath(companionCharacter)
+
+		char, err := io.LoadCharacter(charPath)
+		if err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+
+		if len(args) == 0 {
+			if len(char.Companions) == 0 {
+				fmt.Printf("%s has no companions\n", char.Name)
+			}
+			char.PrintCompanions()
+			return nil
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("please name the companion to %s", args[0])
+		}
+		name := strings.Join(args[1:], " ")
+
+		var summary string
+		switch args[0] {
+		case "add":
+			var m monster.Monster
+			if err := monster.FetchMonster(name, &m); err != nil {
+				return fmt.Errorf("unknown monster %q: %w", name, err)
+			}
+			comp, err := character.NewCompanion(char, m, companionName, companionKind)
+			if err != nil {
+				return err
+			}
+			if summary, err = char.AddCompanion(comp); err != nil {
+				return err
+			}
+		case "remove":
+			if summary, err = char.RemoveCompanion(name); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown companion action %q, expected \"add\" or \"remove\"", args[0])
+		}
+
+		if err := io.WriteJSON(char, charPath); err != nil {
+			return fmt.Errorf("failed to save character as JSON: %w", err)
+		}
+		fmt.Printf("✓ %s %s\n", char.Name, summary)
+		return nil
+	},
+}
+
+func init() {
+	// Add the companion command to the root
+	rootCmd.AddCommand(companionCmd)
+
+	// --character -c flag for the companions' owner
+	companionCmd.Flags().StringVarP(&companionCharacter, "character", "c", "", "JSON character the companion belongs to (looked up in characters/)")
+
+	// Kind and name of an added companion
+	companionCmd.Flags().StringVar(&companionKind, "kind", character.CompanionSummon, "Companion kind: beast, familiar, steed or summon")
+	companionCmd.Flags().StringVar(&companionName, "name", "", "Name of the companion (defaults to the creature's)")
+}
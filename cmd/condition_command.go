@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/spf13/cobra"
+)
+
+var deathSaveSeed uint64
+
+var conditionCmd = &cobra.Command{
+	Use:   "condition add|remove <condition>",
+	Short: "Apply or remove a condition such as poisoned or prone",
+	Long: `Applies or removes an SRD condition on a character's play state.
+Conditions are reflected in rolls made with roll -c (disadvantage, automatic failures) and in the character's speed.`,
+	Example: `  MKDIRagons condition -c leki add poisoned
+  MKDIRagons condition -c leki remove poisoned`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args[1:], " ")
+
+		switch args[0] {
+		case "add":
+			var condition conditions.Condition
+			if err := conditions.FetchCondition(name, &condition); err != nil {
+				return fmt.Errorf("unknown condition %q: %w", name, err)
+			}
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				summary, err := state.AddCondition(condition)
+				if err == nil {
+					condition.Print()
+				}
+				return summary, err
+			})
+		case "remove":
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				return state.RemoveCondition(name)
+			})
+		default:
+			return fmt.Errorf("unknown condition action %q, expected \"add\" or \"remove\"", args[0])
+		}
+	},
+}
+
+var exhaustionCmd = &cobra.Command{
+	Use:     "exhaustion <level>",
+	Short:   "Set a character's exhaustion level (0-6)",
+	Example: `  MKDIRagons exhaustion -c leki 2`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		level, err := amountArg(args)
+		if err != nil {
+			return err
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.SetExhaustion(level)
+		})
+	},
+}
+
+var deathSaveCmd = &cobra.Command{
+	Use:   "death-save",
+	Short: "Roll a death saving throw for a character at 0 HP",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roller := dice.NewRandomRoller()
+		if cmd.Flags().Changed("seed") {
+			roller = dice.NewRoller(deathSaveSeed)
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.DeathSave(roller)
+		})
+	},
+}
+
+var stabilizeCmd = &cobra.Command{
+	Use:   "stabilize",
+	Short: "Stabilize a dying character",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.Stabilize()
+		})
+	},
+}
+
+func init() {
+	// Add the condition commands to the root
+	rootCmd.AddCommand(conditionCmd, exhaustionCmd, deathSaveCmd, stabilizeCmd)
+
+	// --character -c flag shared with the other play commands
+	for _, c := range []*cobra.Command{conditionCmd, exhaustionCmd, deathSaveCmd, stabilizeCmd} {
+		c.PersistentFlags().StringVarP(&playCharacter, "character", "c", "", "JSON character to track (looked up in characters/)")
+	}
+
+	// --seed flag for a reproducible death save
+	deathSaveCmd.Flags().Uint64Var(&deathSaveSeed, "seed", 0, "Seed for the death save (random if not set)")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/encounter"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/spf13/cobra"
+)
+
+var (
+	encounterParty       string
+	encounterLevels      []int
+	encounterRandom      string
+	encounterMinCR       string
+	encounterMaxCR       string
+	encounterType        string
+	encounterMaxMonsters int
+	encounterSeed        uint64
+)
+
+// partyLevels returns the character levels of the --party or --levels flag
+func partyLevels() ([]int, error) {
+	if encounterParty == "" {
+		if len(encounterLevels) == 0 {
+			return nil, fmt.Errorf("please provide a party with --party or character levels with --levels")
+		}
+		return encounterLevels, nil
+	}
+
+	p, err := io.LoadParty(partyPath(encounterParty))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load party: %w", err)
+	}
+	members, err := io.LoadMembers(p)
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]int, len(members))
+	for i, m := range members {
+		levels[i] = m.Character.Level
+	}
+	return levels, nil
+}
+
+var encounterCmd = &cobra.Command{
+	Use:   "encounter",
+	Short: "Build and rate encounters against a party's XP thresholds",
+}
+
+var encounterBuildCmd = &cobra.Command{
+	Use:   "build [monsters...]",
+	Short: "Rate an encounter as easy, medium, hard or deadly, or generate a random one",
+	Long: `Adds up the party's XP thresholds by level and rates the monsters' XP after the multiple monster
+multiplier. Monsters take a count like inventory entries, e.g. "goblin x4". With --random a random encounter of
+that difficulty is generated instead, from SRD monsters within --min-cr and --max-cr and optionally of --type.`,
+	Example: `  MKDIRagons encounter build --party tuesday "goblin x4" bugbear
+  MKDIRagons encounter build --levels 5,5,4,4 "young red dragon"
+  MKDIRagons encounter build --party tuesday --random hard --max-cr 2 --type undead`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		levels, err := partyLevels()
+		if err != nil {
+			return err
+		}
+
+		var e *encounter.Encounter
+		if encounterRandom == "" {
+			if len(args) == 0 {
+				return fmt.Errorf("please list the monsters, or generate an encounter with --random")
+			}
+			if e, err = encounter.BuildEncounter(args); err != nil {
+				return err
+			}
+		} else {
+			target, err := encounter.ParseDifficulty(encounterRandom)
+			if err != nil {
+				return err
+			}
+			opts := encounter.Options{Type: encounterType, MaxMonsters: encounterMaxMonsters}
+			if opts.MinCR, err = monster.ParseCR(encounterMinCR); err != nil {
+				return err
+			}
+			if opts.MaxCR, err = monster.ParseCR(encounterMaxCR); err != nil {
+				return err
+			}
+
+			roller := dice.NewRandomRoller()
+			if cmd.Flags().Changed("seed") {
+				roller = dice.NewRoller(encounterSeed)
+			}
+			if e, err = encounter.Generate(levels, target, opts, roller); err != nil {
+				return err
+			}
+			fmt.Printf("Seed: %d\n", roller.Seed())
+		}
+
+		rating, err := encounter.Rate(levels, e)
+		if err != nil {
+			return err
+		}
+		fmt.Println(rating)
+		return nil
+	},
+}
+
+func init() {
+	// Add the encounter commands to the root
+	encounterCmd.AddCommand(encounterBuildCmd)
+	rootCmd.AddCommand(encounterCmd)
+
+	// The party facing the encounter
+	encounterBuildCmd.Flags().StringVarP(&encounterParty, "party", "p", "", "Party to build the encounter for (looked up in parties/)")
+	encounterBuildCmd.Flags().IntSliceVar(&encounterLevels, "levels", nil, "Character levels of the party, e.g. 5,5,4,4")
+
+	// Random encounter filters
+	encounterBuildCmd.Flags().StringVar(&encounterRandom, "random", "", "Generate a random encounter of this difficulty: easy, medium, hard or deadly")
+	encounterBuildCmd.Flags().StringVar(&encounterMinCR, "min-cr", "0", "Lowest challenge rating of a random monster, e.g. 1/4")
+	encounterBuildCmd.Flags().StringVar(&encounterMaxCR, "max-cr", "30", "Highest challenge rating of a random monster")
+	encounterBuildCmd.Flags().StringVar(&encounterType, "type", "", "Monster type of a random encounter, e.g. undead")
+	encounterBuildCmd.Flags().IntVar(&encounterMaxMonsters, "max-monsters", 0, "Most monsters in a random encounter (no limit if not set)")
+	encounterBuildCmd.Flags().Uint64Var(&encounterSeed, "seed", 0, "Seed for the random encounter (random if not set)")
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	levelFile     string
+	levelClass    string
+	levelTOML     string
+	levelASI      []string
+	levelFeat     string
+	levelSpells   []string
+	levelSubclass string
+	levelRollHP   bool
+	levelSeed     uint64
+	levelPrint    bool
+)
+
+var levelUpCmd = &cobra.Command{
+	Use:   "levelup",
+	Short: "Advance an existing character by one level",
+	Long: `Loads a saved JSON character, increments its level and rolls or averages only the new hit die.
+New choices (ability score improvement or feat, spells, subclass) can be passed as flags, otherwise they are prompted for at the right level.
+Both the JSON character and its source TOML are updated. The TOML is rewritten from its fields, so comments in it are not kept.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Require the --file flag
+		if levelFile == "" {
+			return fmt.Errorf("please provide a JSON file with --file")
+		}
+		if !strings.Contains(levelFile, "/") {
+			levelFile = "characters/" + levelFile
+		}
+
+		char, err := io.LoadCharacter(levelFile)
+		if err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+
+		if levelClass != "" && !strings.EqualFold(levelClass, char.Class.Name) {
+			return fmt.Errorf("multiclassing is not supported yet: %s is a %s", char.Name, char.Class.Name)
+		}
+
+		choices, err := levelUpChoices(cmd, char)
+		if err != nil {
+			return err
+		}
+
+		result, err := character.LevelUp(char, choices, hpRoller(cmd, levelRollHP, levelSeed))
+		if err != nil {
+			return fmt.Errorf("error levelling up character: %w", err)
+		}
+
+		// The TOML goes first, so a TOML that can't be updated leaves both files at the old level
+		tomlPath, err := updateSourceTOML(cmd, char, result)
+		if err != nil {
+			return err
+		}
+
+		if err := io.WriteJSON(char, levelFile); err != nil {
+			if tomlPath != "" {
+				return fmt.Errorf("source TOML %s is now level %d but the character JSON failed to save: %w", tomlPath, result.Level, err)
+			}
+			return fmt.Errorf("failed to save character as JSON: %w", err)
+		}
+		fmt.Printf("✓ %s is now level %d (+%d HP)\n", char.Name, result.Level, result.HPGained)
+
+		if levelPrint {
+			char.Print()
+		}
+
+		return nil
+	},
+}
+
+// levelUpChoices collects choices from flags, prompting for any the new level requires
+func levelUpChoices(cmd *cobra.Command, char *character.Character) (character.LevelUpChoices, error) {
+	var choices character.LevelUpChoices
+	reader := bufio.NewReader(cmd.InOrStdin())
+	next := char.Level + 1
+
+	// Ability score improvement or feat
+	if !cmd.Flags().Changed("asi") && !cmd.Flags().Changed("feat") && char.NeedsASI() {
+		answer := prompt(reader, fmt.Sprintf("Level %d grants an ability score improvement. Enter two abilities (e.g. str,con) or a feat name: ", next))
+		if strings.Contains(answer, ",") {
+			levelASI = strings.Split(answer, ",")
+		} else if _, err := core.ParseAbility(answer); err == nil {
+			levelASI = []string{answer, answer}
+		} else {
+			levelFeat = answer
+		}
+	}
+	for _, name := range levelASI {
+		ability, err := core.ParseAbility(name)
+		if err != nil {
+			return choices, err
+		}
+		choices.ASI = append(choices.ASI, ability)
+	}
+	choices.Feat = levelFeat
+
+	// Subclass
+	if !cmd.Flags().Changed("subclass") && char.NeedsSubclass() {
+		levelSubclass = prompt(reader, fmt.Sprintf("Level %d %s chooses a subclass: ", next, char.Class.Name))
+	}
+	choices.Subclass = levelSubclass
+
+	// New spells, only prompted for spellcasters
+	if !cmd.Flags().Changed("spells") && char.Class.Spellcasting.Level > 0 && next >= char.Class.Spellcasting.Level {
+		answer := prompt(reader, "New spells (comma separated, blank for none): ")
+		if answer != "" {
+			levelSpells = strings.Split(answer, ",")
+		}
+	}
+	for _, spell := range levelSpells {
+		if spell = strings.TrimSpace(spell); spell != "" {
+			choices.Spells = append(choices.Spells, spell)
+		}
+	}
+
+	return choices, nil
+}
+
+// prompt prints a question and returns the trimmed line typed by the user
+func prompt(reader *bufio.Reader, question string) string {
+	fmt.Print(question)
+	answer, _ := reader.ReadString('\n')
+	return strings.TrimSpace(answer)
+}
+
+// updateSourceTOML mirrors the level up into the character's TOML template,
+// returning its path or "" when there's no TOML to update
+func updateSourceTOML(cmd *cobra.Command, char *character.Character, result character.LevelUpResult) (string, error) {
+	tomlPath := levelTOML
+	if !cmd.Flags().Changed("toml") {
+		tomlPath = filepath.Join("toml-characters", strings.ToLower(char.Name)+".toml")
+		if _, err := os.Stat(tomlPath); errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("No source TOML found at %s, skipping (use --toml to set its path)\n", tomlPath)
+			return "", nil
+		}
+	} else if !strings.Contains(tomlPath, "/") {
+		tomlPath = "toml-characters/" + tomlPath
+	}
+
+	base, err := template.TomlParse(tomlPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source TOML: %w", err)
+	}
+
+	character.ApplyLevelUp(&base, result)
+
+	if err := template.WriteTOML(&base, tomlPath); err != nil {
+		return "", fmt.Errorf("failed to update source TOML: %w", err)
+	}
+	fmt.Printf("✓ Source TOML updated: %s\n", tomlPath)
+
+	return tomlPath, nil
+}
+
+func init() {
+	// Add the levelup command to the root
+	rootCmd.AddCommand(levelUpCmd)
+
+	// --file -f flag for providing filepath to the JSON character
+	levelUpCmd.Flags().StringVarP(&levelFile, "file", "f", "characters/", "Path to the JSON file")
+
+	// --class flag for the class gaining the level
+	levelUpCmd.Flags().StringVar(&levelClass, "class", "", "Class to gain the level in (defaults to the character's class)")
+
+	// --toml -t flag for the source TOML to keep in sync
+	levelUpCmd.Flags().StringVarP(&levelTOML, "toml", "t", "", "Path to the source TOML file (defaults to toml-characters/<name>.toml)")
+
+	// Level up choices
+	levelUpCmd.Flags().StringSliceVar(&levelASI, "asi", nil, "Ability score improvement as two abilities, e.g. --asi str,con or --asi wis,wis")
+	levelUpCmd.Flags().StringVar(&levelFeat, "feat", "", "Feat to take instead of an ability score improvement")
+	levelUpCmd.Flags().StringSliceVar(&levelSpells, "spells", nil, "Comma separated list of newly learned spells")
+	levelUpCmd.Flags().StringVar(&levelSubclass, "subclass", "", "Subclass to pick at the subclass level")
+
+	// --rollHP -r flag for rolling the new hit die instead of using its average
+	levelUpCmd.Flags().BoolVarP(&levelRollHP, "rollHP", "r", false, "Roll the new hit die instead of using its average")
+
+	// --seed flag for a reproducible hit die roll
+	levelUpCmd.Flags().Uint64Var(&levelSeed, "seed", 0, "Seed for rolling the hit die (random if not set)")
+
+	// --print -p flag for printing the character after levelling up
+	levelUpCmd.Flags().BoolVarP(&levelPrint, "print", "p", false, "Print character info after levelling up")
+}
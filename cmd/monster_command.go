@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/spf13/cobra"
+)
+
+var monsterCmd = &cobra.Command{
+	Use:   "monster <name>",
+	Short: "Look up an SRD creature and print its stat block",
+	Example: `  MKDIRagons monster goblin
+  MKDIRagons monster "Young Red Dragon"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args, " ")
+
+		var m monster.Monster
+		if err := monster.FetchMonster(name, &m); err != nil {
+			return fmt.Errorf("unknown monster %q: %w", name, err)
+		}
+
+		m.Print()
+		return nil
+	},
+}
+
+func init() {
+	// Add the monster command to the root
+	rootCmd.AddCommand(monsterCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/party"
+	"github.com/spf13/cobra"
+)
+
+// partyPath resolves a party name to its JSON, e.g. "tuesday" to parties/tuesday.json
+func partyPath(path string) string {
+	return jsonPath("parties/", path)
+}
+
+// changeParty loads a party, applies change to it and saves it
+func changeParty(name string, change func(p *party.Party) (string, error)) error {
+	path := partyPath(name)
+	p, err := io.LoadParty(path)
+	if err != nil {
+		return fmt.Errorf("failed to load party: %w", err)
+	}
+
+	summary, err := change(p)
+	if err != nil {
+		return err
+	}
+	if err := io.WriteParty(p, path); err != nil {
+		return fmt.Errorf("failed to save party: %w", err)
+	}
+	fmt.Printf("✓ %s %s\n", p.Name, summary)
+	return nil
+}
+
+var partyCmd = &cobra.Command{
+	Use:   "party",
+	Short: "Group saved characters into parties and view a party summary",
+	Long: `Parties are named groups of saved JSON characters, kept in parties/ (the party "tuesday" is
+parties/tuesday.json). Characters are looked up in characters/ like the play commands.`,
+}
+
+var partyCreateCmd = &cobra.Command{
+	Use:     "create <party> [characters...]",
+	Short:   "Create a party, optionally with its first members",
+	Example: `  MKDIRagons party create tuesday leki vex`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := partyPath(args[0])
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("party %s already exists", path)
+		}
+
+		p := &party.Party{Name: args[0]}
+		for _, member := range args[1:] {
+			if _, err := p.Add(characterPath(member)); err != nil {
+				return err
+			}
+		}
+		if _, err := io.LoadMembers(p); err != nil {
+			return err
+		}
+		if err := io.WriteParty(p, path); err != nil {
+			return fmt.Errorf("failed to save party: %w", err)
+		}
+		fmt.Printf("✓ Party saved to: %s\n", path)
+		return nil
+	},
+}
+
+var partyAddCmd = &cobra.Command{
+	Use:     "add <party> <character>",
+	Short:   "Add a saved character to a party",
+	Example: `  MKDIRagons party add tuesday bob`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		charPath := characterPath(args[1])
+		if _, err := io.LoadCharacter(charPath); err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+		return changeParty(args[0], func(p *party.Party) (string, error) {
+			return p.Add(charPath)
+		})
+	},
+}
+
+var partyRemoveCmd = &cobra.Command{
+	Use:     "remove <party> <character>",
+	Short:   "Take a character out of a party",
+	Example: `  MKDIRagons party remove tuesday bob`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeParty(args[0], func(p *party.Party) (string, error) {
+			return p.Remove(characterPath(args[1]))
+		})
+	},
+}
+
+var partyShowCmd = &cobra.Command{
+	Use:   "show <party>",
+	Short: "Show a party's AC, HP, passive Perception, speed, spell save DCs, languages and coverage gaps",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := io.LoadParty(partyPath(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to load party: %w", err)
+		}
+		members, err := io.LoadMembers(p)
+		if err != nil {
+			return err
+		}
+		fmt.Println(party.Summary(p.Name, members))
+		return nil
+	},
+}
+
+var partyLongRestCmd = &cobra.Command{
+	Use:   "long-rest <party>",
+	Short: "Give every member of a party a long rest",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := io.LoadParty(partyPath(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to load party: %w", err)
+		}
+		members, err := io.LoadMembers(p)
+		if err != nil {
+			return err
+		}
+		for _, result := range party.LongRest(members) {
+			fmt.Println(result)
+		}
+		if err := io.WriteMemberStates(p, members); err != nil {
+			return fmt.Errorf("failed to save play state: %w", err)
+		}
+		fmt.Println()
+		fmt.Println(party.Summary(p.Name, members))
+		return nil
+	},
+}
+
+func init() {
+	// Add the party commands to the root
+	partyCmd.AddCommand(partyCreateCmd, partyAddCmd, partyRemoveCmd, partyShowCmd, partyLongRestCmd)
+	rootCmd.AddCommand(partyCmd)
+}
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/spf13/cobra"
+)
+
+var (
+	playCharacter string
+	damageType    string
+	restDice      int
+	restSeed      uint64
+	supplyCount   int
+	hpCompanion   string
+	castSlot      int
+	castRitual    bool
+	concSeed      uint64
+	restPrepare   []string
+)
+
+// withPlayState loads the character and its play state, applies change and saves the state.
+// A nil change only prints the current state.
+func withPlayState(change func(char *character.Character, state *play.State) (string, error)) error {
+	if playCharacter == "" {
+		return fmt.Errorf("please provide a JSON character with --character")
+	}
+	charPath := characterPath(playCharacter)
+
+	char, err := io.LoadCharacter(charPath)
+	if err != nil {
+		return fmt.Errorf("failed to load character: %w", err)
+	}
+
+	statePath := io.StatePath(charPath)
+	state, err := io.LoadState(statePath, char)
+	if err != nil {
+		return fmt.Errorf("failed to load play state: %w", err)
+	}
+
+	if change != nil {
+		summary, err := change(char, state)
+		if err != nil {
+			return err
+		}
+		if err := io.WriteState(state, statePath); err != nil {
+			return fmt.Errorf("failed to save play state: %w", err)
+		}
+		fmt.Printf("✓ %s %s\n", state.Character, summary)
+	}
+
+	fmt.Println(state)
+	return nil
+}
+
+// amountArg parses the single number argument of a play command
+func amountArg(args []string) (int, error) {
+	amount, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", args[0])
+	}
+	return amount, nil
+}
+
+var hpCmd = &cobra.Command{
+	Use:   "hp",
+	Short: "Show or change a character's hit points during play",
+	Long: `Tracks hit points across a session. The play state is saved next to the character JSON
+(characters/leki.json keeps its state in characters/leki.state.json) and every change can be undone.`,
+	Example: `  MKDIRagons hp -c leki
+  MKDIRagons hp -c leki damage 7
+  MKDIRagons hp -c leki heal 4
+  MKDIRagons hp -c leki temp 5`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPlayState(nil)
+	},
+}
+
+var hpDamageCmd = &cobra.Command{
+	Use:     "damage <amount>",
+	Short:   "Take damage, temporary hit points absorb it first",
+	Long:    `Takes damage, which temporary hit points absorb first. With --type the character's resistances, immunities and vulnerabilities halve, prevent or double it.`,
+	Example: `  MKDIRagons hp -c leki damage 12 --type fire`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := amountArg(args)
+		if err != nil {
+			return err
+		}
+		if hpCompanion != "" {
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				return state.DamageCompanion(hpCompanion, amount)
+			})
+		}
+		if damageType == "" {
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				return state.Damage(amount)
+			})
+		}
+
+		var fetched damage.DamageType
+		if err := damage.FetchDamageType(damageType, &fetched); err != nil {
+			return fmt.Errorf("unknown damage type %q: %w", damageType, err)
+		}
+		return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+			defenses := char.Defenses
+			if defenses == nil {
+				// Characters saved before defenses were derived
+				defenses = char.BuildDefenses()
+			}
+			return state.TypedDamage(amount, fetched.Name, defenses)
+		})
+	},
+}
+
+var hpHealCmd = &cobra.Command{
+	Use:   "heal <amount>",
+	Short: "Regain hit points, up to max HP",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := amountArg(args)
+		if err != nil {
+			return err
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			if hpCompanion != "" {
+				return state.HealCompanion(hpCompanion, amount)
+			}
+			return state.Heal(amount)
+		})
+	},
+}
+
+var hpTempCmd = &cobra.Command{
+	Use:   "temp <amount>",
+	Short: "Gain temporary hit points (the higher value is kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := amountArg(args)
+		if err != nil {
+			return err
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.SetTempHP(amount)
+		})
+	},
+}
+
+var slotCmd = &cobra.Command{
+	Use:     "slot use <level>",
+	Short:   "Expend a spell slot",
+	Example: `  MKDIRagons slot -c leki use 3`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "use" {
+			return fmt.Errorf("unknown slot action %q, expected \"use\"", args[0])
+		}
+		level, err := amountArg(args[1:])
+		if err != nil {
+			return err
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.UseSlot(level)
+		})
+	},
+}
+
+var resourceCmd = &cobra.Command{
+	Use:     "resource use <name>",
+	Short:   "Expend a use of a class resource such as Rage, Ki or Channel Divinity",
+	Example: `  MKDIRagons resource -c leki use "channel divinity"`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "use" {
+			return fmt.Errorf("unknown resource action %q, expected \"use\"", args[0])
+		}
+		name := strings.Join(args[1:], " ")
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.UseResource(name)
+		})
+	},
+}
+
+var castCmd = &cobra.Command{
+	Use:   "cast <spell>",
+	Short: "Cast a known spell, spending its slot and starting concentration",
+	Long: `Casts a spell the character knows or has prepared: a slot of the spell's level (or --slot) is spent, and
+a concentration spell ends concentration on any other spell. Bards, Clerics, Druids and Wizards can cast ritual
+spells with --ritual instead, without a slot. Costly material components, such as Revivify's diamonds, have to
+be among the character's valuables. Roll its attack or damage with roll -c <character> cast <spell>.`,
+	Example: `  MKDIRagons cast -c leki bless
+  MKDIRagons cast -c leki "spiritual weapon" --slot 3
+  MKDIRagons cast -c leki "detect magic" --ritual`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args, " ")
+		return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+			if castRitual {
+				return state.CastRitual(char, name)
+			}
+			return state.Cast(char, name, castSlot)
+		})
+	},
+}
+
+var concentrationCmd = &cobra.Command{
+	Use:   "concentration save|end",
+	Short: "Roll a Constitution save to keep concentrating, or end concentration",
+	Long: `Taking damage while concentrating owes a Constitution save of DC 10 or half the damage, whichever is
+higher. "save" rolls the oldest save owed, ending concentration on a failure, and "end" stops concentrating.
+Dropping to 0 HP or becoming incapacitated ends concentration on its own.`,
+	Example: `  MKDIRagons concentration -c leki save
+  MKDIRagons concentration -c leki end`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "save":
+			roller := dice.NewRandomRoller()
+			if cmd.Flags().Changed("seed") {
+				roller = dice.NewRoller(concSeed)
+			}
+			return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+				return state.ConcentrationSave(char, roller)
+			})
+		case "end":
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				return state.EndConcentration()
+			})
+		default:
+			return fmt.Errorf("unknown concentration action %q, expected \"save\" or \"end\"", args[0])
+		}
+	},
+}
+
+var useCmd = &cobra.Command{
+	Use:   "use <supply>",
+	Short: "Spend ammunition, a thrown weapon or a consumable such as Alchemist's Fire",
+	Example: `  MKDIRagons use -c leki arrow
+  MKDIRagons use -c leki "healer's kit" --count 2`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args, " ")
+		count := supplyCount
+		if !cmd.Flags().Changed("count") {
+			count = 1
+		}
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.UseSupply(name, count)
+		})
+	},
+}
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover <supply>",
+	Short: "Recover spent ammunition or thrown weapons after a fight",
+	Long: `Recovers spent ammunition or thrown weapons. Without --count half of the spent ammunition
+(rounded down) or every thrown weapon is recovered.`,
+	Example: `  MKDIRagons recover -c leki arrow
+  MKDIRagons recover -c leki javelin --count 1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args, " ")
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.RecoverSupply(name, supplyCount)
+		})
+	},
+}
+
+var shortRestCmd = &cobra.Command{
+	Use:     "short-rest",
+	Short:   "Take a short rest, optionally spending hit dice to heal",
+	Example: `  MKDIRagons short-rest -c leki --dice 2`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roller := dice.NewRandomRoller()
+		if cmd.Flags().Changed("seed") {
+			roller = dice.NewRoller(restSeed)
+		}
+		return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+			return state.ShortRest(char, restDice, roller)
+		})
+	},
+}
+
+var longRestCmd = &cobra.Command{
+	Use:   "long-rest",
+	Short: "Take a long rest, restoring HP, spell slots, class resources and half of the hit dice",
+	Long: `Takes a long rest. Clerics, Druids, Paladins and Wizards can swap their prepared spells
+with --prepare, up to their spellcasting modifier plus their level.`,
+	Example: `  MKDIRagons long-rest -c leki
+  MKDIRagons long-rest -c leki --prepare "bless,cure wounds,spiritual weapon"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+			summary, err := state.LongRest()
+			if err != nil || len(restPrepare) == 0 {
+				return summary, err
+			}
+			// A failed swap returns before the state is saved, so the rest isn't kept either
+			prepared, err := state.Prepare(char, restPrepare)
+			if err != nil {
+				return "", err
+			}
+			return summary + " and " + prepared, nil
+		})
+	},
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last change to a character's play state",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+			return state.Undo()
+		})
+	},
+}
+
+func init() {
+	// Add the play commands to the root
+	hpCmd.AddCommand(hpDamageCmd, hpHealCmd, hpTempCmd)
+	rootCmd.AddCommand(hpCmd, slotCmd, resourceCmd, castCmd, concentrationCmd, useCmd, recoverCmd, shortRestCmd, longRestCmd, undoCmd)
+
+	// --character -c flag shared by every play command
+	for _, c := range []*cobra.Command{hpCmd, slotCmd, resourceCmd, castCmd, concentrationCmd, useCmd, recoverCmd, shortRestCmd, longRestCmd, undoCmd} {
+		c.PersistentFlags().StringVarP(&playCharacter, "character", "c", "", "JSON character to track (looked up in characters/)")
+	}
+
+	// --companion flag for tracking a companion's hit points instead
+	hpCmd.PersistentFlags().StringVar(&hpCompanion, "companion", "", "Name of the companion taking the damage or healing")
+
+	// --type flag for applying resistances, immunities and vulnerabilities
+	hpDamageCmd.Flags().StringVarP(&damageType, "type", "t", "", "Damage type, e.g. fire or slashing")
+
+	// --count -n flag for how much of a supply is used or recovered
+	for _, c := range []*cobra.Command{useCmd, recoverCmd} {
+		c.Flags().IntVarP(&supplyCount, "count", "n", 0, "How many to use (default 1) or recover (default half of the spent ammunition)")
+	}
+
+	// Casting with a higher slot and rolling concentration saves
+	castCmd.Flags().IntVar(&castSlot, "slot", 0, "Spell slot level to cast with (defaults to the spell's level)")
+	castCmd.Flags().BoolVar(&castRitual, "ritual", false, "Cast the spell as a ritual, without a slot")
+	concentrationCmd.Flags().Uint64Var(&concSeed, "seed", 0, "Seed for the save (random if not set)")
+
+	// --prepare flag for swapping prepared spells at the end of a long rest
+	longRestCmd.Flags().StringSliceVar(&restPrepare, "prepare", nil, "Comma separated list of spells to prepare")
+
+	// Short rest hit dice
+	shortRestCmd.Flags().IntVar(&restDice, "dice", 0, "Number of hit dice to spend")
+	shortRestCmd.Flags().Uint64Var(&restSeed, "seed", 0, "Seed for the hit dice (random if not set)")
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollSeed      uint64
+	rollJSON      bool
+	rollCharacter string
+	rollAdv       bool
+	rollDis       bool
+	rollSlot      int
+)
+
+var rollCmd = &cobra.Command{
+	Use:   "roll <expression>",
+	Short: "Roll a dice expression, or a check for a saved character",
+	Long: `Rolls a dice expression and prints each die and the total.
+Supports NdS, d% (d100), + and - modifiers, keep/drop (4d6kh3, 2d20kl1, 4d6dl1),
+advantage/disadvantage on a d20 (1d20adv, 1d20dis) and exploding dice (1d6!).
+
+With --character the arguments name a roll for that character instead:
+  <skill>            a skill check, e.g. stealth or "sleight of hand"
+  save <ability>     a saving throw, e.g. save dex
+  attack <weapon>    an attack roll and damage with a weapon in the inventory
+  cast <spell>       a spell attack or save DC and damage, --slot casts it at a higher level`,
+	Example: `  MKDIRagons roll "1d20+5"
+  MKDIRagons roll 4d6kh3
+  MKDIRagons roll 1d20adv --json
+  MKDIRagons roll -c leki.json stealth
+  MKDIRagons roll -c leki.json save wis --adv
+  MKDIRagons roll -c leki.json attack mace
+  MKDIRagons roll -c leki.json cast "guiding bolt" --slot 2`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roller := dice.NewRandomRoller()
+		if cmd.Flags().Changed("seed") {
+			roller = dice.NewRoller(rollSeed)
+		}
+
+		if rollCharacter == "" {
+			result, err := dice.Eval(roller, strings.Join(args, " "), "roll")
+			if err != nil {
+				return err
+			}
+			return printRoll(result)
+		}
+
+		charPath := characterPath(rollCharacter)
+		char, err := io.LoadCharacter(charPath)
+		if err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+
+		// Wild Shape, conditions, exhaustion, prepared spells, spent components and remaining ammunition from the play state
+		state, err := io.LoadState(io.StatePath(charPath), char)
+		if err != nil {
+			return fmt.Errorf("failed to load play state: %w", err)
+		}
+		char = state.WithSpent(state.WithPrepared(state.Shaped(char)))
+
+		check, err := characterCheck(char, state, args)
+		if err != nil {
+			return err
+		}
+		state.ApplyEffects(&check)
+		state.ApplySupplies(&check)
+
+		// The flags are more sources, resolved with the rest when rolling
+		check.Advantage = check.Advantage || rollAdv
+		check.Disadvantage = check.Disadvantage || rollDis
+
+		result, err := char.RollCheck(check, roller)
+		if err != nil {
+			return err
+		}
+
+		return printRoll(result)
+	},
+}
+
+// characterPath resolves a JSON character name to a path under characters/
+func characterPath(path string) string {
+	return jsonPath("characters/", path)
+}
+
+// jsonPath resolves a bare name to a JSON file under dir, leaving paths with a directory as they are
+func jsonPath(dir, path string) string {
+	if !strings.Contains(path, "/") {
+		path = dir + path
+	}
+	if !strings.HasSuffix(path, ".json") {
+		path += ".json"
+	}
+	return path
+}
+
+// characterCheck turns the roll arguments into a check for the character, attacking with the beast in Wild Shape
+func characterCheck(char *character.Character, state *play.State, args []string) (character.Check, error) {
+	rest := strings.Join(args[1:], " ")
+
+	switch strings.ToLower(args[0]) {
+	case "save":
+		ability, err := core.ParseAbility(rest)
+		if err != nil {
+			return character.Check{}, err
+		}
+		return char.SavingThrow(ability), nil
+	case "attack":
+		if state.WildShape != nil {
+			return state.WildShapeAttack(rest)
+		}
+		return char.WeaponAttack(rest)
+	case "cast":
+		if state.WildShape != nil {
+			return character.Check{}, fmt.Errorf("%s can't cast spells while in Wild Shape", char.Name)
+		}
+		return char.SpellCast(rest, rollSlot)
+	default:
+		return char.SkillCheck(strings.Join(args, " "))
+	}
+}
+
+// printRoll prints a roll result as a breakdown, or as JSON with --json
+func printRoll(result any) error {
+	if rollJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal roll: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(result)
+	return nil
+}
+
+func init() {
+	// Add the roll command to the root
+	rootCmd.AddCommand(rollCmd)
+
+	// --seed flag for reproducible rolls
+	rollCmd.Flags().Uint64Var(&rollSeed, "seed", 0, "Seed for the dice (random if not set)")
+
+	// --json flag for machine readable output
+	rollCmd.Flags().BoolVar(&rollJSON, "json", false, "Print the roll as JSON")
+
+	// --character -c flag for rolling checks, saves, attacks and spells of a saved character
+	rollCmd.Flags().StringVarP(&rollCharacter, "character", "c", "", "JSON character to roll for (looked up in characters/)")
+
+	// Advantage, disadvantage and upcasting
+	rollCmd.Flags().BoolVar(&rollAdv, "adv", false, "Roll with advantage")
+	rollCmd.Flags().BoolVar(&rollDis, "dis", false, "Roll with disadvantage")
+	rollCmd.Flags().IntVar(&rollSlot, "slot", 0, "Spell slot level to cast with (defaults to the spell's level)")
+}
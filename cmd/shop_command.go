@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/template"
+	"github.com/spf13/cobra"
+)
+
+var shopCharacter string
+
+var shopCmd = &cobra.Command{
+	Use:   "shop buy|sell <equipment>",
+	Short: "Buy or sell SRD equipment with a character's wallet",
+	Long: `Buys or sells equipment at its SRD cost, converting coins and giving change automatically.
+Entries can carry a quantity, e.g. "Arrow x20". Equipment sells for half its cost.
+Without arguments the character's wallet is shown.`,
+	Example: `  MKDIRagons shop -c leki
+  MKDIRagons shop -c leki buy "Arrow x20"
+  MKDIRagons shop -c leki sell dagger`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if shopCharacter == "" {
+			return fmt.Errorf("please provide a JSON character with --character")
+		}
+		charPath := characterPath(shopCharacter)
+
+		char, err := io.LoadCharacter(charPath)
+		if err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+
+		if len(args) == 0 {
+			fmt.Printf("%s's wallet: %s\n", char.Name, char.Inventory.Wallet)
+			return nil
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("please name the equipment to %s", args[0])
+		}
+		entry := strings.Join(args[1:], " ")
+
+		var summary string
+		switch args[0] {
+		case "buy":
+			goods, err := inventory.FetchEquipment(entry)
+			if err != nil {
+				return fmt.Errorf("unknown equipment %q: %w", entry, err)
+			}
+			price, err := char.Inventory.Buy(goods)
+			if err != nil {
+				return fmt.Errorf("can't buy %s: %w", entry, err)
+			}
+			summary = fmt.Sprintf("bought %s for %s", entry, inventory.FormatCopper(price))
+		case "sell":
+			name, count := template.ParseQuantity(entry)
+			received, err := char.Inventory.Sell(name, count)
+			if err != nil {
+				return fmt.Errorf("can't sell %s: %w", entry, err)
+			}
+			summary = fmt.Sprintf("sold %s for %s", entry, inventory.FormatCopper(received))
+		default:
+			return fmt.Errorf("unknown shop action %q, expected \"buy\" or \"sell\"", args[0])
+		}
+
+		// Armor changes AC and magic items change defenses
+		char.RefreshDerived()
+
+		if err := io.WriteJSON(char, charPath); err != nil {
+			return fmt.Errorf("failed to save character as JSON: %w", err)
+		}
+		fmt.Printf("✓ %s %s, wallet: %s\n", char.Name, summary, char.Inventory.Wallet)
+		return nil
+	},
+}
+
+var stowContainer string
+
+var stowCmd = &cobra.Command{
+	Use:   "stow <equipment>",
+	Short: "Stow equipment in a container such as a backpack, or take it out",
+	Long: `Organizes a character's inventory by moving equipment into a carried container (backpack, pouch, sack,
+chest, basket or an equipment pack). Containers with a capacity refuse gear that doesn't fit.
+Without --in the equipment is taken out of its container. Names pick loose gear first, while a path
+such as explorers-pack/backpack picks the backpack stowed in the pack.`,
+	Example: `  MKDIRagons stow -c leki "Rope, hempen (50 feet)" --in backpack
+  MKDIRagons stow -c leki torch --in explorers-pack/backpack
+  MKDIRagons stow -c leki dagger`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withCharacter(func(char *character.Character) (string, error) {
+			return char.Inventory.Stow(strings.Join(args, " "), stowContainer)
+		})
+	},
+}
+
+var attuneEnd bool
+
+var attuneCmd = &cobra.Command{
+	Use:   "attune <magic item>",
+	Short: "Attune to a magic item, or end the attunement",
+	Long: fmt.Sprintf(`Attunes a character to one of their magic items so its effects apply, up to %d items at once.
+With --end the attunement is ended.`, inventory.MaxAttuned),
+	Example: `  MKDIRagons attune -c leki "Cloak of Protection"
+  MKDIRagons attune -c leki "Cloak of Protection" --end`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.Join(args, " ")
+		return withCharacter(func(char *character.Character) (string, error) {
+			if attuneEnd {
+				return char.Inventory.Unattune(name)
+			}
+			return char.Inventory.Attune(name)
+		})
+	},
+}
+
+// withCharacter loads the --character JSON, applies change, re-derives its stats and saves it
+func withCharacter(change func(char *character.Character) (string, error)) error {
+	if shopCharacter == "" {
+		return fmt.Errorf("please provide a JSON character with --character")
+	}
+	charPath := characterPath(shopCharacter)
+
+	char, err := io.LoadCharacter(charPath)
+	if err != nil {
+		return fmt.Errorf("failed to load character: %w", err)
+	}
+
+	summary, err := change(char)
+	if err != nil {
+		return err
+	}
+	char.RefreshDerived()
+
+	if err := io.WriteJSON(char, charPath); err != nil {
+		return fmt.Errorf("failed to save character as JSON: %w", err)
+	}
+	fmt.Printf("✓ %s %s\n", char.Name, summary)
+	char.Inventory.Print()
+	return nil
+}
+
+func init() {
+	// Add the shop commands to the root
+	rootCmd.AddCommand(shopCmd, stowCmd, attuneCmd)
+
+	// --character -c flag for the character being updated
+	for _, c := range []*cobra.Command{shopCmd, stowCmd, attuneCmd} {
+		c.Flags().StringVarP(&shopCharacter, "character", "c", "", "JSON character to update (looked up in characters/)")
+	}
+
+	// --in flag for the container to stow equipment in
+	stowCmd.Flags().StringVar(&stowContainer, "in", "", "Container to stow the equipment in (takes it out when not set)")
+
+	// --end flag for ending an attunement
+	attuneCmd.Flags().BoolVar(&attuneEnd, "end", false, "End the attunement instead")
+}
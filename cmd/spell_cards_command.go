@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/cards"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cardsCharacter string
+	cardsPrepared  bool
+	cardsLevels    []int
+	cardsOutput    string
+)
+
+var spellCardsCmd = &cobra.Command{
+	Use:   "spell-cards",
+	Short: "Export a character's spells as printable HTML cards",
+	Long: `Writes a card for each of a saved character's spells to an HTML page, nine playing card sized
+cards to a page. Long descriptions continue on extra cards. Open the page in a browser and print it,
+or print to PDF. --prepared keeps only the spells the character can cast today, using the prepared
+list from the play state, and --level keeps only some spell levels (0 for cantrips).`,
+	Example: `  MKDIRagons spell-cards -c leki
+  MKDIRagons spell-cards -c leki --prepared
+  MKDIRagons spell-cards -c leki --level 0,1 -o leki-low.html`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cardsCharacter == "" {
+			return fmt.Errorf("please choose a character with --character")
+		}
+		charPath := characterPath(cardsCharacter)
+		char, err := io.LoadCharacter(charPath)
+		if err != nil {
+			return fmt.Errorf("failed to load character: %w", err)
+		}
+
+		// Spells prepared after the last long rest
+		state, err := io.LoadState(io.StatePath(charPath), char)
+		if err != nil {
+			return fmt.Errorf("failed to load play state: %w", err)
+		}
+		char = state.WithPrepared(char)
+
+		list := cards.Cards(char, cards.Filter{Prepared: cardsPrepared, Levels: cardsLevels})
+		if len(list) == 0 {
+			return fmt.Errorf("%s has no spells to put on cards", char.Name)
+		}
+
+		path := cardsOutput
+		if path == "" {
+			name := strings.TrimSuffix(filepath.Base(charPath), ".json")
+			path = filepath.Join("spellcards", name+".html")
+		}
+		if err := io.WriteSpellCards(list, char.Name+"'s spells", path); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Cards for %d spells saved to: %s\n", len(list), path)
+		return nil
+	},
+}
+
+func init() {
+	// Add the spell cards command to the root
+	rootCmd.AddCommand(spellCardsCmd)
+
+	// --character -c flag for the saved character
+	spellCardsCmd.Flags().StringVarP(&cardsCharacter, "character", "c", "", "JSON character to make cards for (looked up in characters/)")
+
+	// Filters
+	spellCardsCmd.Flags().BoolVar(&cardsPrepared, "prepared", false, "Only spells the character can cast today")
+	spellCardsCmd.Flags().IntSliceVar(&cardsLevels, "level", nil, "Only spells of these levels, 0 for cantrips (e.g. 0,1)")
+
+	// --output -o flag for the HTML file
+	spellCardsCmd.Flags().StringVarP(&cardsOutput, "output", "o", "", "Path to the HTML file (defaults to spellcards/<character>.html)")
+}
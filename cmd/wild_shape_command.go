@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/spf13/cobra"
+)
+
+var wildShapeEnd bool
+
+var wildShapeCmd = &cobra.Command{
+	Use:   "wild-shape <beast>",
+	Short: "Turn a druid into an SRD beast with Wild Shape, or revert",
+	Long: `Spends a use of Wild Shape to turn a druid into a beast, checked against the druid's level
+(challenge rating, no swimming or flying speed early on). While transformed, roll -c uses the beast's
+Strength, Dexterity, Constitution, AC and attacks, damage comes off the beast's hit points first and the
+druid reverts when they run out, carrying over the rest. With --end the druid reverts.`,
+	Example: `  MKDIRagons wild-shape -c ash wolf
+  MKDIRagons roll -c ash attack bite
+  MKDIRagons wild-shape -c ash --end`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if wildShapeEnd {
+			return withPlayState(func(_ *character.Character, state *play.State) (string, error) {
+				return state.EndWildShape()
+			})
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("please name the beast to turn into")
+		}
+		name := strings.Join(args, " ")
+
+		var beast monster.Monster
+		if err := monster.FetchMonster(name, &beast); err != nil {
+			return fmt.Errorf("unknown beast %q: %w", name, err)
+		}
+		return withPlayState(func(char *character.Character, state *play.State) (string, error) {
+			return state.StartWildShape(char, &beast)
+		})
+	},
+}
+
+func init() {
+	// Add the wild shape command to the root
+	rootCmd.AddCommand(wildShapeCmd)
+
+	// --character -c flag shared with the other play commands
+	wildShapeCmd.Flags().StringVarP(&playCharacter, "character", "c", "", "JSON character to track (looked up in characters/)")
+
+	// --end flag for reverting to the druid's normal form
+	wildShapeCmd.Flags().BoolVar(&wildShapeEnd, "end", false, "Revert to the druid's normal form")
+}
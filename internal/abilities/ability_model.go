@@ -16,27 +16,48 @@ type AbilityScores struct {
 	Charisma     int
 }
 
-// Modifier takes an ability and returns the modifier
-func (ab *AbilityScores) Modifier(a core.Ability) int {
-	// Get the value for a given ability score
-	var score int
+// Score returns the raw value of the given ability score
+func (ab *AbilityScores) Score(a core.Ability) int {
 	switch a {
 	case core.Strength:
-		score = ab.Strength
+		return ab.Strength
 	case core.Dexterity:
-		score = ab.Dexterity
+		return ab.Dexterity
 	case core.Constitution:
-		score = ab.Constitution
+		return ab.Constitution
 	case core.Intelligence:
-		score = ab.Intelligence
+		return ab.Intelligence
 	case core.Wisdom:
-		score = ab.Wisdom
+		return ab.Wisdom
 	case core.Charisma:
-		score = ab.Charisma
+		return ab.Charisma
+	default:
+		return 0
 	}
+}
 
+// SetScore overwrites the value of the given ability score
+func (ab *AbilityScores) SetScore(a core.Ability, value int) {
+	switch a {
+	case core.Strength:
+		ab.Strength = value
+	case core.Dexterity:
+		ab.Dexterity = value
+	case core.Constitution:
+		ab.Constitution = value
+	case core.Intelligence:
+		ab.Intelligence = value
+	case core.Wisdom:
+		ab.Wisdom = value
+	case core.Charisma:
+		ab.Charisma = value
+	}
+}
+
+// Modifier takes an ability and returns the modifier
+func (ab *AbilityScores) Modifier(a core.Ability) int {
 	// Formula to calculate the bonus: (ability score value - 10)/2, rounded down
-	return int(math.Floor(float64(score-10) / 2.0))
+	return int(math.Floor(float64(ab.Score(a)-10) / 2.0))
 }
 
 // Print for Fetchable interface methods
@@ -246,6 +246,21 @@ func TestAbilityScoreModification(t *testing.T) {
 	assert.Equal(t, 3, scores.Modifier(core.Strength))
 }
 
+// TestScoreAndSetScore tests reading and writing scores through the Ability enum
+func TestScoreAndSetScore(t *testing.T) {
+	scores := &abilities.AbilityScores{Wisdom: 15}
+
+	assert.Equal(t, 15, scores.Score(core.Wisdom))
+	assert.Equal(t, 0, scores.Score(core.Charisma))
+
+	scores.SetScore(core.Charisma, 18)
+	assert.Equal(t, 18, scores.Charisma)
+	assert.Equal(t, 4, scores.Modifier(core.Charisma))
+
+	scores.SetScore(core.Wisdom, scores.Score(core.Wisdom)+1)
+	assert.Equal(t, 16, scores.Wisdom)
+}
+
 // TestNegativeAbilityScores tests negative ability scores (unusual but possible)
 func TestNegativeAbilityScores(t *testing.T) {
 	scores := &abilities.AbilityScores{
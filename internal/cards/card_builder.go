@@ -0,0 +1,80 @@
+package cards
+
+import (
+	"html/template"
+	"io"
+)
+
+// page is what the HTML template renders: a title and the cards of each printed page
+type page struct {
+	Title string
+	Pages [][]Card
+}
+
+// cardsHTML lays cards out at playing card size, 2.5 by 3.5 inches, three by three on a letter page
+var cardsHTML = template.Must(template.New("cards").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  @page { size: letter; margin: 0.25in; }
+  body { margin: 0; font-family: Georgia, serif; color: #222; }
+  .page { display: grid; grid-template-columns: repeat(3, 2.5in); grid-auto-rows: 3.5in; gap: 0.1in;
+    justify-content: center; padding: 0.1in 0; page-break-after: always; break-after: page; }
+  .page:last-child { page-break-after: auto; break-after: auto; }
+  .card { box-sizing: border-box; border: 2px solid #7a1f1f; border-radius: 8px; padding: 0.08in;
+    display: flex; flex-direction: column; overflow: hidden; font-size: 6.5pt; line-height: 1.25; }
+  .card h2 { margin: 0; font-size: 10pt; color: #7a1f1f; }
+  .level { font-style: italic; margin-bottom: 0.04in; }
+  .stats { display: grid; grid-template-columns: 1fr 1fr; gap: 1px; margin-bottom: 0.04in; }
+  .stats div { background: #f3e9e0; padding: 1px 3px; }
+  .stats b { display: block; font-size: 5.5pt; text-transform: uppercase; color: #7a1f1f; }
+  .tags span { display: inline-block; border: 1px solid #7a1f1f; border-radius: 3px; padding: 0 3px; margin: 0 2px 2px 0; font-size: 5.5pt; }
+  .text { flex: 1; overflow: hidden; }
+  .text p { margin: 0 0 0.03in; }
+  .footer { border-top: 1px solid #7a1f1f; margin-top: 0.03in; font-size: 5.5pt; text-align: right; }
+</style>
+</head>
+<body>
+{{- range .Pages}}
+<section class="page">
+{{- range .}}
+  <article class="card">
+    <h2>{{.Name}}{{if .Continued}} (continued){{end}}</h2>
+    {{- if not .Continued}}
+    <div class="level">{{.Level}}</div>
+    <div class="stats">
+      <div><b>Casting Time</b>{{.CastingTime}}</div>
+      <div><b>Range</b>{{.Range}}</div>
+      <div><b>Components</b>{{.Components}}</div>
+      <div><b>Duration</b>{{.Duration}}</div>
+    </div>
+    {{- if .Tags}}
+    <div class="tags">{{range .Tags}}<span>{{.}}</span>{{end}}</div>
+    {{- end}}
+    {{- end}}
+    <div class="text">
+      {{- range .Desc}}
+      <p>{{.}}</p>
+      {{- end}}
+      {{- if .HigherLevel}}
+      <p><b>At Higher Levels.</b></p>
+      {{- range .HigherLevel}}
+      <p>{{.}}</p>
+      {{- end}}
+      {{- end}}
+    </div>
+    <div class="footer">{{.Footer}}</div>
+  </article>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// Render writes the cards as a standalone HTML page, ready to print
+func Render(w io.Writer, title string, cards []Card) error {
+	return cardsHTML.Execute(w, page{Title: title, Pages: Pages(cards)})
+}
@@ -0,0 +1,34 @@
+package cards_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	list := []cards.Card{{
+		Name:        "Bless",
+		Level:       "1st-level enchantment",
+		Tags:        []string{"Concentration"},
+		Desc:        []string{"You bless up to three creatures <of your choice>."},
+		HigherLevel: []string{"One more creature for each slot level above 1st."},
+		Footer:      "Leki, level 1 Cleric",
+	}}
+	var sb strings.Builder
+
+	require.NoError(t, cards.Render(&sb, "Leki's spells", list))
+	html := sb.String()
+
+	assert.Contains(t, html, "<title>Leki&#39;s spells</title>")
+	assert.Contains(t, html, "@page")
+	assert.Contains(t, html, "<h2>Bless</h2>")
+	assert.Contains(t, html, "<span>Concentration</span>")
+	assert.Contains(t, html, "&lt;of your choice&gt;")
+	assert.Contains(t, html, "At Higher Levels.")
+	assert.Contains(t, html, "Leki, level 1 Cleric")
+	assert.Equal(t, 1, strings.Count(html, `<section class="page">`))
+}
@@ -0,0 +1,209 @@
+package cards
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+)
+
+// Card is a printable spell card
+type Card struct {
+	Name        string
+	Level       string // e.g. "3rd-level evocation" or "Evocation cantrip"
+	CastingTime string
+	Range       string
+	Components  string // e.g. "V, S, M (a tiny ball of bat guano and sulfur)"
+	Duration    string
+	Tags        []string // Concentration, Ritual, Prepared or Always prepared
+	Desc        []string
+	HigherLevel []string
+	Footer      string // The character and class the card is for
+	Continued   bool   // A continuation card holding the rest of a long description
+}
+
+// Filter picks which of a character's spells get cards
+type Filter struct {
+	Prepared bool  // Only spells the character can cast today
+	Levels   []int // Only spells of these levels, 0 for cantrips
+}
+
+// NewCard lays out a spell's details on a card
+func NewCard(spell *spells.Spell) Card {
+	school := strings.ToLower(spell.School.Name)
+	level := fmt.Sprintf("%s-level %s", core.Ordinal(spell.Level), school)
+	if spell.Level == 0 {
+		level = strings.TrimSpace(spell.School.Name + " cantrip")
+	}
+
+	components := strings.Join(spell.Components, ", ")
+	if spell.Material != "" {
+		components += fmt.Sprintf(" (%s)", strings.TrimSuffix(spell.Material, "."))
+	}
+
+	card := Card{
+		Name:        spell.Name,
+		Level:       strings.TrimSpace(level),
+		CastingTime: spell.CastingTime,
+		Range:       spell.Range,
+		Components:  components,
+		Duration:    spell.Duration,
+		Desc:        spell.Desc,
+		HigherLevel: spell.HigherLevel,
+	}
+	if spell.Concentration {
+		card.Tags = append(card.Tags, "Concentration")
+	}
+	if spell.Ritual {
+		card.Tags = append(card.Tags, "Ritual")
+	}
+	return card
+}
+
+// Cards makes a card for each of the character's spells that pass the filter, in level order
+func Cards(c *character.Character, filter Filter) []Card {
+	preparing := c.Class.Preparation() == class.PreparedSpells || c.Class.Preparation() == class.SpellbookSpells
+	footer := strings.TrimSpace(fmt.Sprintf("%s, level %d %s", c.Name, c.Level, c.Class.Name))
+
+	var cards []Card
+	for level := range c.Spells {
+		if len(filter.Levels) > 0 && !slices.Contains(filter.Levels, level) {
+			continue
+		}
+		for i := range c.Spells[level] {
+			spell := &c.Spells[level][i]
+			castable := c.CanCast(spell) == nil
+			if filter.Prepared && !castable {
+				continue
+			}
+
+			card := NewCard(spell)
+			card.Footer = footer
+			switch {
+			case c.IsAlwaysPrepared(spell):
+				card.Tags = append(card.Tags, "Always prepared")
+			case preparing && level > 0 && castable:
+				card.Tags = append(card.Tags, "Prepared")
+			}
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// CardText is about how many characters fit in the text area of a card, before its header takes a share
+const CardText = 900
+
+// lineWidth is about how many characters fit on one line of a card
+const lineWidth = 50
+
+// lines estimates how many lines text takes at width characters per line
+func lines(text string, width int) int {
+	return max(1, (len(text)+width-1)/width)
+}
+
+// Room is how much of limit is left for the description once the header is laid out:
+// the level line, the two rows of the stats grid with their labels, and the tags.
+// Continuation cards only repeat the name, so they get all of it.
+func (card Card) Room(limit int) int {
+	if card.Continued {
+		return limit
+	}
+	half := lineWidth / 2
+	rows := 1
+	rows += 1 + max(lines(card.CastingTime, half), lines(card.Range, half))
+	rows += 1 + max(lines(card.Components, half), lines(card.Duration, half))
+	if len(card.Tags) > 0 {
+		rows++
+	}
+	return max(limit-rows*lineWidth, 2*lineWidth)
+}
+
+// TextSize is how much room the card's description takes, counted in whole lines,
+// with a line for the "At Higher Levels." heading
+func (card Card) TextSize() int {
+	size := 0
+	for _, p := range append(slices.Clone(card.Desc), card.HigherLevel...) {
+		size += lines(p, lineWidth) * lineWidth
+	}
+	if len(card.HigherLevel) > 0 {
+		size += lineWidth
+	}
+	return size
+}
+
+// Split breaks a card whose description doesn't fit into the card and continuation cards.
+// Each card is filled up to its Room, breaking paragraphs between words where they don't fit.
+func (card Card) Split(limit int) []Card {
+	current := card
+	current.Desc, current.HigherLevel = nil, nil
+	var split []Card
+	next := func() {
+		split = append(split, current)
+		current = Card{Name: card.Name, Footer: card.Footer, Continued: true}
+	}
+
+	add := func(p string, higher bool) {
+		for p != "" {
+			room := current.Room(limit) - current.TextSize()
+			if higher && len(current.HigherLevel) == 0 {
+				room -= lineWidth
+			}
+			// Start a new card rather than leave a sliver of a paragraph at the bottom
+			if room < 2*lineWidth && current.TextSize() > 0 {
+				next()
+				continue
+			}
+
+			piece, rest := cut(p, max(room, lineWidth)/lineWidth*lineWidth)
+			if higher {
+				current.HigherLevel = append(current.HigherLevel, piece)
+			} else {
+				current.Desc = append(current.Desc, piece)
+			}
+			p = rest
+		}
+	}
+	for _, p := range card.Desc {
+		add(p, false)
+	}
+	for _, p := range card.HigherLevel {
+		add(p, true)
+	}
+	return append(split, current)
+}
+
+// cut splits a paragraph after at most n characters, between words where it can
+func cut(paragraph string, n int) (string, string) {
+	if len(paragraph) <= n {
+		return paragraph, ""
+	}
+	at := strings.LastIndex(paragraph[:n], " ")
+	if at <= 0 {
+		at = n
+	}
+	return strings.TrimSpace(paragraph[:at]), strings.TrimSpace(paragraph[at:])
+}
+
+// PerPage is how many cards fit on a printed page, three rows of three
+const PerPage = 9
+
+// Pages splits long cards and groups every card into printed pages
+func Pages(cards []Card) [][]Card {
+	var all []Card
+	for _, card := range cards {
+		all = append(all, card.Split(CardText)...)
+	}
+
+	var pages [][]Card
+	for len(all) > 0 {
+		n := min(PerPage, len(all))
+		pages = append(pages, all[:n])
+		all = all[n:]
+	}
+	return pages
+}
@@ -0,0 +1,164 @@
+package cards_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/cards"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cleric builds a level 1 cleric with a cantrip, three level 1 spells and Bless prepared
+func cleric() *character.Character {
+	return &character.Character{
+		Name:  "Leki",
+		Level: 1,
+		Class: class.Class{
+			Name:         "Cleric",
+			Spellcasting: class.Spellcasting{SpellcastingAbility: reference.Reference{Index: "wis", Name: "WIS"}},
+		},
+		AbilityScores: abilities.AbilityScores{Wisdom: 14},
+		Spells: [][]spells.Spell{
+			{{Name: "Sacred Flame", School: reference.Reference{Name: "Evocation"}}},
+			{
+				{Name: "Bless", Level: 1, Concentration: true},
+				{Name: "Cure Wounds", Level: 1},
+				{Name: "Shield of Faith", Level: 1},
+			},
+		},
+		Prepared:       []string{"Bless"},
+		AlwaysPrepared: []string{"Shield of Faith"},
+	}
+}
+
+func TestNewCard(t *testing.T) {
+	fireball := &spells.Spell{
+		Name:        "Fireball",
+		Level:       3,
+		School:      reference.Reference{Name: "Evocation"},
+		CastingTime: "1 action",
+		Range:       "150 feet",
+		Components:  []string{"V", "S", "M"},
+		Material:    "A tiny ball of bat guano and sulfur.",
+		Duration:    "Instantaneous",
+		Desc:        []string{"A bright streak flashes from your pointing finger."},
+	}
+
+	card := cards.NewCard(fireball)
+
+	assert.Equal(t, "3rd-level evocation", card.Level)
+	assert.Equal(t, "V, S, M (A tiny ball of bat guano and sulfur)", card.Components)
+	assert.Empty(t, card.Tags)
+
+	ritual := cards.NewCard(&spells.Spell{Name: "Detect Magic", Level: 1, Ritual: true, Concentration: true})
+	assert.Equal(t, []string{"Concentration", "Ritual"}, ritual.Tags)
+
+	cantrip := cards.NewCard(&spells.Spell{Name: "Sacred Flame", School: reference.Reference{Name: "Evocation"}})
+	assert.Equal(t, "Evocation cantrip", cantrip.Level)
+}
+
+func TestCards(t *testing.T) {
+	names := func(cards []cards.Card) []string {
+		var list []string
+		for _, card := range cards {
+			list = append(list, card.Name)
+		}
+		return list
+	}
+
+	t.Run("makes a card for every spell", func(t *testing.T) {
+		all := cards.Cards(cleric(), cards.Filter{})
+
+		assert.Equal(t, []string{"Sacred Flame", "Bless", "Cure Wounds", "Shield of Faith"}, names(all))
+		assert.Equal(t, "Leki, level 1 Cleric", all[0].Footer)
+		assert.Equal(t, []string{"Concentration", "Prepared"}, all[1].Tags)
+		assert.Empty(t, all[2].Tags)
+		assert.Equal(t, []string{"Always prepared"}, all[3].Tags)
+	})
+
+	t.Run("only prepared spells", func(t *testing.T) {
+		prepared := cards.Cards(cleric(), cards.Filter{Prepared: true})
+
+		assert.Equal(t, []string{"Sacred Flame", "Bless", "Shield of Faith"}, names(prepared))
+	})
+
+	t.Run("only some levels", func(t *testing.T) {
+		cantrips := cards.Cards(cleric(), cards.Filter{Levels: []int{0}})
+
+		assert.Equal(t, []string{"Sacred Flame"}, names(cantrips))
+	})
+}
+
+func TestSplit(t *testing.T) {
+	t.Run("a short card fits", func(t *testing.T) {
+		card := cards.Card{Name: "Bless", Desc: []string{"You bless up to three creatures."}}
+
+		assert.Equal(t, []cards.Card{card}, card.Split(cards.CardText))
+	})
+
+	t.Run("a long description continues on more cards", func(t *testing.T) {
+		card := cards.Card{
+			Name:        "Wish",
+			Level:       "9th-level conjuration",
+			CastingTime: "1 action",
+			Range:       "Self",
+			Components:  "V",
+			Duration:    "Instantaneous",
+			Footer:      "Leki, level 17 Wizard",
+			Desc:        []string{strings.Repeat("word ", 150), strings.Repeat("more ", 60)},
+			HigherLevel: []string{strings.Repeat("high ", 40)},
+		}
+
+		split := card.Split(cards.CardText)
+
+		require.Len(t, split, 2)
+		assert.Equal(t, "9th-level conjuration", split[0].Level)
+		assert.False(t, split[0].Continued)
+		assert.True(t, split[1].Continued)
+		assert.Equal(t, "Leki, level 17 Wizard", split[1].Footer)
+		assert.NotEmpty(t, split[1].HigherLevel)
+		for _, c := range split {
+			assert.LessOrEqual(t, c.TextSize(), c.Room(cards.CardText))
+		}
+	})
+
+	t.Run("the header card's text never exceeds its room", func(t *testing.T) {
+		// A long first paragraph under a tall header with long components and tags
+		card := cards.Card{
+			Name:        "Symbol",
+			Level:       "7th-level abjuration",
+			CastingTime: "1 minute",
+			Range:       "Touch",
+			Components:  "V, S, M (mercury, phosphorus, and powdered diamond and opal with a total value of at least 1,000 gp, which the spell consumes)",
+			Duration:    "Until dispelled or triggered",
+			Tags:        []string{"Concentration", "Ritual"},
+			Desc:        []string{strings.Repeat("glyph ", 300)},
+		}
+		assert.Less(t, card.Room(cards.CardText), cards.CardText-len(card.Components))
+
+		split := card.Split(cards.CardText)
+
+		require.Greater(t, len(split), 1)
+		assert.LessOrEqual(t, split[0].TextSize(), split[0].Room(cards.CardText))
+		for _, c := range split[1:] {
+			assert.LessOrEqual(t, c.TextSize(), c.Room(cards.CardText))
+		}
+	})
+}
+
+func TestPages(t *testing.T) {
+	list := make([]cards.Card, 10)
+
+	pages := cards.Pages(list)
+
+	require.Len(t, pages, 2)
+	assert.Len(t, pages[0], cards.PerPage)
+	assert.Len(t, pages[1], 1)
+	assert.Empty(t, cards.Pages(nil))
+}
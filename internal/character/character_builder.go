@@ -6,6 +6,7 @@ import (
 	"github.com/kwford18/MKDIRagons/internal/abilities"
 	"github.com/kwford18/MKDIRagons/internal/class"
 	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/inventory"
 	"github.com/kwford18/MKDIRagons/internal/race"
 	"github.com/kwford18/MKDIRagons/internal/skills"
@@ -14,8 +15,9 @@ import (
 	"github.com/kwford18/MKDIRagons/template"
 )
 
-// BuildCharacterWithFetcher builds a character using a custom fetcher (for testing)
-func BuildCharacterWithFetcher(fetcher core.Fetcher, base *template.Character, rollHP bool) (*Character, error) {
+// BuildCharacterWithFetcher builds a character using a custom fetcher (for testing).
+// HP is rolled with roller, or uses hit die averages when roller is nil.
+func BuildCharacterWithFetcher(fetcher core.Fetcher, base *template.Character, roller *dice.Roller) (*Character, error) {
 	var playerRace race.Race
 	var playerClass class.Class
 	var playerInventory inventory.Inventory
@@ -70,37 +72,88 @@ func BuildCharacterWithFetcher(fetcher core.Fetcher, base *template.Character, r
 		return nil, err
 	}
 
+	// Class starting equipment, once the class's options are known
+	if len(base.StartingEquipmentChoices) > 0 {
+		err := inventory.AddStartingEquipmentWithFetcher(fetcher, &playerClass, base.StartingEquipmentChoices, base.StartingEquipmentPicks, &playerInventory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Magic items enhance weapons and armor in the inventory, so they're fetched once it's complete
+	if err := inventory.FetchMagicItemsWithFetcher(fetcher, base, &playerInventory); err != nil {
+		return nil, err
+	}
+
 	// Build ability scores, saves, & skills
 	abilityScores := abilities.BuildAbilityScores(base, playerRace)
 	savingThrows := abilities.BuildSavingThrows(base, abilityScores, &playerClass)
-	skillList := skills.BuildSkillList(base)
+	skillList := skills.BuildSkillList(base, abilityScores)
 
 	// Build Combat Stats
 	var firstArmor *inventory.Armor
 	if len(playerInventory.Armor) > 0 {
 		firstArmor = &playerInventory.Armor[0]
 	}
-	combatStats, err := stats.BuildStats(base.Level, abilityScores, playerClass, rollHP, firstArmor)
+	combatStats, err := stats.BuildStats(base.Level, abilityScores, playerClass, roller, firstArmor)
 	if err != nil {
 		return nil, err
 	}
+	combatStats.RecomputeHP(abilityScores.Modifier(core.Constitution), stats.HPBonusPerLevel(base.Feats, base.Subrace))
+
+	// Keep the seed & rolls so the character can be re-derived exactly
+	var rollLog []dice.Log
+	if roller != nil {
+		rollLog = append(rollLog, roller.Log())
+	}
+
+	char := &Character{
+		Name:           base.Name,
+		Level:          base.Level,
+		Race:           playerRace,
+		Subrace:        base.Subrace,
+		Class:          playerClass,
+		Subclass:       base.Subclass,
+		Pact:           base.Pact,
+		Feats:          base.Feats,
+		Stats:          combatStats,
+		AbilityScores:  abilityScores,
+		SavingThrows:   savingThrows,
+		Skills:         skillList,
+		Proficiencies:  base.Proficiencies,
+		Inventory:      playerInventory,
+		Spells:         spellbook,
+		Prepared:       base.Spells.Prepared,
+		AlwaysPrepared: base.Spells.AlwaysPrepared,
+		RollLog:        rollLog,
+	}
+	char.Stats.AC = char.ArmorClass()
+	char.Defenses = char.BuildDefenses()
+
+	if err := char.CheckSpells(); err != nil {
+		return nil, err
+	}
+
+	// Companions scale with their owner, so they're added once the character is built
+	if err := AddCompanionsWithFetcher(fetcher, char, base.Companions); err != nil {
+		return nil, err
+	}
+
+	return char, nil
+}
 
-	return &Character{
-		Name:          base.Name,
-		Level:         base.Level,
-		Race:          playerRace,
-		Class:         playerClass,
-		Stats:         combatStats,
-		AbilityScores: abilityScores,
-		SavingThrows:  savingThrows,
-		Skills:        skillList,
-		Proficiencies: base.Proficiencies,
-		Inventory:     playerInventory,
-		Spells:        spellbook,
-	}, nil
+// RollStartingGold rolls the class's starting wealth into the wallet, logging the roll with the HP rolls
+func (c *Character) RollStartingGold(roller *dice.Roller) (int, error) {
+	gold, err := c.Class.RollStartingGold(roller)
+	if err != nil {
+		return 0, err
+	}
+	c.Inventory.Wallet.GP += gold
+	c.RollLog = append(c.RollLog, roller.Log())
+	return gold, nil
 }
 
 // BuildCharacter builds a character using the default fetcher (for production)
-func BuildCharacter(base *template.Character, rollHP bool) (*Character, error) {
-	return BuildCharacterWithFetcher(core.DefaultFetcher, base, rollHP)
+func BuildCharacter(base *template.Character, roller *dice.Roller) (*Character, error) {
+	return BuildCharacterWithFetcher(core.DefaultFetcher, base, roller)
 }
@@ -6,9 +6,12 @@ import (
 	"testing"
 
 	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/monster"
 	"github.com/kwford18/MKDIRagons/internal/race"
 	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
 	"github.com/kwford18/MKDIRagons/template"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,6 +59,13 @@ func (m *MockFetcher) FetchJSON(property reference.Fetchable, input string) erro
 			Base:     11,
 			DexBonus: true,
 		}
+	case *spells.Spell:
+		d.Name = input
+		d.Level = 1
+	case *monster.Monster:
+		d.Index, d.Name = input, input
+		d.Type, d.Size = "beast", "Tiny"
+		d.HitPoints = 1
 	case *inventory.Inventory:
 		// Fallback if the builder fetches the whole inventory container (unlikely but possible)
 		d.Items = []inventory.Item{{
@@ -99,7 +109,7 @@ func TestBuildCharacterWithFetcher_Success(t *testing.T) {
 	fetcher := &MockFetcher{ShouldFail: false}
 
 	// Execute
-	char, err := character.BuildCharacterWithFetcher(fetcher, base, false)
+	char, err := character.BuildCharacterWithFetcher(fetcher, base, nil)
 
 	// Verify
 	assert.NoError(t, err)
@@ -112,9 +122,10 @@ func TestBuildCharacterWithFetcher_Success(t *testing.T) {
 	assert.Equal(t, "TestClass", char.Class.Name)
 
 	// Check Derived Stats (HitDie 10, Level 5, Con +1)
-	// Avg HP for d10 is 6.
-	// HP = (6 + 1) * 5 = 35
-	assert.Equal(t, 35, char.Stats.HP)
+	// Level 1 takes the max die, avg HP for d10 is 6 after that.
+	// HP = (10 + 1) + (6 + 1) * 4 = 39
+	assert.Equal(t, 39, char.Stats.HP)
+	assert.Len(t, char.Stats.HPHistory, 5)
 
 	// Check AC (Leather Armor 11 + Dex 2 = 13)
 	assert.Equal(t, 13, char.Stats.AC)
@@ -137,6 +148,113 @@ func TestBuildCharacterWithFetcher_Success(t *testing.T) {
 	assert.True(t, foundItem, "Expected to find 'Test Item' in inventory")
 }
 
+func TestBuildCharacterWithFetcher_SeededRoll(t *testing.T) {
+	base := &template.Character{
+		Name:  "Seeded",
+		Level: 3,
+		AbilityScores: template.AbilityScores{
+			Constitution: 10,
+		},
+	}
+
+	char, err := character.BuildCharacterWithFetcher(&MockFetcher{}, base, dice.NewRoller(42))
+
+	assert.NoError(t, err)
+	// d10 max at level 1, seed 42 then rolls 7 and 4: 10 + 7 + 4
+	assert.Equal(t, 21, char.Stats.HP)
+	if assert.Len(t, char.RollLog, 1) {
+		assert.Equal(t, uint64(42), char.RollLog[0].Seed)
+		assert.Len(t, char.RollLog[0].Rolls, 2)
+	}
+
+	// No roller means averages and no roll log
+	avg, err := character.BuildCharacterWithFetcher(&MockFetcher{}, base, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 22, avg.Stats.HP)
+	assert.Empty(t, avg.RollLog)
+}
+
+func TestRollStartingGold(t *testing.T) {
+	char, err := character.BuildCharacterWithFetcher(&MockFetcher{}, &template.Character{Name: "Seeded", Level: 3}, dice.NewRoller(42))
+	assert.NoError(t, err)
+	char.Class.Name = "Fighter"
+
+	gold, err := char.RollStartingGold(dice.NewRoller(dice.SubSeed(42, "starting gold")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, gold, char.Inventory.Wallet.GP)
+	// Logged after the HP rolls, from its own seed, so starting wealth can be reproduced
+	if assert.Len(t, char.RollLog, 2) {
+		assert.Equal(t, dice.SubSeed(42, "starting gold"), char.RollLog[1].Seed)
+		assert.Len(t, char.RollLog[1].Rolls, 5, "a fighter rolls 5d4 x 10 gp")
+		assert.Equal(t, "starting gold", char.RollLog[1].Rolls[0].Label)
+	}
+
+	char.Class.Name = "TestClass"
+	_, err = char.RollStartingGold(dice.NewRoller(1))
+	assert.Error(t, err)
+}
+
+func TestBuildCharacterWithFetcher_HPBonuses(t *testing.T) {
+	// Hill Dwarf with the Tough feat gains 3 extra HP per level
+	base := &template.Character{
+		Name:    "Stout",
+		Level:   2,
+		Subrace: "Hill Dwarf",
+		Feats:   []string{"Tough"},
+		AbilityScores: template.AbilityScores{
+			Constitution: 10,
+		},
+	}
+
+	char, err := character.BuildCharacterWithFetcher(&MockFetcher{}, base, nil)
+
+	assert.NoError(t, err)
+	// (10 + 3) + (6 + 3) = 22
+	assert.Equal(t, 22, char.Stats.HP)
+	assert.Equal(t, "Hill Dwarf", char.Subrace)
+	assert.Equal(t, []string{"Tough"}, char.Feats)
+}
+
+// wizardFetcher is a MockFetcher whose class is a Wizard casting with Intelligence
+type wizardFetcher struct {
+	MockFetcher
+}
+
+func (w *wizardFetcher) FetchJSON(property reference.Fetchable, input string) error {
+	if err := w.MockFetcher.FetchJSON(property, input); err != nil {
+		return err
+	}
+	if c, ok := property.(*class.Class); ok {
+		c.Index, c.Name, c.HitDie = "wizard", "Wizard", 6
+		c.Spellcasting.SpellcastingAbility = reference.Reference{Index: "int", Name: "INT"}
+	}
+	return nil
+}
+
+func TestBuildCharacterWithFetcher_Spellbook(t *testing.T) {
+	// A level 1 Wizard with Int 16 prepares 4 of the 6 spells in a starting spellbook
+	base := &template.Character{
+		Name:          "Vex",
+		Level:         1,
+		AbilityScores: template.AbilityScores{Intelligence: 16, Constitution: 10},
+		Spells: template.Spells{Level: [][]string{{}, {
+			"Burning Hands", "Detect Magic", "Mage Armor", "Magic Missile", "Shield", "Sleep",
+		}}},
+	}
+
+	char, err := character.BuildCharacterWithFetcher(&wizardFetcher{}, base, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, char.PreparedLimit())
+	assert.Equal(t, []string{"Burning Hands", "Detect Magic", "Mage Armor", "Magic Missile"}, char.PreparedSpells())
+
+	// Picking more than the limit still fails
+	base.Spells.Prepared = []string{"Burning Hands", "Detect Magic", "Mage Armor", "Magic Missile", "Shield"}
+	_, err = character.BuildCharacterWithFetcher(&wizardFetcher{}, base, nil)
+	assert.EqualError(t, err, "Vex has 5 spells prepared, but a level 1 wizard can prepare 4")
+}
+
 func TestBuildCharacterWithFetcher_FetchError(t *testing.T) {
 	// Setup
 	base := &template.Character{
@@ -151,7 +269,7 @@ func TestBuildCharacterWithFetcher_FetchError(t *testing.T) {
 	}
 
 	// Execute
-	char, err := character.BuildCharacterWithFetcher(fetcher, base, false)
+	char, err := character.BuildCharacterWithFetcher(fetcher, base, nil)
 
 	// Verify
 	assert.Error(t, err)
@@ -168,7 +286,7 @@ func TestBuildCharacterWithFetcher_ConcurrentErrorHandling(t *testing.T) {
 		FailOn:     "class",
 	}
 
-	char, err := character.BuildCharacterWithFetcher(fetcher, base, false)
+	char, err := character.BuildCharacterWithFetcher(fetcher, base, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, char)
@@ -0,0 +1,240 @@
+package character
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// Companion kinds, from the class feature or spell that grants the creature
+const (
+	CompanionBeast    = "beast"    // Ranger's Companion of a Beast Master ranger
+	CompanionFamiliar = "familiar" // Find Familiar
+	CompanionSteed    = "steed"    // Find Steed
+	CompanionSummon   = "summon"   // Any other creature fighting alongside the character
+)
+
+// familiarForms are the creatures Find Familiar can summon, by index
+var familiarForms = []string{
+	"bat", "cat", "crab", "frog", "hawk", "lizard", "octopus", "owl", "poisonous-snake",
+	"quipper", "rat", "raven", "sea-horse", "spider", "weasel",
+}
+
+// chainFamiliarForms are the extra forms of a Pact of the Chain warlock's familiar
+var chainFamiliarForms = []string{"imp", "pseudodragon", "quasit", "sprite"}
+
+// steedForms are the creatures Find Steed can summon, by index
+var steedForms = []string{"warhorse", "pony", "camel", "elk", "mastiff"}
+
+// Companion is a creature that belongs to the character, such as a familiar or a ranger's beast
+type Companion struct {
+	Name    string          `json:"name"`
+	Kind    string          `json:"kind"`
+	Monster monster.Monster `json:"monster"`
+}
+
+// KindName describes where the companion comes from, e.g. "Ranger's Companion"
+func (comp *Companion) KindName() string {
+	switch comp.Kind {
+	case CompanionBeast:
+		return "Ranger's Companion"
+	case CompanionFamiliar:
+		return "Familiar"
+	case CompanionSteed:
+		return "Steed"
+	default:
+		return "Summon"
+	}
+}
+
+// NewCompanion checks that the character can have the creature as a companion of a kind.
+// The companion is named after the creature when name is empty.
+func NewCompanion(c *Character, m monster.Monster, name, kind string) (Companion, error) {
+	if name == "" {
+		name = m.Name
+	}
+	comp := Companion{Name: name, Kind: strings.ToLower(kind), Monster: m}
+
+	switch comp.Kind {
+	case CompanionBeast:
+		switch {
+		case !strings.EqualFold(c.Class.Name, "ranger") || c.Level < 3 || !strings.Contains(strings.ToLower(c.Subclass), "beast"):
+			return comp, fmt.Errorf("a Ranger's Companion needs a Beast Master ranger of level 3 or higher")
+		case !strings.EqualFold(m.Type, "beast"):
+			return comp, fmt.Errorf("%s is a %s, not a beast", m.Name, m.Type)
+		case m.ChallengeRating > 0.25:
+			return comp, fmt.Errorf("%s is CR %s, a Ranger's Companion can be at most CR 1/4", m.Name, monster.FormatCR(m.ChallengeRating))
+		case !slices.Contains([]string{"Tiny", "Small", "Medium"}, m.Size):
+			return comp, fmt.Errorf("%s is %s, a Ranger's Companion can be at most Medium", m.Name, m.Size)
+		}
+	case CompanionFamiliar:
+		forms := familiarForms
+		if c.pactOfTheChain() {
+			forms = append(slices.Clone(forms), chainFamiliarForms...)
+		}
+		if !slices.Contains(forms, m.Index) {
+			return comp, fmt.Errorf("%s isn't a form Find Familiar can take", m.Name)
+		}
+	case CompanionSteed:
+		if !slices.Contains(steedForms, m.Index) {
+			return comp, fmt.Errorf("%s isn't a form Find Steed can take", m.Name)
+		}
+	case CompanionSummon:
+	default:
+		return comp, fmt.Errorf("unknown companion kind %q, expected beast, familiar, steed or summon", kind)
+	}
+
+	return comp, nil
+}
+
+// pactOfTheChain reports whether the character is a warlock of level 3 or higher who took the Pact of the Chain
+func (c *Character) pactOfTheChain() bool {
+	return strings.EqualFold(c.Class.Name, "warlock") && c.Level >= 3 && strings.Contains(strings.ToLower(c.Pact), "chain")
+}
+
+// toHit matches the attack bonus in an action's description, e.g. "+4 to hit"
+var toHit = regexp.MustCompile(`\+(\d+) to hit`)
+
+// Stats returns the companion's stat block after its owner's scaling.
+// A Ranger's Companion adds the ranger's proficiency bonus to AC, attack and damage rolls and its proficient
+// saves and skills, and has at least 4 HP per ranger level. A steed has an Intelligence of at least 6.
+func (comp *Companion) Stats(owner *Character) monster.Monster {
+	m := comp.Monster
+	m.Name = comp.Name
+
+	switch comp.Kind {
+	case CompanionBeast:
+		bonus := owner.ProficiencyBonus()
+		if len(m.ArmorClass) > 0 {
+			m.ArmorClass = slices.Clone(m.ArmorClass)
+			m.ArmorClass[0].Value += bonus
+		} else {
+			m.ArmorClass = []monster.ArmorClass{{Type: "dex", Value: m.AC() + bonus}}
+		}
+		m.HitPoints = max(m.HitPoints, 4*owner.Level)
+
+		m.Proficiencies = slices.Clone(m.Proficiencies)
+		for i := range m.Proficiencies {
+			m.Proficiencies[i].Value += bonus
+		}
+
+		m.Actions = slices.Clone(m.Actions)
+		for i := range m.Actions {
+			action := &m.Actions[i]
+			if action.AttackBonus == 0 {
+				continue
+			}
+			action.AttackBonus += bonus
+			action.Desc = toHit.ReplaceAllStringFunc(action.Desc, func(match string) string {
+				n, _ := strconv.Atoi(toHit.FindStringSubmatch(match)[1])
+				return fmt.Sprintf("+%d to hit", n+bonus)
+			})
+			if len(action.Damage) > 0 {
+				action.Damage = slices.Clone(action.Damage)
+				action.Damage[0].DamageDice += fmt.Sprintf("%+d", bonus)
+			}
+		}
+	case CompanionSteed:
+		m.Intelligence = max(m.Intelligence, 6)
+	}
+	return m
+}
+
+// MaxHP returns the companion's hit point maximum after its owner's scaling
+func (comp *Companion) MaxHP(owner *Character) int {
+	m := comp.Stats(owner)
+	return m.HitPoints
+}
+
+// FindCompanion returns the character's companion with a name, ignoring case
+func (c *Character) FindCompanion(name string) (*Companion, error) {
+	for i := range c.Companions {
+		if strings.EqualFold(c.Companions[i].Name, strings.TrimSpace(name)) {
+			return &c.Companions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no companion named %q", c.Name, name)
+}
+
+// AddCompanion adds a companion, which must have a name no other companion has
+func (c *Character) AddCompanion(comp Companion) (string, error) {
+	if _, err := c.FindCompanion(comp.Name); err == nil {
+		return "", fmt.Errorf("%s already has a companion named %s", c.Name, comp.Name)
+	}
+	c.Companions = append(c.Companions, comp)
+	return fmt.Sprintf("gained %s (%s, %s)", comp.Name, comp.Monster.Name, comp.KindName()), nil
+}
+
+// RemoveCompanion dismisses a companion
+func (c *Character) RemoveCompanion(name string) (string, error) {
+	comp, err := c.FindCompanion(name)
+	if err != nil {
+		return "", err
+	}
+	removed := comp.Name
+	c.Companions = slices.DeleteFunc(c.Companions, func(other Companion) bool { return other.Name == removed })
+	return fmt.Sprintf("dismissed %s", removed), nil
+}
+
+// PrintCompanions prints the stat block of every companion after its owner's scaling
+func (c *Character) PrintCompanions() {
+	for i := range c.Companions {
+		comp := &c.Companions[i]
+		m := comp.Stats(c)
+		fmt.Printf("Companion: %s (%s, %s)\n", comp.Name, comp.Monster.Name, comp.KindName())
+		fmt.Println(&m)
+		if comp.Kind == CompanionBeast {
+			fmt.Printf("Includes %s's proficiency bonus (%+d) on AC, attacks, damage, saves and skills.\n", c.Name, c.ProficiencyBonus())
+		}
+		fmt.Println()
+	}
+}
+
+// AddCompanionsWithFetcher fetches the template's companions in parallel and adds them to the character,
+// using a custom fetcher (for testing)
+func AddCompanionsWithFetcher(fetcher core.Fetcher, c *Character, entries []template.Companion) error {
+	monsters := make([]monster.Monster, len(entries))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(entries))
+
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry template.Companion) {
+			defer wg.Done()
+			if err := monster.FetchMonsterWithFetcher(fetcher, entry.Monster, &monsters[i]); err != nil {
+				errs <- err
+			}
+		}(i, entry)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return err
+	}
+
+	for i, entry := range entries {
+		comp, err := NewCompanion(c, monsters[i], entry.Name, entry.Kind)
+		if err != nil {
+			return err
+		}
+		if _, err := c.AddCompanion(comp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddCompanions fetches the template's companions using the default fetcher
+func AddCompanions(c *Character, entries []template.Companion) error {
+	return AddCompanionsWithFetcher(core.DefaultFetcher, c, entries)
+}
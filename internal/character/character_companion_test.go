@@ -0,0 +1,155 @@
+package character_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wolf() monster.Monster {
+	return monster.Monster{
+		Index: "wolf", Name: "Wolf", Size: "Medium", Type: "beast", ChallengeRating: 0.25,
+		ArmorClass:    []monster.ArmorClass{{Type: "natural", Value: 13}},
+		HitPoints:     11,
+		AbilityScores: abilities.AbilityScores{Strength: 12, Dexterity: 15, Constitution: 12, Intelligence: 3, Wisdom: 12, Charisma: 6},
+		Proficiencies: []monster.Proficiency{{Value: 4, Proficiency: reference.Reference{Name: "Skill: Stealth"}}},
+		Actions: []monster.Action{{
+			Name: "Bite", AttackBonus: 4,
+			Desc:   "Melee Weapon Attack: +4 to hit, reach 5 ft., one target.",
+			Damage: []monster.ActionDamage{{DamageType: reference.Reference{Name: "Piercing"}, DamageDice: "2d4+2"}},
+		}},
+	}
+}
+
+func beastMaster() *character.Character {
+	return &character.Character{Name: "Vex", Level: 5, Class: class.Class{Name: "Ranger"}, Subclass: "Beast Master"}
+}
+
+func TestNewCompanion(t *testing.T) {
+	ranger := beastMaster()
+	cleric := &character.Character{Name: "Leki", Level: 5, Class: class.Class{Name: "Cleric"}}
+	owl := monster.Monster{Index: "owl", Name: "Owl", Size: "Tiny", Type: "beast"}
+	warhorse := monster.Monster{Index: "warhorse", Name: "Warhorse", Size: "Large", Type: "beast", ChallengeRating: 0.5}
+	imp := monster.Monster{Index: "imp", Name: "Imp", Size: "Tiny", Type: "fiend"}
+	chainWarlock := &character.Character{Name: "Mal", Level: 3, Class: class.Class{Name: "Warlock"}, Pact: "chain"}
+	bladeWarlock := &character.Character{Name: "Mal", Level: 3, Class: class.Class{Name: "Warlock"}, Pact: "blade"}
+
+	tests := []struct {
+		name  string
+		owner *character.Character
+		m     monster.Monster
+		kind  string
+		err   string
+	}{
+		{"ranger's companion", ranger, wolf(), "beast", ""},
+		{"not a beast master", cleric, wolf(), "beast", "Beast Master ranger"},
+		{"too strong", ranger, warhorse, "beast", "at most CR 1/4"},
+		{"familiar", cleric, owl, "familiar", ""},
+		{"not a familiar form", cleric, wolf(), "familiar", "isn't a form Find Familiar"},
+		{"pact of the chain familiar", chainWarlock, imp, "familiar", ""},
+		{"other pact", bladeWarlock, imp, "familiar", "isn't a form Find Familiar"},
+		{"steed", cleric, warhorse, "Steed", ""},
+		{"summon", cleric, wolf(), "summon", ""},
+		{"unknown kind", cleric, wolf(), "pet", "unknown companion kind"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := character.NewCompanion(tt.owner, tt.m, "", tt.kind)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+
+	ranger.Subclass = "Hunter"
+	_, err := character.NewCompanion(ranger, wolf(), "", "beast")
+	assert.Error(t, err)
+}
+
+func TestCompanionStats_RangersCompanion(t *testing.T) {
+	ranger := beastMaster()
+	comp, err := character.NewCompanion(ranger, wolf(), "Trinket", "beast")
+	require.NoError(t, err)
+
+	stats := comp.Stats(ranger)
+
+	// Proficiency bonus +3 on AC, attacks, damage and skills, and 4 HP per ranger level
+	assert.Equal(t, "Trinket", stats.Name)
+	assert.Equal(t, 16, stats.AC())
+	assert.Equal(t, 20, stats.HitPoints)
+	assert.Equal(t, 7, stats.Actions[0].AttackBonus)
+	assert.Equal(t, "2d4+2+3", stats.Actions[0].Damage[0].DamageDice)
+	assert.Contains(t, stats.Actions[0].Desc, "+7 to hit")
+	assert.Equal(t, 7, stats.Proficiencies[0].Value)
+
+	// The SRD stat block itself is untouched
+	assert.Equal(t, 13, comp.Monster.AC())
+	assert.Equal(t, 4, comp.Monster.Actions[0].AttackBonus)
+	assert.Equal(t, "2d4+2", comp.Monster.Actions[0].Damage[0].DamageDice)
+
+	ranger.Level = 9
+	assert.Equal(t, 36, comp.MaxHP(ranger))
+}
+
+func TestCompanionStats_Steed(t *testing.T) {
+	paladin := &character.Character{Name: "Sir", Level: 5, Class: class.Class{Name: "Paladin"}}
+	comp, err := character.NewCompanion(paladin, monster.Monster{Index: "warhorse", Name: "Warhorse", HitPoints: 19,
+		AbilityScores: abilities.AbilityScores{Intelligence: 2}}, "", "steed")
+	require.NoError(t, err)
+
+	stats := comp.Stats(paladin)
+
+	assert.Equal(t, 6, stats.Intelligence)
+	assert.Equal(t, 19, comp.MaxHP(paladin))
+}
+
+func TestAddAndRemoveCompanion(t *testing.T) {
+	ranger := beastMaster()
+	comp, _ := character.NewCompanion(ranger, wolf(), "Trinket", "beast")
+
+	summary, err := ranger.AddCompanion(comp)
+	require.NoError(t, err)
+	assert.Equal(t, "gained Trinket (Wolf, Ranger's Companion)", summary)
+
+	_, err = ranger.AddCompanion(comp)
+	assert.ErrorContains(t, err, "already has a companion named Trinket")
+
+	found, err := ranger.FindCompanion("trinket")
+	require.NoError(t, err)
+	assert.Equal(t, "Wolf", found.Monster.Name)
+
+	summary, err = ranger.RemoveCompanion("Trinket")
+	require.NoError(t, err)
+	assert.Equal(t, "dismissed Trinket", summary)
+	assert.Empty(t, ranger.Companions)
+
+	_, err = ranger.RemoveCompanion("Trinket")
+	assert.Error(t, err)
+}
+
+func TestAddCompanionsWithFetcher(t *testing.T) {
+	wizard := &character.Character{Name: "Merlin", Level: 3, Class: class.Class{Name: "Wizard"}}
+
+	err := character.AddCompanionsWithFetcher(&MockFetcher{}, wizard, []template.Companion{
+		{Monster: "owl", Kind: "familiar", Name: "Archimedes"},
+		{Monster: "rat", Kind: "summon"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, wizard.Companions, 2)
+	assert.Equal(t, "Archimedes", wizard.Companions[0].Name)
+	assert.Equal(t, "owl", wizard.Companions[0].Monster.Index)
+	assert.Equal(t, "rat", wizard.Companions[1].Name)
+
+	err = character.AddCompanionsWithFetcher(&MockFetcher{}, wizard, []template.Companion{{Monster: "wolf", Kind: "beast"}})
+	assert.ErrorContains(t, err, "Beast Master")
+}
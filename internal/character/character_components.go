@@ -0,0 +1,154 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+)
+
+// classFoci is the kind of spellcasting focus each class can use in place of a component pouch
+var classFoci = map[string]string{
+	"bard": inventory.FocusInstrument, "cleric": inventory.FocusHoly, "druid": inventory.FocusDruidic,
+	"paladin": inventory.FocusHoly, "sorcerer": inventory.FocusArcane, "warlock": inventory.FocusArcane,
+	"wizard": inventory.FocusArcane,
+}
+
+// focusNames describes each kind of spellcasting focus
+var focusNames = map[string]string{
+	inventory.FocusArcane: "arcane focus", inventory.FocusDruidic: "druidic focus",
+	inventory.FocusHoly: "holy symbol", inventory.FocusInstrument: "musical instrument",
+}
+
+// CanRitualCast reports whether the character can cast a spell as a ritual, taking 10 minutes longer
+// without spending a slot. Wizards cast rituals from their spellbook, other ritual casters have to
+// know or have the spell prepared.
+func (c *Character) CanRitualCast(spell *spells.Spell) bool {
+	if !spell.Ritual || !c.Class.RitualCaster() {
+		return false
+	}
+	return c.Class.Preparation() == class.SpellbookSpells || c.CanCast(spell) == nil
+}
+
+// SpellFocus returns the component pouch or spellcasting focus in the inventory that the character's
+// class can use for material components without a cost, "" when there's none
+func (c *Character) SpellFocus() string {
+	classFocus := classFoci[strings.ToLower(c.Class.Name)]
+	for i := range c.Inventory.Items {
+		if focus := c.Inventory.Items[i].Focus(); focus == inventory.ComponentPouch || (focus != "" && focus == classFocus) {
+			return c.Inventory.Items[i].Name
+		}
+	}
+	return ""
+}
+
+// genericMaterialWords don't tell materials apart, e.g. the "dust" of "diamond dust"
+var genericMaterialWords = []string{"a", "an", "and", "bit", "dust", "four", "of", "or", "pair", "powdered", "ring", "small", "some", "strip", "the"}
+
+// materialWords returns the words naming a material, singular, e.g. "diamond" for "Diamonds"
+func materialWords(name string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		word = strings.TrimSuffix(word, "s")
+		if !slices.Contains(genericMaterialWords, word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isMaterial reports whether a valuable is the item a costly material component names
+func isMaterial(valuable inventory.Valuable, material spells.CostlyMaterial) bool {
+	wanted := materialWords(material.Item)
+	return slices.ContainsFunc(materialWords(valuable.Name), func(word string) bool { return slices.Contains(wanted, word) })
+}
+
+// hasMaterial reports whether the character's valuables cover a costly material component:
+// a single one worth enough, or enough worth in total when any amount will do
+func (c *Character) hasMaterial(material spells.CostlyMaterial) bool {
+	total := 0
+	for _, valuable := range c.Inventory.Valuables {
+		if !isMaterial(valuable, material) {
+			continue
+		}
+		if material.AtLeast && valuable.GP >= material.GP {
+			return true
+		}
+		total += valuable.GP * valuable.Carried()
+	}
+	return !material.AtLeast && total >= material.GP
+}
+
+// ConsumedMaterials returns the valuables casting a spell uses up: for each consumed costly material,
+// the cheapest single one worth enough, or enough of them to make up the worth in total
+func (c *Character) ConsumedMaterials(spell *spells.Spell) []inventory.Valuable {
+	var consumed []inventory.Valuable
+	for _, material := range spell.CostlyMaterials() {
+		if !material.Consumed || !c.hasMaterial(material) {
+			continue
+		}
+		var matching []inventory.Valuable
+		for _, valuable := range c.Inventory.Valuables {
+			if isMaterial(valuable, material) && (!material.AtLeast || valuable.GP >= material.GP) {
+				matching = append(matching, valuable)
+			}
+		}
+		slices.SortStableFunc(matching, func(a, b inventory.Valuable) int { return a.GP - b.GP })
+
+		if material.AtLeast {
+			consumed = append(consumed, inventory.Valuable{Name: matching[0].Name, GP: matching[0].GP})
+			continue
+		}
+		left := material.GP
+		for _, valuable := range matching {
+			if left <= 0 {
+				break
+			}
+			count := min(valuable.Carried(), (left+valuable.GP-1)/max(1, valuable.GP))
+			spent := inventory.Valuable{Name: valuable.Name, GP: valuable.GP}
+			if count > 1 {
+				spent.Count = count
+			}
+			consumed = append(consumed, spent)
+			left -= count * valuable.GP
+		}
+	}
+	return consumed
+}
+
+// MissingComponents lists the components the character lacks for a spell: costly materials that
+// aren't among their valuables, and a component pouch or focus for the other materials
+func (c *Character) MissingComponents(spell *spells.Spell) []string {
+	if !spell.HasComponent("M") {
+		return nil
+	}
+
+	var missing []string
+	for _, material := range spell.CostlyMaterials() {
+		if !c.hasMaterial(material) {
+			missing = append(missing, fmt.Sprintf("needs %s", material))
+		}
+	}
+	if c.SpellFocus() == "" {
+		focus := "component pouch"
+		if name, ok := focusNames[classFoci[strings.ToLower(c.Class.Name)]]; ok {
+			focus += " or " + name
+		}
+		missing = append(missing, fmt.Sprintf("no %s for the material components", focus))
+	}
+	return missing
+}
+
+// MissingCostlyMaterial returns an error naming the first costly material component the character lacks
+func (c *Character) MissingCostlyMaterial(spell *spells.Spell) error {
+	for _, material := range spell.CostlyMaterials() {
+		if !c.hasMaterial(material) {
+			return fmt.Errorf("%s needs %s to cast %s", c.Name, material, spell.Name)
+		}
+	}
+	return nil
+}
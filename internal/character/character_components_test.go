@@ -0,0 +1,103 @@
+package character_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revivify is a spell whose material component is costly and consumed
+var revivify = spells.Spell{Name: "Revivify", Level: 3, Components: []string{"V", "S", "M"}, Material: "Diamonds worth 300 gp, which the spell consumes."}
+
+// holySymbol is an SRD amulet, which clerics and paladins can use as a focus
+var holySymbol = inventory.Item{
+	BaseEquipment: inventory.BaseEquipment{Index: "amulet", Name: "Amulet"},
+	GearCategory:  &reference.Reference{Index: "holy-symbols"},
+}
+
+func TestCanRitualCast(t *testing.T) {
+	detectMagic := spells.Spell{Name: "Detect Magic", Level: 1, Ritual: true}
+	alarm := spells.Spell{Name: "Alarm", Level: 1, Ritual: true}
+
+	// Wizards cast any ritual in their spellbook, prepared or not
+	wizard := spellcaster("Wizard", nil, []spells.Spell{detectMagic, alarm})
+	wizard.Prepared = []string{"Alarm"}
+	assert.True(t, wizard.CanRitualCast(&wizard.Spells[1][0]))
+
+	// Clerics only cast the rituals they have prepared
+	cleric := spellcaster("Cleric", nil, []spells.Spell{detectMagic, alarm})
+	cleric.Prepared = []string{"Alarm"}
+	assert.False(t, cleric.CanRitualCast(&cleric.Spells[1][0]))
+	assert.True(t, cleric.CanRitualCast(&cleric.Spells[1][1]))
+
+	sorcerer := spellcaster("Sorcerer", nil, []spells.Spell{detectMagic})
+	assert.False(t, sorcerer.CanRitualCast(&sorcerer.Spells[1][0]))
+
+	shield := spells.Spell{Name: "Shield", Level: 1}
+	assert.False(t, wizard.CanRitualCast(&shield))
+}
+
+func TestSpellFocus(t *testing.T) {
+	cleric := spellcaster("Cleric")
+	assert.Empty(t, cleric.SpellFocus())
+
+	cleric.Inventory.Items = []inventory.Item{holySymbol}
+	assert.Equal(t, "Amulet", cleric.SpellFocus())
+
+	// A holy symbol isn't a wizard's focus, but a component pouch works for everyone
+	wizard := spellcaster("Wizard")
+	wizard.Inventory.Items = []inventory.Item{holySymbol}
+	assert.Empty(t, wizard.SpellFocus())
+	wizard.Inventory.Items = append(wizard.Inventory.Items, inventory.Item{BaseEquipment: inventory.BaseEquipment{Index: "component-pouch", Name: "Component pouch"}})
+	assert.Equal(t, "Component pouch", wizard.SpellFocus())
+}
+
+func TestMissingComponents(t *testing.T) {
+	cleric := spellcaster("Cleric", nil, nil, nil, []spells.Spell{revivify})
+	spell := &cleric.Spells[3][0]
+
+	assert.Equal(t, []string{"needs diamonds worth 300 gp", "no component pouch or holy symbol for the material components"}, cleric.MissingComponents(spell))
+	assert.EqualError(t, cleric.MissingCostlyMaterial(spell), "Leki needs diamonds worth 300 gp to cast Revivify")
+
+	// Diamonds add up to the worth needed
+	cleric.Inventory.Items = []inventory.Item{holySymbol}
+	cleric.Inventory.Valuables = []inventory.Valuable{{Name: "Diamond", GP: 150, Count: 2}}
+	assert.Empty(t, cleric.MissingComponents(spell))
+	require.NoError(t, cleric.MissingCostlyMaterial(spell))
+
+	// A diamond "worth at least" 500 gp has to be a single one
+	raiseDead := spells.Spell{Name: "Raise Dead", Components: []string{"M"}, Material: "A diamond worth at least 500 gp, which the spell consumes."}
+	assert.Equal(t, []string{"needs diamond worth at least 500 gp"}, cleric.MissingComponents(&raiseDead))
+	cleric.Inventory.Valuables = append(cleric.Inventory.Valuables, inventory.Valuable{Name: "Flawless Diamond", GP: 500})
+	assert.Empty(t, cleric.MissingComponents(&raiseDead))
+
+	// Other gems don't count
+	cleric.Inventory.Valuables = []inventory.Valuable{{Name: "Ruby", GP: 1000}}
+	assert.Equal(t, []string{"needs diamonds worth 300 gp"}, cleric.MissingComponents(spell))
+
+	assert.Empty(t, cleric.MissingComponents(&spells.Spell{Name: "Sacred Flame", Components: []string{"V", "S"}}))
+}
+
+func TestConsumedMaterials(t *testing.T) {
+	cleric := spellcaster("Cleric", nil, nil, nil, []spells.Spell{revivify})
+	spell := &cleric.Spells[3][0]
+	assert.Empty(t, cleric.ConsumedMaterials(spell), "nothing to consume without the diamonds")
+
+	// Cheaper diamonds are used up first, as many as it takes
+	cleric.Inventory.Valuables = []inventory.Valuable{{Name: "Diamond", GP: 200, Count: 2}, {Name: "Small Diamond", GP: 50, Count: 3}}
+	assert.Equal(t, []inventory.Valuable{{Name: "Small Diamond", GP: 50, Count: 3}, {Name: "Diamond", GP: 200}}, cleric.ConsumedMaterials(spell))
+
+	// A diamond "worth at least" 500 gp uses the cheapest single one worth enough
+	raiseDead := spells.Spell{Name: "Raise Dead", Components: []string{"M"}, Material: "A diamond worth at least 500 gp, which the spell consumes."}
+	cleric.Inventory.Valuables = []inventory.Valuable{{Name: "Diamond", GP: 1000}, {Name: "Diamond", GP: 600}}
+	assert.Equal(t, []inventory.Valuable{{Name: "Diamond", GP: 600}}, cleric.ConsumedMaterials(&raiseDead))
+
+	// Components that aren't consumed stay
+	identify := spells.Spell{Name: "Identify", Components: []string{"M"}, Material: "A pearl worth at least 100 gp and an owl feather."}
+	cleric.Inventory.Valuables = []inventory.Valuable{{Name: "Pearl", GP: 100}}
+	assert.Empty(t, cleric.ConsumedMaterials(&identify))
+}
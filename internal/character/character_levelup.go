@@ -0,0 +1,247 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/skills"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// LevelUpChoices holds the decisions a player makes when their character gains a level
+type LevelUpChoices struct {
+	ASI      []core.Ability // Each entry is a +1 increase, so list an ability twice for +2
+	Feat     string         // Taken instead of an ASI
+	Spells   []string       // Newly learned spells, placed by their fetched spell level
+	Subclass string
+}
+
+// LevelUpResult summarizes what changed when a character gained a level
+type LevelUpResult struct {
+	Level    int
+	HPGained int
+	Choices  LevelUpChoices
+	Spells   []spells.Spell
+}
+
+// NeedsASI reports whether the next level grants an Ability Score Improvement or feat
+func (c *Character) NeedsASI() bool {
+	return c.Class.IsASILevel(c.Level + 1)
+}
+
+// NeedsSubclass reports whether the next level is where the character must pick a subclass
+func (c *Character) NeedsSubclass() bool {
+	return c.Subclass == "" && c.Level+1 >= c.Class.SubclassLevel()
+}
+
+// validateLevelUp checks the choices against the level being gained
+func (c *Character) validateLevelUp(choices LevelUpChoices) error {
+	next := c.Level + 1
+	if next > 20 {
+		return fmt.Errorf("%s is already level 20", c.Name)
+	}
+
+	if len(choices.ASI) > 0 || choices.Feat != "" {
+		if !c.Class.IsASILevel(next) {
+			return fmt.Errorf("%s does not gain an ability score improvement at level %d", c.Class.Name, next)
+		}
+		if len(choices.ASI) > 0 && choices.Feat != "" {
+			return fmt.Errorf("choose either an ability score improvement or a feat, not both")
+		}
+		if len(choices.ASI) > 0 && len(choices.ASI) != 2 {
+			return fmt.Errorf("an ability score improvement is two +1 increases, got %d", len(choices.ASI))
+		}
+	}
+
+	if choices.Subclass != "" {
+		if c.Subclass != "" {
+			return fmt.Errorf("%s already has the %s subclass", c.Name, c.Subclass)
+		}
+		if next < c.Class.SubclassLevel() {
+			return fmt.Errorf("%s picks a subclass at level %d", c.Class.Name, c.Class.SubclassLevel())
+		}
+	}
+
+	return nil
+}
+
+// fetchLearnedSpells concurrently fetches the spells picked during a level up
+func fetchLearnedSpells(fetcher core.Fetcher, names []string) ([]spells.Spell, error) {
+	learned := make([]spells.Spell, len(names))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(names))
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			if err := fetcher.FetchJSON(&learned[i], name); err != nil {
+				errs <- err
+			}
+		}(i, name)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return nil, err
+	}
+	return learned, nil
+}
+
+// addSpells returns a copy of the spell lists with the learned spells added at their levels
+func addSpells(lists [][]spells.Spell, learned []spells.Spell) [][]spells.Spell {
+	lists = slices.Clone(lists)
+	for _, spell := range learned {
+		for len(lists) <= spell.Level {
+			lists = append(lists, []spells.Spell{})
+		}
+		lists[spell.Level] = append(slices.Clone(lists[spell.Level]), spell)
+	}
+	return lists
+}
+
+// LevelUpWithFetcher advances the character by one level using a custom fetcher (for testing).
+// Only the new level's hit die is rolled with roller (or averaged when nil), existing HP is kept as-is.
+func LevelUpWithFetcher(fetcher core.Fetcher, c *Character, choices LevelUpChoices, roller *dice.Roller) (LevelUpResult, error) {
+	if err := c.validateLevelUp(choices); err != nil {
+		return LevelUpResult{}, err
+	}
+
+	// Fetch spells first so a failure leaves the character untouched
+	learned, err := fetchLearnedSpells(fetcher, choices.Spells)
+	if err != nil {
+		return LevelUpResult{}, err
+	}
+
+	// Apply the ability score improvement to a copy until everything is validated
+	scores := c.AbilityScores
+	for _, ability := range choices.ASI {
+		scores.SetScore(ability, scores.Score(ability)+1)
+		if scores.Score(ability) > 20 {
+			return LevelUpResult{}, fmt.Errorf("%s cannot be increased above 20", ability)
+		}
+	}
+
+	// The new spells have to fit the limits of the level being gained
+	probe := *c
+	probe.Level, probe.AbilityScores, probe.Spells = c.Level+1, scores, addSpells(c.Spells, learned)
+	if err := probe.CheckSpells(); err != nil {
+		return LevelUpResult{}, err
+	}
+
+	feats := c.Feats
+	if choices.Feat != "" {
+		feats = append(slices.Clone(c.Feats), choices.Feat)
+	}
+
+	oldConBonus := c.AbilityScores.Modifier(core.Constitution)
+	newConBonus := scores.Modifier(core.Constitution)
+	oldBonus := stats.HPBonusPerLevel(c.Feats, c.Subrace)
+	newBonus := stats.HPBonusPerLevel(feats, c.Subrace)
+
+	entry, err := stats.NewHPEntry(c.Level+1, c.Class.HitDie, newConBonus, newBonus, roller)
+	if err != nil {
+		return LevelUpResult{}, err
+	}
+
+	hpBefore := c.Stats.HP
+	c.Stats.AddLevel(entry)
+	if len(c.Stats.HPHistory) == c.Level+1 {
+		// Constitution and per-level bonuses apply retroactively to every level
+		c.Stats.RecomputeHP(newConBonus, newBonus)
+	} else {
+		// Characters saved without an HP history only know their total
+		c.Stats.HP += (newConBonus - oldConBonus + newBonus - oldBonus) * c.Level
+	}
+	hpGained := c.Stats.HP - hpBefore
+
+	c.Level++
+	c.AbilityScores = scores
+	c.Feats = feats
+	if choices.Subclass != "" {
+		c.Subclass = choices.Subclass
+	}
+	c.Spells = probe.Spells
+
+	if roller != nil {
+		c.RollLog = append(c.RollLog, roller.Log())
+	}
+
+	c.RefreshDerived()
+
+	return LevelUpResult{
+		Level:    c.Level,
+		HPGained: hpGained,
+		Choices:  choices,
+		Spells:   learned,
+	}, nil
+}
+
+// LevelUp advances the character by one level using the default fetcher (for production)
+func LevelUp(c *Character, choices LevelUpChoices, roller *dice.Roller) (LevelUpResult, error) {
+	return LevelUpWithFetcher(core.DefaultFetcher, c, choices, roller)
+}
+
+// ApplyLevelUp records a level up on the source template so the TOML stays in sync with the JSON
+func ApplyLevelUp(base *template.Character, result LevelUpResult) {
+	base.Level = result.Level
+	for _, ability := range result.Choices.ASI {
+		base.AbilityScores.Increase(ability, 1)
+	}
+	if result.Choices.Feat != "" {
+		base.Feats = append(base.Feats, result.Choices.Feat)
+	}
+	if result.Choices.Subclass != "" {
+		base.Subclass = result.Choices.Subclass
+	}
+	for i, spell := range result.Spells {
+		for len(base.Spells.Level) <= spell.Level {
+			base.Spells.Level = append(base.Spells.Level, []string{})
+		}
+		base.Spells.Level[spell.Level] = append(base.Spells.Level[spell.Level], result.Choices.Spells[i])
+	}
+}
+
+// toTemplate rebuilds the minimal template needed by the save & skill builders
+func (c *Character) toTemplate() *template.Character {
+	var expertise []string
+	for _, skill := range c.Skills.All() {
+		if skill.Expertise {
+			expertise = append(expertise, skill.Name)
+		}
+	}
+
+	return &template.Character{
+		Name:  c.Name,
+		Level: c.Level,
+		AbilityScores: template.AbilityScores{
+			Strength:     c.AbilityScores.Strength,
+			Dexterity:    c.AbilityScores.Dexterity,
+			Constitution: c.AbilityScores.Constitution,
+			Wisdom:       c.AbilityScores.Wisdom,
+			Intelligence: c.AbilityScores.Intelligence,
+			Charisma:     c.AbilityScores.Charisma,
+		},
+		Proficiencies: c.Proficiencies,
+		Expertise:     expertise,
+	}
+}
+
+// RefreshDerived recomputes the values that depend on level, ability scores, features and armor
+func (c *Character) RefreshDerived() {
+	base := c.toTemplate()
+	c.SavingThrows = abilities.BuildSavingThrows(base, c.AbilityScores, &c.Class)
+	c.Skills = skills.BuildSkillList(base, c.AbilityScores)
+
+	c.Stats.AC = c.ArmorClass()
+	c.Defenses = c.BuildDefenses()
+}
@@ -0,0 +1,266 @@
+package character_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/race"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/kwford18/MKDIRagons/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLevelUpCharacter builds a level 3 cleric as it would be loaded from JSON
+func setupLevelUpCharacter() *character.Character {
+	return &character.Character{
+		Name:  "Leki",
+		Level: 3,
+		Class: class.Class{
+			Name:   "Cleric",
+			HitDie: 8,
+			SavingThrows: []reference.Reference{
+				{Index: "wis", Name: "WIS"},
+				{Index: "cha", Name: "CHA"},
+			},
+		},
+		Subclass: "Life",
+		Stats:    stats.Stats{HP: 21, AC: 10, Speed: 30},
+		AbilityScores: abilities.AbilityScores{
+			Strength: 10, Dexterity: 10, Constitution: 15,
+			Intelligence: 12, Wisdom: 16, Charisma: 13,
+		},
+		Proficiencies: []string{"Insight"},
+		Spells:        [][]spells.Spell{{}, {{Name: "Bless", Level: 1}}},
+	}
+}
+
+func TestLevelUp_AverageHPOnlyForNewLevel(t *testing.T) {
+	c := setupLevelUpCharacter()
+
+	result, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{}, nil)
+
+	require.NoError(t, err)
+	// d8 average 5 + Con 2 = 7, existing HP untouched
+	assert.Equal(t, 4, c.Level)
+	assert.Equal(t, 4, result.Level)
+	assert.Equal(t, 7, result.HPGained)
+	assert.Equal(t, 28, c.Stats.HP)
+}
+
+func TestLevelUp_RolledHPSeeded(t *testing.T) {
+	c := setupLevelUpCharacter()
+
+	result, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{}, dice.NewRoller(42))
+
+	require.NoError(t, err)
+	// Seed 42 rolls a 3 on the d8, plus Con 2
+	assert.Equal(t, 5, result.HPGained)
+	assert.Equal(t, 26, c.Stats.HP)
+
+	// The seed and roll are kept on the character
+	require.Len(t, c.RollLog, 1)
+	assert.Equal(t, uint64(42), c.RollLog[0].Seed)
+	assert.Equal(t, 3, c.RollLog[0].Rolls[0].Result)
+}
+
+func TestLevelUp_ASIRecomputesDerivedValues(t *testing.T) {
+	c := setupLevelUpCharacter()
+	choices := character.LevelUpChoices{ASI: []core.Ability{core.Constitution, core.Wisdom}}
+
+	result, err := character.LevelUpWithFetcher(&MockFetcher{}, c, choices, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 16, c.AbilityScores.Constitution)
+	assert.Equal(t, 17, c.AbilityScores.Wisdom)
+	// Con mod 2 -> 3: new level gives 5 + 3, plus 1 for each of the 3 previous levels
+	assert.Equal(t, 11, result.HPGained)
+	// Wis save: mod 3 + proficiency 2
+	assert.Equal(t, 5, c.SavingThrows.Wisdom)
+	// Insight: mod 3 + proficiency 2
+	assert.Equal(t, 5, c.Skills.Insight.Bonus)
+	assert.True(t, c.Skills.Insight.Proficient)
+}
+
+// nimbleFetcher is a MockFetcher whose race adds 2 to Dexterity
+type nimbleFetcher struct {
+	MockFetcher
+}
+
+func (n *nimbleFetcher) FetchJSON(property reference.Fetchable, input string) error {
+	if err := n.MockFetcher.FetchJSON(property, input); err != nil {
+		return err
+	}
+	if r, ok := property.(*race.Race); ok {
+		r.AbilityBonuses = []race.AbilityBonus{{AbilityScore: reference.Reference{Index: "dex", Name: "DEX"}, Bonus: 2}}
+	}
+	return nil
+}
+
+func TestLevelUp_NoASIKeepsSkills(t *testing.T) {
+	base := &template.Character{
+		Name:          "Vex",
+		Level:         2,
+		AbilityScores: template.AbilityScores{Dexterity: 13, Constitution: 10},
+		Proficiencies: []string{"Stealth"},
+		Expertise:     []string{"Stealth"},
+	}
+	c, err := character.BuildCharacterWithFetcher(&nimbleFetcher{}, base, nil)
+	require.NoError(t, err)
+	// Dex 13 + 2 is +2, plus proficiency and expertise
+	require.Equal(t, 6, c.Skills.Stealth.Bonus)
+	built := c.Skills
+
+	_, err = character.LevelUpWithFetcher(&nimbleFetcher{}, c, character.LevelUpChoices{}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, built, c.Skills)
+}
+
+func TestLevelUp_ProficiencyBonusIncrease(t *testing.T) {
+	c := setupLevelUpCharacter()
+	c.Level = 4
+
+	_, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, c.ProficiencyBonus())
+	assert.Equal(t, 6, c.SavingThrows.Wisdom)
+	assert.Equal(t, 6, c.Skills.Insight.Bonus)
+}
+
+func TestLevelUp_FeatAndSpells(t *testing.T) {
+	c := setupLevelUpCharacter()
+	choices := character.LevelUpChoices{Feat: "Alert", Spells: []string{"Cure Wounds"}}
+
+	result, err := character.LevelUpWithFetcher(&MockFetcher{}, c, choices, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alert"}, c.Feats)
+	require.Len(t, result.Spells, 1)
+	require.Len(t, c.Spells[1], 2)
+	assert.Equal(t, "Cure Wounds", c.Spells[1][1].Name)
+}
+
+func TestLevelUp_SpellsKnownLimit(t *testing.T) {
+	c := setupLevelUpCharacter()
+	c.Class.Name = "Sorcerer"
+	c.Level = 1
+	c.Spells = [][]spells.Spell{{}, {{Name: "Shield", Level: 1}}}
+
+	_, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{Spells: []string{"Sleep", "Burning Hands", "Magic Missile"}}, nil)
+
+	assert.EqualError(t, err, "Leki knows 4 spells, but a level 2 sorcerer knows 3")
+	assert.Equal(t, 1, c.Level, "character should be unchanged on error")
+	assert.Len(t, c.Spells[1], 1)
+
+	_, err = character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{Spells: []string{"Sleep", "Burning Hands"}}, nil)
+
+	require.NoError(t, err)
+	assert.Len(t, c.Spells[1], 3)
+}
+
+func TestLevelUp_InvalidChoices(t *testing.T) {
+	testCases := []struct {
+		name    string
+		level   int
+		choices character.LevelUpChoices
+	}{
+		{"ASI at non-ASI level", 4, character.LevelUpChoices{ASI: []core.Ability{core.Wisdom, core.Wisdom}}},
+		{"ASI and feat together", 3, character.LevelUpChoices{ASI: []core.Ability{core.Wisdom, core.Wisdom}, Feat: "Alert"}},
+		{"Single ASI increase", 3, character.LevelUpChoices{ASI: []core.Ability{core.Wisdom}}},
+		{"Second subclass", 3, character.LevelUpChoices{Subclass: "Knowledge"}},
+		{"Already max level", 20, character.LevelUpChoices{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := setupLevelUpCharacter()
+			c.Level = tc.level
+
+			_, err := character.LevelUpWithFetcher(&MockFetcher{}, c, tc.choices, nil)
+
+			assert.Error(t, err)
+			assert.Equal(t, tc.level, c.Level, "character should be unchanged on error")
+		})
+	}
+}
+
+func TestLevelUp_ScoreCap(t *testing.T) {
+	c := setupLevelUpCharacter()
+	c.AbilityScores.Wisdom = 20
+
+	_, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{ASI: []core.Ability{core.Wisdom, core.Constitution}}, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 20, c.AbilityScores.Wisdom)
+	assert.Equal(t, 15, c.AbilityScores.Constitution)
+}
+
+func TestLevelUp_Subclass(t *testing.T) {
+	c := setupLevelUpCharacter()
+	c.Class = class.Class{Name: "Fighter", HitDie: 10}
+	c.Subclass = ""
+	c.Level = 2
+
+	assert.True(t, c.NeedsSubclass())
+	assert.False(t, c.NeedsASI())
+
+	_, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{Subclass: "Champion"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Champion", c.Subclass)
+	assert.False(t, c.NeedsSubclass())
+	assert.True(t, c.NeedsASI())
+}
+
+func TestApplyLevelUp(t *testing.T) {
+	base := &template.Character{
+		Name:  "Leki",
+		Level: 3,
+		AbilityScores: template.AbilityScores{
+			Constitution: 15, Wisdom: 16,
+		},
+		Spells: template.Spells{Level: [][]string{{}, {"Bless"}}},
+	}
+	result := character.LevelUpResult{
+		Level: 4,
+		Choices: character.LevelUpChoices{
+			ASI:    []core.Ability{core.Wisdom, core.Wisdom},
+			Spells: []string{"Spiritual Weapon"},
+		},
+		Spells: []spells.Spell{{Name: "Spiritual Weapon", Level: 2}},
+	}
+
+	character.ApplyLevelUp(base, result)
+
+	assert.Equal(t, 4, base.Level)
+	assert.Equal(t, 18, base.AbilityScores.Wisdom)
+	assert.Equal(t, 15, base.AbilityScores.Constitution)
+	require.Len(t, base.Spells.Level, 3)
+	assert.Equal(t, []string{"Spiritual Weapon"}, base.Spells.Level[2])
+}
+
+func TestLevelUp_WithHPHistory(t *testing.T) {
+	c := setupLevelUpCharacter()
+	// Level 3 cleric, d8, Con +2: 10 + 7 + 7 = 24
+	var err error
+	c.Stats, err = stats.BuildStats(3, c.AbilityScores, c.Class, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 24, c.Stats.HP)
+
+	result, err := character.LevelUpWithFetcher(&MockFetcher{}, c, character.LevelUpChoices{Feat: "Tough"}, nil)
+
+	require.NoError(t, err)
+	// New level 5 + 2, Tough adds 2 to all 4 levels
+	assert.Equal(t, 15, result.HPGained)
+	assert.Equal(t, 39, c.Stats.HP)
+	assert.Len(t, c.Stats.HPHistory, 4)
+	assert.Equal(t, 4, c.Stats.HitDice[0].Max)
+}
@@ -0,0 +1,60 @@
+package character
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/skills"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+)
+
+// Abilities returns the ability scores after magic items that set a score, e.g. Gauntlets of Ogre Power.
+// A setter only applies when it's higher than the character's own score.
+func (c *Character) Abilities() abilities.AbilityScores {
+	scores := c.AbilityScores
+	for ability, score := range c.Inventory.MagicEffects().Scores {
+		if score > scores.Score(ability) {
+			scores.SetScore(ability, score)
+		}
+	}
+	return scores
+}
+
+// abilityBoost is how much magic items raise an ability's modifier over the stored one
+func (c *Character) abilityBoost(ability core.Ability) int {
+	scores := c.Abilities()
+	return scores.Modifier(ability) - c.AbilityScores.Modifier(ability)
+}
+
+// SkillBonuses returns the skills after magic items that raise an ability score or add to ability checks.
+// The stored skills are built from the character's own scores.
+func (c *Character) SkillBonuses() skills.SkillList {
+	list := c.Skills
+	checks := c.Inventory.MagicEffects().Checks
+	for _, skill := range c.Skills.All() {
+		if skill.Name == "" {
+			continue
+		}
+		list.Find(skill.Name).Bonus += c.abilityBoost(c.GetSkillAbility(skill.Name)) + checks
+	}
+	return list
+}
+
+// MaxHP returns max HP after magic items that raise Constitution, e.g. an Amulet of Health.
+// The stored HP is built from the character's own score, so each level gains the higher modifier's difference.
+func (c *Character) MaxHP() int {
+	return c.Stats.HP + c.abilityBoost(core.Constitution)*c.Level
+}
+
+// ArmorClass returns AC from the equipped armor, class and ability scores, plus magic item bonuses
+func (c *Character) ArmorClass() int {
+	armor := c.EquippedArmor()
+	effects := c.Inventory.MagicEffects()
+
+	ac := stats.ArmorClass(c.Abilities(), c.Class, armor) + effects.AC
+	if armor == nil {
+		ac += effects.UnarmoredAC
+	} else {
+		ac += c.Inventory.EnhancementBonus(armor.Name)
+	}
+	return ac
+}
@@ -2,10 +2,13 @@ package character
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kwford18/MKDIRagons/internal/abilities"
 	"github.com/kwford18/MKDIRagons/internal/class"
 	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/inventory"
 	"github.com/kwford18/MKDIRagons/internal/race"
 	"github.com/kwford18/MKDIRagons/internal/skills"
@@ -14,17 +17,26 @@ import (
 )
 
 type Character struct {
-	Name          string                  `json:"name"`
-	Level         int                     `json:"level"`
-	Race          race.Race               `json:"race"`
-	Class         class.Class             `json:"class"`
-	Stats         stats.Stats             `json:"stats"`
-	Proficiencies []string                `json:"proficiencies"`
-	AbilityScores abilities.AbilityScores `json:"ability_scores"`
-	Skills        skills.SkillList        `json:"skills"`
-	SavingThrows  abilities.AbilityScores `json:"saving_throws"`
-	Inventory     inventory.Inventory     `json:"inventory"`
-	Spells        [][]spells.Spell        `json:"spells"`
+	Name           string                  `json:"name"`
+	Level          int                     `json:"level"`
+	Race           race.Race               `json:"race"`
+	Subrace        string                  `json:"subrace,omitempty"`
+	Class          class.Class             `json:"class"`
+	Subclass       string                  `json:"subclass,omitempty"`
+	Pact           string                  `json:"pact,omitempty"` // Warlock's Pact Boon, e.g. "chain"
+	Feats          []string                `json:"feats,omitempty"`
+	Stats          stats.Stats             `json:"stats"`
+	Defenses       damage.Defenses         `json:"defenses,omitempty"`
+	Proficiencies  []string                `json:"proficiencies"`
+	AbilityScores  abilities.AbilityScores `json:"ability_scores"`
+	Skills         skills.SkillList        `json:"skills"`
+	SavingThrows   abilities.AbilityScores `json:"saving_throws"`
+	Inventory      inventory.Inventory     `json:"inventory"`
+	Spells         [][]spells.Spell        `json:"spells"`
+	Prepared       []string                `json:"prepared,omitempty"`        // Leveled spells prepared today, the first ones up to the limit when empty
+	AlwaysPrepared []string                `json:"always_prepared,omitempty"` // Domain and oath spells
+	Companions     []Companion             `json:"companions,omitempty"`
+	RollLog        []dice.Log              `json:"roll_log,omitempty"`
 }
 
 func (c *Character) ProficiencyBonus() int {
@@ -61,30 +73,137 @@ func (c *Character) GetSkillAbility(name string) core.Ability {
 	}
 }
 
+// PassivePerception returns 10 + the Perception bonus, including magic items
+func (c *Character) PassivePerception() int {
+	return 10 + c.SkillBonuses().Perception.Bonus
+}
+
+// Languages lists the languages the character's race speaks
+func (c *Character) Languages() []string {
+	names := make([]string, len(c.Race.Languages))
+	for i, language := range c.Race.Languages {
+		names[i] = language.Name
+	}
+	return names
+}
+
+// HasProficiency reports whether the character is proficient with a skill, tool or piece of equipment,
+// from their chosen proficiencies or their class, e.g. "Thieves' Tools"
+func (c *Character) HasProficiency(name string) bool {
+	for _, p := range c.Proficiencies {
+		if strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	for _, p := range c.Class.Proficiencies {
+		if strings.EqualFold(p.Name, name) || strings.EqualFold(p.Index, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// itemNames lists the names of everything in the inventory, for deriving item defenses
+func (c *Character) itemNames() []string {
+	var names []string
+	for _, item := range c.Inventory.Items {
+		names = append(names, item.Name)
+	}
+	for _, armor := range c.Inventory.Armor {
+		names = append(names, armor.Name)
+	}
+	for _, item := range c.Inventory.MagicItems {
+		if item.Active() {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// BuildDefenses derives resistances, immunities and vulnerabilities from race, class and items
+func (c *Character) BuildDefenses() damage.Defenses {
+	return damage.BuildDefenses(&c.Race, c.Subrace, &c.Class, c.Subclass, c.Level, c.itemNames())
+}
+
+// Encumbrance returns carried weight against carrying capacity, from Strength and race size
+func (c *Character) Encumbrance() inventory.Encumbrance {
+	return inventory.BuildEncumbrance(c.Abilities().Strength, c.Race.Size, c.Inventory.Weight())
+}
+
 func (c *Character) Print() {
 	// Name, Level, Race, Class, Basic Stats
 	fmt.Printf("Name: %s\n", c.Name)
 	fmt.Printf("Level: %d\n", c.Level)
 	c.Race.Print()
 	c.Class.Print()
-	c.Stats.Print()
+	if c.Subclass != "" {
+		fmt.Printf("Subclass: %s\n", c.Subclass)
+	}
+	// HP after magic items that raise Constitution
+	stats := c.Stats
+	stats.HP = c.MaxHP()
+	stats.Print()
+	c.Defenses.Print()
 
 	fmt.Println()
 
 	// Equipment
 	c.Inventory.Print()
+	c.Encumbrance().Print()
 
 	fmt.Println()
 
-	// Spells
-	fmt.Println("Spells:")
+	// Spells, marking what's prepared for classes that prepare them
+	preparing := c.Class.Preparation() == class.PreparedSpells || c.Class.Preparation() == class.SpellbookSpells
+	if preparing {
+		fmt.Printf("Spells (%d/%d prepared):\n", len(c.PreparedSpells()), c.PreparedLimit())
+	} else {
+		fmt.Println("Spells:")
+	}
 	for i, level := range c.Spells {
 		if len(level) == 0 {
 			continue
 		}
 		fmt.Printf("  Level %d:\n", i)
 		for _, spell := range level {
-			fmt.Printf("    - %s\n", spell.Name)
+			var tags []string
+			switch {
+			case i > 0 && c.IsAlwaysPrepared(&spell):
+				tags = append(tags, "always prepared")
+			case i > 0 && preparing && c.CanCast(&spell) == nil:
+				tags = append(tags, "prepared")
+			}
+			if c.CanRitualCast(&spell) {
+				tags = append(tags, "ritual")
+			}
+			for _, material := range spell.CostlyMaterials() {
+				tag := "needs " + material.String()
+				if material.Consumed {
+					tag = "consumes " + material.String()
+				}
+				if !c.hasMaterial(material) {
+					tag += " - not carried"
+				}
+				tags = append(tags, tag)
+			}
+			if len(tags) > 0 {
+				fmt.Printf("    - %s (%s)\n", spell.Name, strings.Join(tags, ", "))
+			} else {
+				fmt.Printf("    - %s\n", spell.Name)
+			}
+		}
+	}
+	if c.Class.Preparation() != class.NoSpellcasting {
+		if focus := c.SpellFocus(); focus != "" {
+			fmt.Printf("  Spellcasting focus: %s\n", focus)
+		} else {
+			fmt.Println("  No spellcasting focus or component pouch")
+		}
+	}
+	if scalings := c.SpellScalings(); len(scalings) > 0 {
+		fmt.Println("  Damage and healing:")
+		for _, scaling := range scalings {
+			fmt.Printf("    %s\n", scaling)
 		}
 	}
 
@@ -92,7 +211,8 @@ func (c *Character) Print() {
 
 	// Ability Scores
 	fmt.Println("Ability Scores:")
-	c.AbilityScores.Print()
+	scores := c.Abilities()
+	scores.Print()
 
 	// Saving throws
 	fmt.Println("Saving Throws:")
@@ -104,8 +224,23 @@ func (c *Character) Print() {
 		fmt.Printf("	- %s\n", prof)
 	}
 
+	// Feats
+	if len(c.Feats) > 0 {
+		fmt.Println("Feats:")
+		for _, feat := range c.Feats {
+			fmt.Printf("	- %s\n", feat)
+		}
+	}
+
 	fmt.Println()
 
-	// Skills
-	c.Skills.Print()
+	// Skills after magic items
+	skillList := c.SkillBonuses()
+	skillList.Print()
+
+	// Companions
+	if len(c.Companions) > 0 {
+		fmt.Println()
+		c.PrintCompanions()
+	}
 }
@@ -5,8 +5,12 @@ import (
 	"github.com/kwford18/MKDIRagons/internal/character"
 	"testing"
 
+	"github.com/kwford18/MKDIRagons/internal/class"
 	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/race"
+	"github.com/kwford18/MKDIRagons/internal/reference"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCharacter_ProficiencyBonus(t *testing.T) {
@@ -90,3 +94,36 @@ func TestCharacter_GetSkillAbility(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDefenses(t *testing.T) {
+	c := &character.Character{
+		Level: 5,
+		Race: race.Race{Traits: []reference.Reference{
+			{Index: "hellish-resistance", Name: "Hellish Resistance"},
+		}},
+		Class: class.Class{Name: "Cleric"},
+	}
+
+	defenses := c.BuildDefenses()
+
+	require.Len(t, defenses, 1)
+	assert.Equal(t, "fire", defenses[0].DamageType.Index)
+}
+
+func TestCharacter_PartyHelpers(t *testing.T) {
+	c := &character.Character{
+		Race: race.Race{Languages: []reference.Reference{{Name: "Common"}, {Name: "Elvish"}}},
+		Class: class.Class{Name: "Rogue", Proficiencies: []reference.Reference{
+			{Index: "thieves-tools", Name: "Thieves' Tools"},
+		}},
+		Proficiencies: []string{"Stealth", "Perception"},
+	}
+	c.Skills.Perception.Bonus = 5
+
+	assert.Equal(t, 15, c.PassivePerception())
+	assert.Equal(t, []string{"Common", "Elvish"}, c.Languages())
+	assert.True(t, c.HasProficiency("thieves' tools"))
+	assert.True(t, c.HasProficiency("thieves-tools"))
+	assert.True(t, c.HasProficiency("stealth"))
+	assert.False(t, c.HasProficiency("Arcana"))
+}
@@ -0,0 +1,388 @@
+package character
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/skills"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+)
+
+// Check describes a roll the character can make: a d20 with a bonus and/or a damage expression
+type Check struct {
+	Kind         string    `json:"kind"` // "skill", "save", "attack" or "cast"
+	Name         string    `json:"name"`
+	Bonus        int       `json:"bonus"`
+	HasD20       bool      `json:"has_d20"`             // False for spells that only force a save
+	Mode         dice.Mode `json:"mode"`                // Resolved from Advantage and Disadvantage when rolled
+	Advantage    bool      `json:"-"`                   // Something grants advantage
+	Disadvantage bool      `json:"-"`                   // Something imposes disadvantage
+	AutoFail     bool      `json:"auto_fail,omitempty"` // Fails without rolling, e.g. a paralyzed creature's Dexterity save
+	Notes        []string  `json:"notes,omitempty"`
+	Damage       string    `json:"damage,omitempty"`
+	DamageType   string    `json:"damage_type,omitempty"`
+	Healing      string    `json:"healing,omitempty"`
+	SaveDC       int       `json:"save_dc,omitempty"`
+	SaveAbility  string    `json:"save_ability,omitempty"`
+	Supply       string    `json:"supply,omitempty"` // Index of the ammunition or thrown weapon the attack spends
+
+	Scaling *SpellScaling `json:"scaling,omitempty"` // A spell's damage or healing at other levels
+}
+
+// CheckResult is a rolled Check
+type CheckResult struct {
+	Character string       `json:"character"`
+	Check     Check        `json:"check"`
+	Roll      *dice.Result `json:"roll,omitempty"`
+	Critical  bool         `json:"critical,omitempty"`
+	Damage    *dice.Result `json:"damage,omitempty"`
+	Healing   *dice.Result `json:"healing,omitempty"`
+}
+
+// normalizeName lowercases a name and strips spaces, hyphens and apostrophes for lookups
+func normalizeName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "", "'", "").Replace(strings.ToLower(name))
+}
+
+// findSkill looks a skill up by name, accepting forms like "sleight of hand" or "animal-handling"
+func (c *Character) findSkill(name string) (skills.Skill, error) {
+	for _, skill := range c.Skills.All() {
+		if normalizeName(skill.Name) == normalizeName(name) {
+			return skill, nil
+		}
+	}
+	return skills.Skill{}, fmt.Errorf("unknown skill %q", name)
+}
+
+// EquippedArmor returns the worn armor (the first armor listed), or nil when unarmored
+func (c *Character) EquippedArmor() *inventory.Armor {
+	if len(c.Inventory.Armor) > 0 {
+		return &c.Inventory.Armor[0]
+	}
+	return nil
+}
+
+// applyEncumbrance gives disadvantage to Strength, Dexterity and Constitution rolls while heavily encumbered
+func (c *Character) applyEncumbrance(check *Check, ability core.Ability) {
+	if ability != core.Strength && ability != core.Dexterity && ability != core.Constitution {
+		return
+	}
+	if e := c.Encumbrance(); e.Level == inventory.HeavilyEncumbered || e.Level == inventory.OverCapacity {
+		check.Disadvantage = true
+		check.Notes = append(check.Notes, fmt.Sprintf("%s: disadvantage on %s rolls", e.Level, ability))
+	}
+}
+
+// SkillCheck resolves the bonus for a skill check, applying disadvantage from armor to Stealth
+func (c *Character) SkillCheck(name string) (Check, error) {
+	skill, err := c.findSkill(name)
+	if err != nil {
+		return Check{}, err
+	}
+
+	bonuses := c.SkillBonuses()
+	check := Check{Kind: "skill", Name: skill.Name, Bonus: bonuses.Find(skill.Name).Bonus, HasD20: true}
+	if armor := c.EquippedArmor(); skill.Name == "Stealth" && armor != nil && armor.StealthDisadvantage {
+		check.Disadvantage = true
+		check.Notes = append(check.Notes, fmt.Sprintf("%s imposes disadvantage on Stealth", armor.Name))
+	}
+	c.applyEncumbrance(&check, c.GetSkillAbility(skill.Name))
+	return check, nil
+}
+
+// SavingThrow resolves the bonus for a saving throw
+func (c *Character) SavingThrow(ability core.Ability) Check {
+	bonus := c.SavingThrows.Score(ability) + c.abilityBoost(ability) + c.Inventory.MagicEffects().Saves
+	check := Check{Kind: "save", Name: ability.String(), Bonus: bonus, HasD20: true}
+	c.applyEncumbrance(&check, ability)
+	return check
+}
+
+// findWeapon looks a weapon in the inventory up by name or index
+func (c *Character) findWeapon(name string) (*inventory.Weapon, error) {
+	for i, weapon := range c.Inventory.Weapons {
+		if normalizeName(weapon.Name) == normalizeName(name) || normalizeName(weapon.Index) == normalizeName(name) {
+			return &c.Inventory.Weapons[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no weapon named %q", c.Name, name)
+}
+
+// WeaponProficient reports whether the class grants proficiency with the weapon,
+// either by category (Simple/Martial Weapons) or by the specific weapon (e.g. Rapiers)
+func (c *Character) WeaponProficient(weapon *inventory.Weapon) bool {
+	for _, prof := range c.Class.Proficiencies {
+		if strings.EqualFold(prof.Name, weapon.WeaponCategory+" Weapons") ||
+			normalizeName(prof.Name) == normalizeName(weapon.Name+"s") ||
+			prof.Index == weapon.Index+"s" {
+			return true
+		}
+	}
+	return false
+}
+
+// WeaponAttack resolves the attack bonus and damage of a weapon in the inventory.
+// Finesse weapons use the better of Strength and Dexterity, ranged weapons use Dexterity.
+func (c *Character) WeaponAttack(name string) (Check, error) {
+	weapon, err := c.findWeapon(name)
+	if err != nil {
+		return Check{}, err
+	}
+
+	scores := c.Abilities()
+	ability := core.Strength
+	if weapon.WeaponRange == "Ranged" {
+		ability = core.Dexterity
+	} else if weapon.HasProperty("finesse") && scores.Modifier(core.Dexterity) > scores.Modifier(core.Strength) {
+		ability = core.Dexterity
+	}
+	// A +N magic weapon adds N to both attack and damage rolls
+	enhancement := c.Inventory.EnhancementBonus(weapon.Name)
+	mod := scores.Modifier(ability) + enhancement
+
+	check := Check{Kind: "attack", Name: weapon.Name, Bonus: mod, HasD20: true}
+	if enhancement > 0 {
+		check.Name = fmt.Sprintf("+%d %s", enhancement, weapon.Name)
+	}
+	if c.WeaponProficient(weapon) {
+		check.Bonus += c.ProficiencyBonus()
+	} else {
+		check.Notes = append(check.Notes, fmt.Sprintf("not proficient with %s", weapon.Name))
+	}
+	c.applyEncumbrance(&check, ability)
+
+	if ammo := weapon.Ammunition(); ammo != "" {
+		check.Supply = ammo
+	} else if weapon.HasProperty("thrown") {
+		check.Supply = weapon.Index
+	}
+
+	if weapon.Damage.DamageDice != "" {
+		check.Damage = weapon.Damage.DamageDice
+		if mod != 0 {
+			check.Damage += fmt.Sprintf("%+d", mod)
+		}
+		check.DamageType = strings.ToLower(weapon.Damage.DamageType.Name)
+	}
+
+	return check, nil
+}
+
+// FindSpell looks a known spell up by name or index
+func (c *Character) FindSpell(name string) (*spells.Spell, error) {
+	for i := range c.Spells {
+		for j, spell := range c.Spells[i] {
+			if normalizeName(spell.Name) == normalizeName(name) || normalizeName(spell.Index) == normalizeName(name) {
+				return &c.Spells[i][j], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s does not know the spell %q", c.Name, name)
+}
+
+// SpellcastingAbility returns the ability the character's class casts spells with
+func (c *Character) SpellcastingAbility() (core.Ability, error) {
+	index := c.Class.Spellcasting.SpellcastingAbility.Index
+	if index == "" {
+		return core.Ability(0), fmt.Errorf("%s has no spellcasting ability", c.Class.Name)
+	}
+	return core.ParseAbility(index)
+}
+
+// SpellSaveDC returns 8 + proficiency bonus + spellcasting modifier
+func (c *Character) SpellSaveDC() (int, error) {
+	ability, err := c.SpellcastingAbility()
+	if err != nil {
+		return 0, err
+	}
+	scores := c.Abilities()
+	return 8 + c.ProficiencyBonus() + scores.Modifier(ability), nil
+}
+
+// SpellAttackBonus returns proficiency bonus + spellcasting modifier
+func (c *Character) SpellAttackBonus() (int, error) {
+	ability, err := c.SpellcastingAbility()
+	if err != nil {
+		return 0, err
+	}
+	scores := c.Abilities()
+	return c.ProficiencyBonus() + scores.Modifier(ability), nil
+}
+
+// scaledDamage picks the entry of a level keyed damage table for the given level,
+// using the highest key that doesn't exceed it
+func scaledDamage(table map[string]string, level int) string {
+	levels := make([]int, 0, len(table))
+	for key := range table {
+		if n, err := strconv.Atoi(key); err == nil && n <= level {
+			levels = append(levels, n)
+		}
+	}
+	if len(levels) == 0 {
+		return ""
+	}
+	sort.Ints(levels)
+	return table[strconv.Itoa(levels[len(levels)-1])]
+}
+
+// SpellCast resolves a spell cast at the given slot level (0 casts at the spell's own level).
+// Spells with an attack roll get the spell attack bonus, spells with a save get the save DC.
+func (c *Character) SpellCast(name string, slot int) (Check, error) {
+	spell, err := c.FindSpell(name)
+	if err != nil {
+		return Check{}, err
+	}
+
+	if spell.Level == 0 {
+		slot = 0
+	} else if slot == 0 {
+		slot = spell.Level
+	} else if slot < spell.Level || slot > 9 {
+		return Check{}, fmt.Errorf("%s is level %d and can't be cast with a level %d slot", spell.Name, spell.Level, slot)
+	}
+
+	check := Check{Kind: "cast", Name: spell.Name}
+	if slot > 0 {
+		check.Notes = append(check.Notes, fmt.Sprintf("cast with a level %d slot", slot))
+	}
+	if spell.Concentration {
+		check.Notes = append(check.Notes, "requires concentration")
+	}
+	if err := c.CanCast(spell); err != nil {
+		check.Notes = append(check.Notes, "not prepared")
+	}
+	if c.CanRitualCast(spell) {
+		check.Notes = append(check.Notes, "can be cast as a ritual")
+	}
+	check.Notes = append(check.Notes, c.MissingComponents(spell)...)
+
+	if spell.AttackType != "" {
+		bonus, err := c.SpellAttackBonus()
+		if err != nil {
+			return Check{}, err
+		}
+		check.HasD20 = true
+		check.Bonus = bonus
+	}
+
+	if spell.DC != nil {
+		dc, err := c.SpellSaveDC()
+		if err != nil {
+			return Check{}, err
+		}
+		check.SaveDC = dc
+		check.SaveAbility = spell.DC.DCType.Name
+		if spell.DC.DCSuccess != "" && spell.DC.DCSuccess != "none" {
+			check.Notes = append(check.Notes, fmt.Sprintf("%s damage on a successful save", spell.DC.DCSuccess))
+		}
+	}
+
+	if spell.Damage != nil {
+		if spell.Level == 0 {
+			check.Damage = c.spellDice(spell.Damage.DamageAtCharacterLevel, c.Level)
+		} else {
+			check.Damage = c.spellDice(spell.Damage.DamageAtSlotLevel, slot)
+		}
+		check.DamageType = strings.ToLower(spell.Damage.DamageType.Name)
+	}
+	if spell.HealAtSlotLevel != nil {
+		check.Healing = c.spellDice(spell.HealAtSlotLevel, slot)
+	}
+	if scaling, ok := c.SpellScaling(spell); ok {
+		check.Scaling = &scaling
+	}
+
+	return check, nil
+}
+
+// Roll rolls the check's d20 and damage. A natural 20 on an attack doubles the damage dice.
+func (ch Check) Roll(roller *dice.Roller) (CheckResult, error) {
+	result := CheckResult{Check: ch}
+	result.Check.Mode = dice.Resolve(ch.Advantage, ch.Disadvantage)
+
+	if ch.HasD20 && !ch.AutoFail {
+		roll, err := dice.Eval(roller, dice.D20(result.Check.Mode, ch.Bonus), ch.Name)
+		if err != nil {
+			return CheckResult{}, err
+		}
+		result.Roll = &roll
+		result.Critical = (ch.Kind == "attack" || ch.Kind == "cast") && roll.Natural() == 20
+	}
+
+	if ch.Damage != "" {
+		expr, err := dice.Parse(ch.Damage)
+		if err != nil {
+			return CheckResult{}, err
+		}
+		if result.Critical {
+			expr = expr.Crit()
+		}
+		damage := expr.Roll(roller, ch.Name+" damage")
+		result.Damage = &damage
+	}
+
+	if ch.Healing != "" {
+		healing, err := dice.Eval(roller, ch.Healing, ch.Name+" healing")
+		if err != nil {
+			return CheckResult{}, err
+		}
+		result.Healing = &healing
+	}
+
+	return result, nil
+}
+
+// RollCheck rolls the check for this character, recording its name on the result
+func (c *Character) RollCheck(check Check, roller *dice.Roller) (CheckResult, error) {
+	result, err := check.Roll(roller)
+	result.Character = c.Name
+	return result, err
+}
+
+// String renders the result as a short breakdown for the terminal
+func (r CheckResult) String() string {
+	var sb strings.Builder
+
+	kind := map[string]string{"skill": "check", "save": "saving throw", "attack": "attack", "cast": "cast"}[r.Check.Kind]
+	fmt.Fprintf(&sb, "%s: %s %s", r.Character, r.Check.Name, kind)
+
+	if r.Roll != nil {
+		fmt.Fprintf(&sb, "\n  Roll:   %s", r.Roll)
+		switch natural := r.Roll.Natural(); {
+		case r.Critical:
+			sb.WriteString(" (critical hit!)")
+		case natural == 20:
+			sb.WriteString(" (natural 20)")
+		case natural == 1:
+			sb.WriteString(" (natural 1)")
+		}
+	}
+	if r.Check.AutoFail {
+		sb.WriteString("\n  Roll:   automatic failure")
+	}
+	if r.Check.SaveDC > 0 {
+		fmt.Fprintf(&sb, "\n  Save:   DC %d %s", r.Check.SaveDC, r.Check.SaveAbility)
+	}
+	if r.Damage != nil {
+		fmt.Fprintf(&sb, "\n  Damage: %s %s", r.Damage, r.Check.DamageType)
+	}
+	if r.Healing != nil {
+		fmt.Fprintf(&sb, "\n  Healing: %s", r.Healing)
+	}
+	if r.Check.Scaling != nil {
+		fmt.Fprintf(&sb, "\n  Scaling: %s", r.Check.Scaling)
+	}
+	if r.Check.Mode != dice.Normal {
+		fmt.Fprintf(&sb, "\n  Rolled with %s", r.Check.Mode)
+	}
+	for _, note := range r.Check.Notes {
+		fmt.Fprintf(&sb, "\n  Note:   %s", note)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,293 @@
+package character_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/skills"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// CharacterRollsTestSuite covers resolving checks, saves, attacks and spells from a built character
+type CharacterRollsTestSuite struct {
+	suite.Suite
+	char *character.Character
+}
+
+func (suite *CharacterRollsTestSuite) SetupTest() {
+	suite.char = &character.Character{
+		Name:  "Leki",
+		Level: 5,
+		Class: class.Class{
+			Name: "Cleric",
+			Proficiencies: []reference.Reference{
+				{Index: "simple-weapons", Name: "Simple Weapons"},
+			},
+			Spellcasting: class.Spellcasting{
+				Level:               1,
+				SpellcastingAbility: reference.Reference{Index: "wis", Name: "WIS"},
+			},
+		},
+		AbilityScores: abilities.AbilityScores{
+			Strength: 10, Dexterity: 14, Constitution: 14,
+			Intelligence: 12, Wisdom: 16, Charisma: 13,
+		},
+		SavingThrows: abilities.AbilityScores{Wisdom: 6, Dexterity: 2},
+		Skills: skills.SkillList{
+			Stealth:       skills.Skill{Name: "Stealth", Bonus: 2},
+			SleightOfHand: skills.Skill{Name: "SleightOfHand", Bonus: 2},
+			Insight:       skills.Skill{Name: "Insight", Bonus: 6, Proficient: true},
+		},
+		Inventory: inventory.Inventory{
+			Armor: []inventory.Armor{{
+				BaseEquipment:       inventory.BaseEquipment{Name: "Padded Armor"},
+				StealthDisadvantage: true,
+			}},
+			Weapons: []inventory.Weapon{
+				{
+					BaseEquipment: inventory.BaseEquipment{
+						Index:      "rapier",
+						Name:       "Rapier",
+						Properties: []reference.Reference{{Index: "finesse"}},
+					},
+					WeaponCategory: "Martial",
+					WeaponRange:    "Melee",
+					Damage:         inventory.Damage{DamageDice: "1d8", DamageType: reference.Reference{Name: "Piercing"}},
+				},
+				{
+					BaseEquipment:  inventory.BaseEquipment{Index: "mace", Name: "Mace"},
+					WeaponCategory: "Simple",
+					WeaponRange:    "Melee",
+					Damage:         inventory.Damage{DamageDice: "1d6", DamageType: reference.Reference{Name: "Bludgeoning"}},
+				},
+			},
+		},
+		Spells: [][]spells.Spell{
+			{{
+				Name: "Sacred Flame", Level: 0,
+				DC:     &spells.SpellDC{DCType: reference.Reference{Name: "DEX"}, DCSuccess: "none"},
+				Damage: &spells.SpellDamage{DamageType: reference.Reference{Name: "Radiant"}, DamageAtCharacterLevel: map[string]string{"1": "1d8", "5": "2d8", "11": "3d8"}},
+			}},
+			{{Name: "Guiding Bolt", Index: "guiding-bolt", Level: 1, AttackType: "ranged",
+				Damage: &spells.SpellDamage{DamageType: reference.Reference{Name: "Radiant"}, DamageAtSlotLevel: map[string]string{"1": "4d6", "2": "5d6"}}}},
+			{},
+			{{Name: "Fireball", Level: 3,
+				DC:     &spells.SpellDC{DCType: reference.Reference{Name: "DEX"}, DCSuccess: "half"},
+				Damage: &spells.SpellDamage{DamageType: reference.Reference{Name: "Fire"}, DamageAtSlotLevel: map[string]string{"3": "8d6", "4": "9d6"}}}},
+		},
+	}
+}
+
+func TestCharacterRollsTestSuite(t *testing.T) {
+	suite.Run(t, new(CharacterRollsTestSuite))
+}
+
+func (suite *CharacterRollsTestSuite) TestSkillCheck_NameForms() {
+	for _, name := range []string{"insight", "Insight", "INSIGHT"} {
+		check, err := suite.char.SkillCheck(name)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 6, check.Bonus)
+		assert.False(suite.T(), check.Advantage || check.Disadvantage)
+	}
+
+	check, err := suite.char.SkillCheck("sleight of hand")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "SleightOfHand", check.Name)
+
+	_, err = suite.char.SkillCheck("juggling")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CharacterRollsTestSuite) TestSkillCheck_StealthArmorDisadvantage() {
+	check, err := suite.char.SkillCheck("stealth")
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), check.Disadvantage)
+	require.Len(suite.T(), check.Notes, 1)
+	assert.Contains(suite.T(), check.Notes[0], "Padded Armor")
+
+	// Advantage cancels the armor's disadvantage
+	check.Advantage = true
+	result, err := suite.char.RollCheck(check, dice.NewRoller(1))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dice.Normal, result.Check.Mode)
+	assert.Len(suite.T(), result.Roll.Terms[0].Dice, 1)
+}
+
+func (suite *CharacterRollsTestSuite) TestSavingThrow() {
+	check := suite.char.SavingThrow(core.Wisdom)
+
+	assert.Equal(suite.T(), "save", check.Kind)
+	assert.Equal(suite.T(), 6, check.Bonus)
+
+	result, err := suite.char.RollCheck(check, dice.NewRoller(4))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), result.Roll.Natural()+6, result.Roll.Total)
+	assert.Nil(suite.T(), result.Damage)
+}
+
+func (suite *CharacterRollsTestSuite) TestWeaponAttack_FinesseUsesDexterity() {
+	check, err := suite.char.WeaponAttack("rapier")
+
+	require.NoError(suite.T(), err)
+	// Dex +2, not proficient with martial weapons
+	assert.Equal(suite.T(), 2, check.Bonus)
+	assert.Equal(suite.T(), "1d8+2", check.Damage)
+	assert.Equal(suite.T(), "piercing", check.DamageType)
+	assert.Contains(suite.T(), check.Notes[0], "not proficient")
+}
+
+func (suite *CharacterRollsTestSuite) TestWeaponAttack_Proficient() {
+	check, err := suite.char.WeaponAttack("Mace")
+
+	require.NoError(suite.T(), err)
+	// Str +0 and proficiency +3
+	assert.Equal(suite.T(), 3, check.Bonus)
+	assert.Equal(suite.T(), "1d6", check.Damage)
+	assert.Empty(suite.T(), check.Notes)
+
+	_, err = suite.char.WeaponAttack("Greataxe")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CharacterRollsTestSuite) TestWeaponProficient_SpecificWeapon() {
+	suite.char.Class.Proficiencies = append(suite.char.Class.Proficiencies, reference.Reference{Index: "rapiers", Name: "Rapiers"})
+
+	check, err := suite.char.WeaponAttack("rapier")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, check.Bonus)
+}
+
+func (suite *CharacterRollsTestSuite) TestAttackRollIncludesDamage() {
+	check, err := suite.char.WeaponAttack("mace")
+	require.NoError(suite.T(), err)
+
+	result, err := suite.char.RollCheck(check, dice.NewRoller(9))
+
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), result.Damage)
+	if result.Critical {
+		assert.Len(suite.T(), result.Damage.Terms[0].Dice, 2)
+	} else {
+		assert.Len(suite.T(), result.Damage.Terms[0].Dice, 1)
+	}
+	assert.Contains(suite.T(), result.String(), "Leki: Mace attack")
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_SaveSpellAtHigherSlot() {
+	check, err := suite.char.SpellCast("fireball", 4)
+
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), check.HasD20)
+	// 8 + proficiency 3 + Wis 3
+	assert.Equal(suite.T(), 14, check.SaveDC)
+	assert.Equal(suite.T(), "DEX", check.SaveAbility)
+	assert.Equal(suite.T(), "9d6", check.Damage)
+	assert.Equal(suite.T(), "fire", check.DamageType)
+
+	result, err := suite.char.RollCheck(check, dice.NewRoller(2))
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.Roll)
+	assert.Len(suite.T(), result.Damage.Terms[0].Dice, 9)
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_AttackSpellDefaultsToItsLevel() {
+	check, err := suite.char.SpellCast("Guiding Bolt", 0)
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), check.HasD20)
+	assert.Equal(suite.T(), 6, check.Bonus)
+	assert.Equal(suite.T(), "4d6", check.Damage)
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_CantripScalesWithCharacterLevel() {
+	check, err := suite.char.SpellCast("sacred flame", 3)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2d8", check.Damage)
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_ConcentrationNote() {
+	suite.char.Spells[1][0].Concentration = true
+
+	check, err := suite.char.SpellCast("Guiding Bolt", 0)
+
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), check.Notes, "requires concentration")
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_Errors() {
+	_, err := suite.char.SpellCast("fireball", 2)
+	assert.Error(suite.T(), err)
+
+	_, err = suite.char.SpellCast("wish", 9)
+	assert.Error(suite.T(), err)
+
+	suite.char.Class.Spellcasting = class.Spellcasting{}
+	_, err = suite.char.SpellCast("fireball", 3)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CharacterRollsTestSuite) TestMagicItems_ApplyToRolls() {
+	suite.char.Inventory.MagicItems = []inventory.MagicItem{
+		{Index: "gauntlets-of-ogre-power", Name: "Gauntlets of Ogre Power", Desc: []string{"Wondrous item, uncommon (requires attunement)"}, Attuned: true},
+		{Index: "cloak-of-protection", Name: "Cloak of Protection", Desc: []string{"Wondrous item, uncommon (requires attunement)"}},
+		{Index: "weapon-1", Name: "+1 Mace", Base: "Mace", Bonus: 1, Desc: []string{"Weapon (any), uncommon"}},
+	}
+
+	// Strength 19 from the gauntlets, proficiency +3 and the mace's +1
+	check, err := suite.char.WeaponAttack("mace")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "+1 Mace", check.Name)
+	assert.Equal(suite.T(), 8, check.Bonus)
+	assert.Equal(suite.T(), "1d6+5", check.Damage)
+	assert.Equal(suite.T(), 4, suite.char.SavingThrow(core.Strength).Bonus)
+
+	// The cloak only works once attuned
+	assert.Equal(suite.T(), 6, suite.char.SavingThrow(core.Wisdom).Bonus)
+	_, err = suite.char.Inventory.Attune("Cloak of Protection")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 7, suite.char.SavingThrow(core.Wisdom).Bonus)
+	assert.Equal(suite.T(), 10, suite.char.AbilityScores.Strength)
+}
+
+func (suite *CharacterRollsTestSuite) TestMagicItems_ApplyToSkills() {
+	suite.char.Skills.Athletics = skills.Skill{Name: "Athletics"}
+	suite.char.Skills.Perception = skills.Skill{Name: "Perception", Bonus: 3}
+	athletics, passive := suite.char.Skills.Athletics.Bonus, suite.char.PassivePerception()
+	suite.char.Inventory.MagicItems = []inventory.MagicItem{
+		{Index: "gauntlets-of-ogre-power", Name: "Gauntlets of Ogre Power", Desc: []string{"Wondrous item, uncommon (requires attunement)"}, Attuned: true},
+		{Index: "stone-of-good-luck-luckstone", Name: "Stone of Good Luck (Luckstone)", Desc: []string{"Wondrous item, uncommon (requires attunement)"}, Attuned: true},
+	}
+
+	// Strength 19 from the gauntlets and +1 to every check from the luckstone
+	assert.Equal(suite.T(), athletics+5, suite.char.SkillBonuses().Athletics.Bonus)
+	assert.Equal(suite.T(), passive+1, suite.char.PassivePerception())
+	check, err := suite.char.SkillCheck("athletics")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), athletics+5, check.Bonus)
+	assert.Equal(suite.T(), athletics, suite.char.Skills.Athletics.Bonus)
+}
+
+func (suite *CharacterRollsTestSuite) TestWeaponAttack_Supply() {
+	suite.char.Inventory.Weapons = append(suite.char.Inventory.Weapons,
+		inventory.Weapon{BaseEquipment: inventory.BaseEquipment{Index: "longbow", Name: "Longbow", Properties: []reference.Reference{{Index: "ammunition"}}}, WeaponRange: "Ranged"},
+		inventory.Weapon{BaseEquipment: inventory.BaseEquipment{Index: "javelin", Name: "Javelin", Properties: []reference.Reference{{Index: "thrown"}}}, WeaponRange: "Melee"},
+	)
+
+	for weapon, supply := range map[string]string{"longbow": "arrow", "javelin": "javelin", "mace": ""} {
+		check, err := suite.char.WeaponAttack(weapon)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), supply, check.Supply, weapon)
+	}
+}
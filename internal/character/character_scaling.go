@@ -0,0 +1,136 @@
+package character
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+)
+
+// cantripTiers are the character levels where cantrip damage goes up
+var cantripTiers = []int{1, 5, 11, 17}
+
+// modTerm matches the spellcasting modifier the SRD adds to some spells, e.g. the "+ MOD" of "1d8 + MOD"
+var modTerm = regexp.MustCompile(`\s*\+\s*MOD\b`)
+
+// spellDice picks the dice of a level keyed table for a level and adds the spellcasting modifier for MOD
+func (c *Character) spellDice(table map[string]string, level int) string {
+	expr := scaledDamage(table, level)
+	if expr == "" {
+		return ""
+	}
+
+	modifier := 0
+	if ability, err := c.SpellcastingAbility(); err == nil {
+		scores := c.Abilities()
+		modifier = scores.Modifier(ability)
+	}
+	mod := ""
+	if modifier != 0 {
+		mod = fmt.Sprintf("%+d", modifier)
+	}
+	return strings.ReplaceAll(modTerm.ReplaceAllString(expr, mod), " ", "")
+}
+
+// ScaledDice is a spell's damage or healing dice at one level
+type ScaledDice struct {
+	Level int    `json:"level"` // Slot level, or character level for cantrips
+	Dice  string `json:"dice"`
+}
+
+// SpellScaling is a spell's damage or healing at every slot level the character can cast it with,
+// or at the character levels where a cantrip gets stronger
+type SpellScaling struct {
+	Spell   string       `json:"spell"`
+	Effect  string       `json:"effect"` // e.g. "fire damage" or "healing"
+	Cantrip bool         `json:"cantrip,omitempty"`
+	Current int          `json:"current,omitempty"` // The cantrip tier the character has reached
+	Levels  []ScaledDice `json:"levels"`
+}
+
+// castableSlots returns the slot levels the character has that can cast a spell of a level,
+// none until they have a slot that high
+func (c *Character) castableSlots(spellLevel int) []int {
+	var levels []int
+	for i, count := range c.Class.SpellSlots(c.Level) {
+		if count > 0 && i+1 >= spellLevel {
+			levels = append(levels, i+1)
+		}
+	}
+	return levels
+}
+
+// SpellScaling computes a spell's damage or healing at each level, false for spells without either
+// and for spells the character has no slots to cast yet
+func (c *Character) SpellScaling(spell *spells.Spell) (SpellScaling, bool) {
+	scaling := SpellScaling{Spell: spell.Name}
+
+	var table map[string]string
+	switch {
+	case spell.Damage != nil && spell.Level == 0:
+		table = spell.Damage.DamageAtCharacterLevel
+		scaling.Effect = strings.ToLower(spell.Damage.DamageType.Name) + " damage"
+	case spell.Damage != nil:
+		table = spell.Damage.DamageAtSlotLevel
+		scaling.Effect = strings.ToLower(spell.Damage.DamageType.Name) + " damage"
+	case spell.HealAtSlotLevel != nil:
+		table = spell.HealAtSlotLevel
+		scaling.Effect = "healing"
+	}
+	if len(table) == 0 {
+		return SpellScaling{}, false
+	}
+	scaling.Effect = strings.TrimSpace(scaling.Effect)
+
+	levels := cantripTiers
+	if spell.Level == 0 {
+		scaling.Cantrip = true
+		for _, tier := range cantripTiers {
+			if c.Level >= tier {
+				scaling.Current = tier
+			}
+		}
+	} else {
+		levels = c.castableSlots(spell.Level)
+	}
+
+	for _, level := range levels {
+		if dice := c.spellDice(table, level); dice != "" {
+			scaling.Levels = append(scaling.Levels, ScaledDice{Level: level, Dice: dice})
+		}
+	}
+	return scaling, len(scaling.Levels) > 0
+}
+
+// SpellScalings computes the damage or healing tables of every spell the character knows
+func (c *Character) SpellScalings() []SpellScaling {
+	var scalings []SpellScaling
+	for level := range c.Spells {
+		for i := range c.Spells[level] {
+			if scaling, ok := c.SpellScaling(&c.Spells[level][i]); ok {
+				scalings = append(scalings, scaling)
+			}
+		}
+	}
+	return scalings
+}
+
+// String renders the scaling on one line, e.g.
+// "Fireball (fire damage by slot): 3rd 8d6 | 4th 9d6 | 5th 10d6"
+func (s SpellScaling) String() string {
+	by := "slot"
+	if s.Cantrip {
+		by = "character level"
+	}
+
+	levels := make([]string, len(s.Levels))
+	for i, level := range s.Levels {
+		levels[i] = fmt.Sprintf("%s %s", core.Ordinal(level.Level), level.Dice)
+		if s.Cantrip && level.Level == s.Current {
+			levels[i] += " (now)"
+		}
+	}
+	return fmt.Sprintf("%s (%s by %s): %s", s.Spell, s.Effect, by, strings.Join(levels, " | "))
+}
@@ -0,0 +1,104 @@
+package character_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addSpiritualWeapon and addCureWounds give the suite's cleric spells that add the spellcasting modifier
+func (suite *CharacterRollsTestSuite) addSpiritualWeapon() {
+	suite.char.Spells[2] = []spells.Spell{{Name: "Spiritual Weapon", Level: 2, AttackType: "melee",
+		Damage: &spells.SpellDamage{DamageType: reference.Reference{Name: "Force"},
+			DamageAtSlotLevel: map[string]string{"2": "1d8 + MOD", "3": "1d8 + MOD", "4": "2d8 + MOD"}}}}
+}
+
+func (suite *CharacterRollsTestSuite) addCureWounds() {
+	suite.char.Spells[1] = append(suite.char.Spells[1], spells.Spell{Name: "Cure Wounds", Level: 1,
+		HealAtSlotLevel: map[string]string{"1": "1d8 + MOD", "2": "2d8 + MOD", "3": "3d8 + MOD"}})
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellScaling_SlotLevels() {
+	suite.addSpiritualWeapon()
+
+	// A level 5 cleric has slots up to 3rd level, and Wisdom 16 adds +3
+	scaling, ok := suite.char.SpellScaling(&suite.char.Spells[2][0])
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), character.SpellScaling{
+		Spell:  "Spiritual Weapon",
+		Effect: "force damage",
+		Levels: []character.ScaledDice{{Level: 2, Dice: "1d8+3"}, {Level: 3, Dice: "1d8+3"}},
+	}, scaling)
+	assert.Equal(suite.T(), "Spiritual Weapon (force damage by slot): 2nd 1d8+3 | 3rd 1d8+3", scaling.String())
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellScaling_NoSlotsYet() {
+	// A level 5 cleric has no 4th level slots
+	guardian := spells.Spell{Name: "Guardian of Faith", Level: 4, Damage: &spells.SpellDamage{
+		DamageType: reference.Reference{Name: "Radiant"}, DamageAtSlotLevel: map[string]string{"4": "20", "5": "20"}}}
+	_, ok := suite.char.SpellScaling(&guardian)
+	assert.False(suite.T(), ok)
+
+	// Paladins get their first slots at level 2
+	paladin := &character.Character{Name: "Vex", Level: 1, Class: class.Class{Name: "Paladin"}}
+	smite := spells.Spell{Name: "Searing Smite", Level: 1, Damage: &spells.SpellDamage{
+		DamageType: reference.Reference{Name: "Fire"}, DamageAtSlotLevel: map[string]string{"1": "1d6", "2": "2d6", "5": "5d6"}}}
+	_, ok = paladin.SpellScaling(&smite)
+	assert.False(suite.T(), ok)
+
+	// Then only their 1st level slots, not every level of the spell's table
+	paladin.Level = 2
+	scaling, ok := paladin.SpellScaling(&smite)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Searing Smite (fire damage by slot): 1st 1d6", scaling.String())
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellScaling_Cantrip() {
+	suite.char.Spells[0][0].Damage.DamageAtCharacterLevel["17"] = "4d8"
+
+	scaling, ok := suite.char.SpellScaling(&suite.char.Spells[0][0])
+	require.True(suite.T(), ok)
+	assert.True(suite.T(), scaling.Cantrip)
+	assert.Equal(suite.T(), 5, scaling.Current)
+	assert.Equal(suite.T(), "Sacred Flame (radiant damage by character level): 1st 1d8 | 5th 2d8 (now) | 11th 3d8 | 17th 4d8", scaling.String())
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellScaling_Healing() {
+	suite.addCureWounds()
+
+	scaling, ok := suite.char.SpellScaling(&suite.char.Spells[1][1])
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Cure Wounds (healing by slot): 1st 1d8+3 | 2nd 2d8+3 | 3rd 3d8+3", scaling.String())
+
+	_, ok = suite.char.SpellScaling(&spells.Spell{Name: "Bless", Level: 1})
+	assert.False(suite.T(), ok)
+
+	assert.Len(suite.T(), suite.char.SpellScalings(), 4)
+}
+
+func (suite *CharacterRollsTestSuite) TestSpellCast_ModifierAndHealing() {
+	suite.addSpiritualWeapon()
+	suite.addCureWounds()
+
+	check, err := suite.char.SpellCast("spiritual weapon", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1d8+3", check.Damage)
+	require.NotNil(suite.T(), check.Scaling)
+	assert.Len(suite.T(), check.Scaling.Levels, 2)
+
+	check, err = suite.char.SpellCast("cure wounds", 2)
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), check.Damage)
+	assert.Equal(suite.T(), "2d8+3", check.Healing)
+
+	result, err := suite.char.RollCheck(check, dice.NewRoller(1))
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), result.Healing)
+	assert.Len(suite.T(), result.Healing.Terms[0].Dice, 2)
+	assert.Contains(suite.T(), result.String(), "Healing: ")
+	assert.Contains(suite.T(), result.String(), "Scaling: Cure Wounds (healing by slot)")
+}
@@ -0,0 +1,131 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+)
+
+// spellNamed reports whether one of the names is the spell's name or index
+func spellNamed(spell *spells.Spell, names []string) bool {
+	return slices.ContainsFunc(names, func(name string) bool {
+		return normalizeName(name) == normalizeName(spell.Name) || normalizeName(name) == normalizeName(spell.Index)
+	})
+}
+
+// IsAlwaysPrepared reports whether a spell is a domain or oath spell that's always prepared
+func (c *Character) IsAlwaysPrepared(spell *spells.Spell) bool {
+	return spellNamed(spell, c.AlwaysPrepared)
+}
+
+// preparing reports whether the character's class prepares its spells each day
+func (c *Character) preparing() bool {
+	return c.Class.Preparation() == class.PreparedSpells || c.Class.Preparation() == class.SpellbookSpells
+}
+
+// PreparedSpells lists the leveled spells the character has prepared, besides the always prepared ones.
+// Without a prepared list the first spells in level order are prepared, up to the limit, so a full
+// spellbook or class list still builds. Classes that know their spells prepare none.
+func (c *Character) PreparedSpells() []string {
+	if !c.preparing() {
+		return nil
+	}
+	if len(c.Prepared) > 0 {
+		return c.Prepared
+	}
+
+	var names []string
+	limit := c.PreparedLimit()
+	for level := 1; level < len(c.Spells); level++ {
+		for i := range c.Spells[level] {
+			if len(names) < limit && !c.IsAlwaysPrepared(&c.Spells[level][i]) {
+				names = append(names, c.Spells[level][i].Name)
+			}
+		}
+	}
+	return names
+}
+
+// PreparedLimit returns how many spells the character can prepare: spellcasting modifier plus level
+func (c *Character) PreparedLimit() int {
+	modifier := 0
+	if ability, err := c.SpellcastingAbility(); err == nil {
+		scores := c.Abilities()
+		modifier = scores.Modifier(ability)
+	}
+	return c.Class.PreparedCount(c.Level, modifier)
+}
+
+// CanCast returns an error when a spell isn't ready to cast today. Cantrips, always prepared spells
+// and the spells of classes that know theirs are always ready, the others have to be prepared.
+func (c *Character) CanCast(spell *spells.Spell) error {
+	if spell.Level == 0 || c.IsAlwaysPrepared(spell) || !c.preparing() {
+		return nil
+	}
+	if !spellNamed(spell, c.PreparedSpells()) {
+		return fmt.Errorf("%s doesn't have %s prepared", c.Name, spell.Name)
+	}
+	return nil
+}
+
+// CheckSpells validates the spell lists against the class's cantrips known, spells known
+// and preparation limits at the character's level
+func (c *Character) CheckSpells() error {
+	preparation := c.Class.Preparation()
+	if preparation == class.NoSpellcasting {
+		return nil
+	}
+	className := strings.ToLower(c.Class.Name)
+
+	for _, name := range c.AlwaysPrepared {
+		if _, err := c.FindSpell(name); err != nil {
+			return fmt.Errorf("always prepared spell %q isn't in %s's spell list", name, c.Name)
+		}
+	}
+
+	cantrips, known := 0, 0
+	for level, list := range c.Spells {
+		for i := range list {
+			switch {
+			case level == 0:
+				cantrips++
+			case !c.IsAlwaysPrepared(&list[i]):
+				known++
+			}
+		}
+	}
+	if limit := c.Class.CantripsKnown(c.Level); limit > 0 && cantrips > limit {
+		return fmt.Errorf("%s knows %d cantrips, but a level %d %s knows %d", c.Name, cantrips, c.Level, className, limit)
+	}
+
+	if preparation == class.KnownSpells {
+		if len(c.Prepared) > 0 {
+			return fmt.Errorf("%s knows their spells and doesn't prepare them", c.Class.Name)
+		}
+		if limit := c.Class.SpellsKnown(c.Level); known > limit {
+			return fmt.Errorf("%s knows %d spells, but a level %d %s knows %d", c.Name, known, c.Level, className, limit)
+		}
+		return nil
+	}
+
+	source := "spell list"
+	if preparation == class.SpellbookSpells {
+		source = "spellbook"
+	}
+	for _, name := range c.Prepared {
+		spell, err := c.FindSpell(name)
+		if err != nil {
+			return fmt.Errorf("%s can't prepare %q: it isn't in their %s", c.Name, name, source)
+		}
+		if spell.Level == 0 {
+			return fmt.Errorf("%s is a cantrip and doesn't need preparing", spell.Name)
+		}
+	}
+	if prepared, limit := len(c.PreparedSpells()), c.PreparedLimit(); prepared > limit {
+		return fmt.Errorf("%s has %d spells prepared, but a level %d %s can prepare %d", c.Name, prepared, c.Level, className, limit)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package character_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spellcaster builds a level 1 caster of a class casting with Wisdom 14 (+2)
+func spellcaster(className string, lists ...[]spells.Spell) *character.Character {
+	return &character.Character{
+		Name:  "Leki",
+		Level: 1,
+		Class: class.Class{
+			Name:         className,
+			Spellcasting: class.Spellcasting{SpellcastingAbility: reference.Reference{Index: "wis", Name: "WIS"}},
+		},
+		AbilityScores: abilities.AbilityScores{Wisdom: 14},
+		Spells:        lists,
+	}
+}
+
+// leveled returns level 1 spells with the given names
+func leveled(names ...string) []spells.Spell {
+	list := make([]spells.Spell, len(names))
+	for i, name := range names {
+		list[i] = spells.Spell{Name: name, Level: 1}
+	}
+	return list
+}
+
+func TestPreparedSpells(t *testing.T) {
+	c := spellcaster("Cleric", []spells.Spell{{Name: "Sacred Flame"}}, leveled("Bless", "Cure Wounds", "Shield of Faith"))
+	c.AlwaysPrepared = []string{"shield of faith"}
+
+	// Without a prepared list everything but the domain spells is prepared
+	assert.Equal(t, []string{"Bless", "Cure Wounds"}, c.PreparedSpells())
+	assert.Equal(t, 3, c.PreparedLimit())
+
+	c.Prepared = []string{"Bless"}
+	assert.NoError(t, c.CanCast(&c.Spells[0][0]))
+	assert.NoError(t, c.CanCast(&c.Spells[1][0]))
+	assert.EqualError(t, c.CanCast(&c.Spells[1][1]), "Leki doesn't have Cure Wounds prepared")
+	assert.NoError(t, c.CanCast(&c.Spells[1][2]))
+	assert.True(t, c.IsAlwaysPrepared(&c.Spells[1][2]))
+
+	// Without a prepared list the first spells up to the limit are prepared
+	c.Prepared = nil
+	c.Spells[1] = append(c.Spells[1], leveled("Sanctuary", "Command")...)
+	assert.Equal(t, []string{"Bless", "Cure Wounds", "Sanctuary"}, c.PreparedSpells())
+	assert.EqualError(t, c.CanCast(&c.Spells[1][4]), "Leki doesn't have Command prepared")
+
+	// Classes that know their spells can cast all of them
+	sorcerer := spellcaster("Sorcerer", nil, leveled("Shield", "Sleep"))
+	assert.Empty(t, sorcerer.PreparedSpells())
+	assert.NoError(t, sorcerer.CanCast(&sorcerer.Spells[1][1]))
+}
+
+func TestCheckSpells(t *testing.T) {
+	testCases := []struct {
+		name     string
+		char     *character.Character
+		prepared []string
+		expected string
+	}{
+		{"Cleric within the limit", spellcaster("Cleric", nil, leveled("Bless", "Cure Wounds", "Sanctuary")), nil, ""},
+		{"Cleric prepares too many", spellcaster("Cleric", nil, leveled("Bless", "Cure Wounds", "Sanctuary", "Command")),
+			[]string{"Bless", "Cure Wounds", "Sanctuary", "Command"}, "Leki has 4 spells prepared, but a level 1 cleric can prepare 3"},
+		{"Cleric lists more than the limit", spellcaster("Cleric", nil, leveled("Bless", "Cure Wounds", "Sanctuary", "Command")), nil, ""},
+		{"Cleric picks from a long list", spellcaster("Cleric", nil, leveled("Bless", "Cure Wounds", "Sanctuary", "Command")), []string{"bless", "command"}, ""},
+		{"Wizard prepares from the spellbook", spellcaster("Wizard", nil, leveled("Shield")), []string{"Sleep"},
+			`Leki can't prepare "Sleep": it isn't in their spellbook`},
+		{"Cantrips aren't prepared", spellcaster("Druid", []spells.Spell{{Name: "Shillelagh"}}), []string{"Shillelagh"},
+			"Shillelagh is a cantrip and doesn't need preparing"},
+		{"Too many cantrips", spellcaster("Bard", []spells.Spell{{Name: "Light"}, {Name: "Mending"}, {Name: "Message"}}), nil,
+			"Leki knows 3 cantrips, but a level 1 bard knows 2"},
+		{"Too many spells known", spellcaster("Sorcerer", nil, leveled("Shield", "Sleep", "Burning Hands")), nil,
+			"Leki knows 3 spells, but a level 1 sorcerer knows 2"},
+		{"Known spells aren't prepared", spellcaster("Warlock", nil, leveled("Hex")), []string{"Hex"},
+			"Warlock knows their spells and doesn't prepare them"},
+		{"Non-casters aren't checked", spellcaster("Fighter", nil, leveled("Shield", "Sleep", "Bless", "Command", "Hex")), nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.char.Prepared = tc.prepared
+			err := tc.char.CheckSpells()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCheckSpells_AlwaysPrepared(t *testing.T) {
+	c := spellcaster("Cleric", nil, leveled("Bless", "Cure Wounds", "Sanctuary", "Command"))
+	c.AlwaysPrepared = []string{"Command"}
+	require.NoError(t, c.CheckSpells(), "domain spells don't count against the limit")
+
+	c.AlwaysPrepared = []string{"Heroism"}
+	assert.EqualError(t, c.CheckSpells(), `always prepared spell "Heroism" isn't in Leki's spell list`)
+}
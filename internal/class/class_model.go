@@ -2,6 +2,10 @@ package class
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/reference"
 )
 
@@ -32,8 +36,9 @@ type ProficiencyChoice struct {
 }
 
 type OptionGroup struct {
-	OptionSetType string   `json:"option_set_type"`
-	Options       []Option `json:"options"`
+	OptionSetType     string               `json:"option_set_type"`
+	Options           []Option             `json:"options"`
+	EquipmentCategory *reference.Reference `json:"equipment_category,omitempty"` // Set when choosing from a whole category
 }
 
 type Option struct {
@@ -42,6 +47,7 @@ type Option struct {
 	Count      int                  `json:"count,omitempty"`
 	Of         *reference.Reference `json:"of,omitempty"`
 	Choice     *ChoiceGroup         `json:"choice,omitempty"`
+	Items      []Option             `json:"items,omitempty"` // Everything granted together by a "multiple" option
 }
 
 type ChoiceGroup struct {
@@ -56,6 +62,30 @@ type EquipmentCategory struct {
 	EquipmentCategory reference.Reference `json:"equipment_category"`
 }
 
+// String describes an option for choosing between them, e.g. "2 Handaxe" or "any simple weapon"
+func (o Option) String() string {
+	switch o.OptionType {
+	case "counted_reference":
+		if o.Count > 1 {
+			return fmt.Sprintf("%d %s", o.Count, o.Of.Name)
+		}
+		return o.Of.Name
+	case "choice":
+		return o.Choice.Desc
+	case "multiple":
+		parts := make([]string, len(o.Items))
+		for i, item := range o.Items {
+			parts[i] = item.String()
+		}
+		return strings.Join(parts, ", ")
+	default:
+		if o.Item != nil {
+			return o.Item.Name
+		}
+		return o.OptionType
+	}
+}
+
 // --- Starting Equipment ---
 
 type StartingEquipment struct {
@@ -95,6 +125,286 @@ type SpellcastingInfo struct {
 	Desc []string `json:"desc"`
 }
 
+// --- Progression ---
+
+// SubclassLevel returns the class level at which a subclass is chosen
+func (c *Class) SubclassLevel() int {
+	switch strings.ToLower(c.Name) {
+	case "cleric", "sorcerer", "warlock":
+		return 1
+	case "druid", "wizard":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// IsASILevel reports whether the class gains an Ability Score Improvement (or feat) at the given level
+func (c *Class) IsASILevel(level int) bool {
+	switch level {
+	case 4, 8, 12, 16, 19:
+		return true
+	case 6, 14:
+		return strings.EqualFold(c.Name, "fighter")
+	case 10:
+		return strings.EqualFold(c.Name, "rogue")
+	default:
+		return false
+	}
+}
+
+// fullCasterSlots is the spell slots per slot level (1st first) for each level of a full caster
+var fullCasterSlots = [][]int{
+	{2}, {3}, {4, 2}, {4, 3}, {4, 3, 2}, {4, 3, 3}, {4, 3, 3, 1}, {4, 3, 3, 2}, {4, 3, 3, 3, 1}, {4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1}, {4, 3, 3, 3, 2, 1}, {4, 3, 3, 3, 2, 1, 1}, {4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1}, {4, 3, 3, 3, 2, 1, 1, 1}, {4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1}, {4, 3, 3, 3, 3, 2, 1, 1, 1}, {4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// PactMagic reports whether the class's spell slots come back on a short rest (Warlock)
+func (c *Class) PactMagic() bool {
+	return strings.EqualFold(c.Name, "warlock")
+}
+
+// SpellSlots returns the number of spell slots per slot level (index 0 is 1st level) at a class level
+func (c *Class) SpellSlots(level int) []int {
+	if level < 1 || level > 20 {
+		return nil
+	}
+
+	switch strings.ToLower(c.Name) {
+	case "bard", "cleric", "druid", "sorcerer", "wizard":
+		return slices.Clone(fullCasterSlots[level-1])
+	case "paladin", "ranger":
+		if level < 2 {
+			return nil
+		}
+		return slices.Clone(fullCasterSlots[(level+1)/2-1])
+	case "warlock":
+		// Pact Magic: a few slots, all of the highest slot level available
+		count, slotLevel := 2, min((level+1)/2, 5)
+		switch {
+		case level == 1:
+			count = 1
+		case level >= 17:
+			count = 4
+		case level >= 11:
+			count = 3
+		}
+		slots := make([]int, slotLevel)
+		slots[slotLevel-1] = count
+		return slots
+	default:
+		return nil
+	}
+}
+
+// Preparation is how a class chooses the spells it can cast on a given day
+type Preparation int
+
+const (
+	NoSpellcasting  Preparation = iota
+	KnownSpells                 // Bard, Ranger, Sorcerer, Warlock: a fixed number learned at level up
+	PreparedSpells              // Cleric, Druid, Paladin: prepared each day from the whole class list
+	SpellbookSpells             // Wizard: prepared each day from the spells copied into the spellbook
+)
+
+// Preparation returns how the class picks its spells
+func (c *Class) Preparation() Preparation {
+	switch strings.ToLower(c.Name) {
+	case "bard", "ranger", "sorcerer", "warlock":
+		return KnownSpells
+	case "cleric", "druid", "paladin":
+		return PreparedSpells
+	case "wizard":
+		return SpellbookSpells
+	default:
+		return NoSpellcasting
+	}
+}
+
+// RitualCaster reports whether the class can cast ritual spells without a slot. Bards, Clerics and
+// Druids cast the rituals they know or have prepared, Wizards any ritual in their spellbook.
+func (c *Class) RitualCaster() bool {
+	switch strings.ToLower(c.Name) {
+	case "bard", "cleric", "druid", "wizard":
+		return true
+	default:
+		return false
+	}
+}
+
+// cantripsKnown is the cantrips known at levels 1, 4 and 10 for each class with cantrips
+var cantripsKnown = map[string][3]int{
+	"bard": {2, 3, 4}, "cleric": {3, 4, 5}, "druid": {2, 3, 4}, "sorcerer": {4, 5, 6}, "warlock": {2, 3, 4}, "wizard": {3, 4, 5},
+}
+
+// spellsKnown is the spells known at each level (1st first) for classes that learn a fixed number
+var spellsKnown = map[string][20]int{
+	"bard":     {4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 15, 15, 16, 18, 19, 19, 20, 22, 22, 22},
+	"ranger":   {0, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9, 10, 10, 11, 11},
+	"sorcerer": {2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 12, 13, 13, 14, 14, 15, 15, 15, 15},
+	"warlock":  {2, 3, 4, 5, 6, 7, 8, 9, 10, 10, 11, 11, 12, 12, 13, 13, 14, 14, 15, 15},
+}
+
+// CantripsKnown returns how many cantrips the class knows at a level
+func (c *Class) CantripsKnown(level int) int {
+	counts, ok := cantripsKnown[strings.ToLower(c.Name)]
+	switch {
+	case !ok || level < 1:
+		return 0
+	case level >= 10:
+		return counts[2]
+	case level >= 4:
+		return counts[1]
+	default:
+		return counts[0]
+	}
+}
+
+// SpellsKnown returns how many leveled spells a class that learns its spells knows at a level,
+// 0 for classes that prepare them
+func (c *Class) SpellsKnown(level int) int {
+	counts, ok := spellsKnown[strings.ToLower(c.Name)]
+	if !ok || level < 1 || level > 20 {
+		return 0
+	}
+	return counts[level-1]
+}
+
+// PreparedCount returns how many spells a preparing class can have prepared: the spellcasting modifier
+// plus the class level (half the level for Paladins), at least one. Classes that don't prepare return 0.
+func (c *Class) PreparedCount(level, modifier int) int {
+	switch {
+	case c.Preparation() != PreparedSpells && c.Preparation() != SpellbookSpells:
+		return 0
+	case strings.EqualFold(c.Name, "paladin"):
+		if level < 2 {
+			return 0
+		}
+		return max(1, modifier+level/2)
+	default:
+		return max(1, modifier+level)
+	}
+}
+
+// Resource is a limited use class feature, such as Rage or Ki
+type Resource struct {
+	Name      string `json:"name"`
+	Max       int    `json:"max"`
+	ShortRest bool   `json:"short_rest"` // Recovered on a short rest, otherwise only on a long rest
+}
+
+// Resources returns the limited use features the class has at a level.
+// Features whose uses depend on an ability modifier (e.g. Bardic Inspiration) aren't included.
+func (c *Class) Resources(level int) []Resource {
+	var resources []Resource
+	add := func(name string, uses int, shortRest bool) {
+		if uses > 0 {
+			resources = append(resources, Resource{Name: name, Max: uses, ShortRest: shortRest})
+		}
+	}
+	// atLevels returns how many thresholds the level has reached
+	atLevels := func(thresholds ...int) int {
+		n := 0
+		for _, t := range thresholds {
+			if level >= t {
+				n++
+			}
+		}
+		return n
+	}
+
+	switch strings.ToLower(c.Name) {
+	case "barbarian":
+		if level < 20 {
+			add("Rage", 1+atLevels(1, 3, 6, 12, 17), false)
+		}
+	case "cleric":
+		add("Channel Divinity", atLevels(2, 6, 18), true)
+	case "druid":
+		add("Wild Shape", 2*atLevels(2), true)
+	case "fighter":
+		add("Second Wind", 1, true)
+		add("Action Surge", atLevels(2, 17), true)
+	case "monk":
+		if level >= 2 {
+			add("Ki", level, true)
+		}
+	case "paladin":
+		add("Lay on Hands", 5*level, false)
+		add("Channel Divinity", atLevels(3), true)
+	case "sorcerer":
+		if level >= 2 {
+			add("Sorcery Points", level, false)
+		}
+	case "wizard":
+		add("Arcane Recovery", 1, false)
+	}
+
+	return resources
+}
+
+// WildShapeLimit is the most powerful beast a druid can Wild Shape into
+type WildShapeLimit struct {
+	MaxCR  float64
+	NoFly  bool
+	NoSwim bool
+}
+
+// WildShape returns the beasts a druid of a level can turn into, false for other classes and before level 2.
+// Circle of the Moon druids reach CR 1 at level 2 and a CR of a third of their level from level 6.
+func (c *Class) WildShape(level int, subclass string) (WildShapeLimit, bool) {
+	if !strings.EqualFold(c.Name, "druid") || level < 2 {
+		return WildShapeLimit{}, false
+	}
+
+	limit := WildShapeLimit{MaxCR: 0.25, NoFly: true, NoSwim: true}
+	switch {
+	case level >= 8:
+		limit = WildShapeLimit{MaxCR: 1}
+	case level >= 4:
+		limit = WildShapeLimit{MaxCR: 0.5, NoFly: true}
+	}
+
+	if strings.Contains(strings.ToLower(subclass), "moon") {
+		limit.MaxCR = max(1, float64(level/3))
+	}
+	return limit, true
+}
+
+// startingWealth is the dice each class rolls for starting gold, multiplied by 10 except for the Monk
+var startingWealth = map[string]string{
+	"barbarian": "2d4", "bard": "5d4", "cleric": "5d4", "druid": "2d4", "fighter": "5d4", "monk": "5d4",
+	"paladin": "5d4", "ranger": "5d4", "rogue": "4d4", "sorcerer": "3d4", "warlock": "4d4", "wizard": "4d4",
+}
+
+// StartingWealth returns the class's starting gold dice and multiplier, e.g. "5d4" x 10 gp
+func (c *Class) StartingWealth() (string, int) {
+	name := strings.ToLower(c.Name)
+	expr, ok := startingWealth[name]
+	if !ok {
+		return "", 0
+	}
+	if name == "monk" {
+		return expr, 1
+	}
+	return expr, 10
+}
+
+// RollStartingGold rolls the class's starting wealth in gold pieces
+func (c *Class) RollStartingGold(roller *dice.Roller) (int, error) {
+	expr, multiplier := c.StartingWealth()
+	if expr == "" {
+		return 0, fmt.Errorf("no starting wealth for class %q", c.Name)
+	}
+	result, err := dice.Eval(roller, expr, "starting gold")
+	if err != nil {
+		return 0, err
+	}
+	return result.Total * multiplier, nil
+}
+
 // PrintFeatures TODO:  prints the class features
 func (c *Class) PrintFeatures() {
 
@@ -2,8 +2,11 @@ package class_test
 
 import (
 	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/reference"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -265,3 +268,212 @@ func TestComplexClass(t *testing.T) {
 	assert.Len(t, testClass.StartingEquipmentOptions, 1)
 	assert.Len(t, testClass.MultiClassing.Prerequisites, 1)
 }
+
+// TestSubclassLevel tests the level each class picks its subclass at
+func TestSubclassLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected int
+	}{
+		{"Cleric", 1},
+		{"Warlock", 1},
+		{"Wizard", 2},
+		{"Druid", 2},
+		{"Fighter", 3},
+		{"Rogue", 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &class.Class{Name: tc.name}
+			assert.Equal(t, tc.expected, c.SubclassLevel())
+		})
+	}
+}
+
+// TestIsASILevel tests the standard and class-specific ability score improvement levels
+func TestIsASILevel(t *testing.T) {
+	wizard := &class.Class{Name: "Wizard"}
+	fighter := &class.Class{Name: "Fighter"}
+	rogue := &class.Class{Name: "Rogue"}
+
+	for _, level := range []int{4, 8, 12, 16, 19} {
+		assert.True(t, wizard.IsASILevel(level), "level %d", level)
+	}
+	assert.False(t, wizard.IsASILevel(5))
+	assert.False(t, wizard.IsASILevel(6))
+	assert.False(t, wizard.IsASILevel(10))
+
+	assert.True(t, fighter.IsASILevel(6))
+	assert.True(t, fighter.IsASILevel(14))
+	assert.False(t, fighter.IsASILevel(10))
+
+	assert.True(t, rogue.IsASILevel(10))
+	assert.False(t, rogue.IsASILevel(6))
+}
+
+// TestSpellSlots tests the slot tables for full, half and pact casters
+func TestSpellSlots(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    int
+		expected []int
+	}{
+		{"Cleric", 1, []int{2}},
+		{"Wizard", 5, []int{4, 3, 2}},
+		{"Druid", 20, []int{4, 3, 3, 3, 3, 2, 2, 1, 1}},
+		{"Paladin", 1, nil},
+		{"Paladin", 2, []int{2}},
+		{"Ranger", 5, []int{4, 2}},
+		{"Warlock", 1, []int{1}},
+		{"Warlock", 5, []int{0, 0, 2}},
+		{"Warlock", 11, []int{0, 0, 0, 0, 3}},
+		{"Fighter", 10, nil},
+		{"Wizard", 21, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &class.Class{Name: tc.name}
+			assert.Equal(t, tc.expected, c.SpellSlots(tc.level))
+		})
+	}
+
+	assert.True(t, (&class.Class{Name: "Warlock"}).PactMagic())
+	assert.False(t, (&class.Class{Name: "Wizard"}).PactMagic())
+}
+
+// TestSpellPreparation tests known and prepared spell limits by class and level
+func TestSpellPreparation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		level       int
+		modifier    int
+		preparation class.Preparation
+		cantrips    int
+		known       int
+		prepared    int
+	}{
+		{"Bard", 1, 3, class.KnownSpells, 2, 4, 0},
+		{"Sorcerer", 10, 4, class.KnownSpells, 6, 11, 0},
+		{"Warlock", 4, 3, class.KnownSpells, 3, 5, 0},
+		{"Ranger", 1, 2, class.KnownSpells, 0, 0, 0},
+		{"Ranger", 5, 2, class.KnownSpells, 0, 4, 0},
+		{"Cleric", 5, 3, class.PreparedSpells, 4, 0, 8},
+		{"Druid", 1, -1, class.PreparedSpells, 2, 0, 1},
+		{"Paladin", 1, 3, class.PreparedSpells, 0, 0, 0},
+		{"Paladin", 5, 3, class.PreparedSpells, 0, 0, 5},
+		{"Wizard", 3, 4, class.SpellbookSpells, 3, 0, 7},
+		{"Fighter", 5, 3, class.NoSpellcasting, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &class.Class{Name: tc.name}
+			assert.Equal(t, tc.preparation, c.Preparation())
+			assert.Equal(t, tc.cantrips, c.CantripsKnown(tc.level))
+			assert.Equal(t, tc.known, c.SpellsKnown(tc.level))
+			assert.Equal(t, tc.prepared, c.PreparedCount(tc.level, tc.modifier))
+		})
+	}
+
+	assert.True(t, (&class.Class{Name: "Wizard"}).RitualCaster())
+	assert.True(t, (&class.Class{Name: "Bard"}).RitualCaster())
+	assert.False(t, (&class.Class{Name: "Sorcerer"}).RitualCaster())
+}
+
+// TestResources tests the limited use features granted by level
+func TestResources(t *testing.T) {
+	cleric := &class.Class{Name: "Cleric"}
+	assert.Empty(t, cleric.Resources(1))
+	assert.Equal(t, []class.Resource{{Name: "Channel Divinity", Max: 2, ShortRest: true}}, cleric.Resources(6))
+
+	barbarian := &class.Class{Name: "Barbarian"}
+	assert.Equal(t, 3, barbarian.Resources(3)[0].Max)
+	assert.False(t, barbarian.Resources(3)[0].ShortRest)
+	assert.Empty(t, barbarian.Resources(20))
+
+	fighter := &class.Class{Name: "Fighter"}
+	assert.Len(t, fighter.Resources(1), 1)
+	assert.Equal(t, "Action Surge", fighter.Resources(17)[1].Name)
+	assert.Equal(t, 2, fighter.Resources(17)[1].Max)
+
+	monk := &class.Class{Name: "Monk"}
+	assert.Equal(t, 7, monk.Resources(7)[0].Max)
+
+	assert.Equal(t, 25, (&class.Class{Name: "Paladin"}).Resources(5)[0].Max)
+}
+
+// TestWildShape tests the beasts a druid can turn into by level and circle
+func TestWildShape(t *testing.T) {
+	druid := &class.Class{Name: "Druid"}
+	tests := []struct {
+		level    int
+		subclass string
+		expected class.WildShapeLimit
+	}{
+		{2, "", class.WildShapeLimit{MaxCR: 0.25, NoFly: true, NoSwim: true}},
+		{4, "Land", class.WildShapeLimit{MaxCR: 0.5, NoFly: true}},
+		{8, "", class.WildShapeLimit{MaxCR: 1}},
+		{2, "Moon", class.WildShapeLimit{MaxCR: 1, NoFly: true, NoSwim: true}},
+		{9, "Circle of the Moon", class.WildShapeLimit{MaxCR: 3}},
+	}
+	for _, tt := range tests {
+		limit, ok := druid.WildShape(tt.level, tt.subclass)
+		assert.True(t, ok)
+		assert.Equal(t, tt.expected, limit, "level %d %s", tt.level, tt.subclass)
+	}
+
+	_, ok := druid.WildShape(1, "")
+	assert.False(t, ok)
+	_, ok = (&class.Class{Name: "Ranger"}).WildShape(8, "")
+	assert.False(t, ok)
+}
+
+// TestStartingWealth tests each class's starting gold dice
+func TestStartingWealth(t *testing.T) {
+	expr, multiplier := (&class.Class{Name: "Cleric"}).StartingWealth()
+	assert.Equal(t, "5d4", expr)
+	assert.Equal(t, 10, multiplier)
+
+	expr, multiplier = (&class.Class{Name: "Monk"}).StartingWealth()
+	assert.Equal(t, "5d4", expr)
+	assert.Equal(t, 1, multiplier)
+
+	gold, err := (&class.Class{Name: "Barbarian"}).RollStartingGold(dice.NewRoller(42))
+	require.NoError(t, err)
+	assert.Equal(t, 0, gold%10)
+	assert.GreaterOrEqual(t, gold, 20)
+	assert.LessOrEqual(t, gold, 80)
+
+	_, err = (&class.Class{Name: "Commoner"}).RollStartingGold(dice.NewRoller(42))
+	assert.Error(t, err)
+}
+
+// TestOptionString tests how starting equipment options are described
+func TestOptionString(t *testing.T) {
+	handaxes := class.Option{OptionType: "counted_reference", Count: 2, Of: &reference.Reference{Name: "Handaxe"}}
+	shield := class.Option{OptionType: "counted_reference", Count: 1, Of: &reference.Reference{Name: "Shield"}}
+	martial := class.Option{OptionType: "choice", Choice: &class.ChoiceGroup{Desc: "a martial weapon"}}
+
+	assert.Equal(t, "2 Handaxe", handaxes.String())
+	assert.Equal(t, "Shield", shield.String())
+	assert.Equal(t, "a martial weapon", martial.String())
+	assert.Equal(t, "a martial weapon, Shield", class.Option{OptionType: "multiple", Items: []class.Option{martial, shield}}.String())
+}
+
+// TestStartingEquipmentOptionsFixture tests that nested starting equipment options decode
+func (suite *ClassModelTestSuite) TestStartingEquipmentOptionsFixture() {
+	var fighter class.Class
+	core.LoadFixtureInto(suite.T(), "fighter.json", &fighter)
+
+	multiple := fighter.StartingEquipmentOptions[0].From.Options[1]
+	assert.Equal(suite.T(), "multiple", multiple.OptionType)
+	assert.Equal(suite.T(), "Leather Armor, Longbow, 20 Arrow", multiple.String())
+
+	var druid class.Class
+	core.LoadFixtureInto(suite.T(), "druid.json", &druid)
+	last := druid.StartingEquipmentOptions[len(druid.StartingEquipmentOptions)-1]
+	require.NotNil(suite.T(), last.From.EquipmentCategory)
+	assert.Equal(suite.T(), "druidic-foci", last.From.EquipmentCategory.Index)
+}
@@ -0,0 +1,249 @@
+package combat
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/play"
+)
+
+// Combatant is a character or monster in a fight, with their play state
+type Combatant struct {
+	Name       string      `json:"name"`
+	Path       string      `json:"path,omitempty"`    // Character JSON, empty for monsters
+	Monster    string      `json:"monster,omitempty"` // SRD monster index, empty for characters
+	DexMod     int         `json:"dex_mod"`
+	Initiative int         `json:"initiative"`
+	State      *play.State `json:"state"`
+
+	Character *character.Character `json:"-"` // Loaded with the fight, nil for monsters
+}
+
+// NewCharacterCombatant adds a saved character to a fight with their play state
+func NewCharacterCombatant(path string, c *character.Character, state *play.State) Combatant {
+	scores := c.Abilities()
+	return Combatant{Name: c.Name, Path: path, DexMod: scores.Modifier(core.Dexterity), State: state, Character: c}
+}
+
+// NewMonsterCombatant adds a monster to a fight at full HP under a name such as "Goblin 2"
+func NewMonsterCombatant(name string, m *monster.Monster) Combatant {
+	return Combatant{Name: name, Monster: m.Index, DexMod: m.Modifier(core.Dexterity), State: play.NewMonsterState(name, m)}
+}
+
+// IsMonster reports whether the combatant is a monster rather than a character
+func (cb *Combatant) IsMonster() bool {
+	return cb.Path == ""
+}
+
+// Defeated reports whether the combatant is out of the fight: a monster at 0 HP or a dead character
+func (cb *Combatant) Defeated() bool {
+	return cb.State.Dead() || (cb.IsMonster() && cb.State.HP == 0)
+}
+
+// Combat is a fight in turn order, saved so it can resume next session
+type Combat struct {
+	Name       string      `json:"name"`
+	Round      int         `json:"round"` // 0 until initiative is rolled
+	Turn       int         `json:"turn"`  // Index of the combatant whose turn it is
+	Combatants []Combatant `json:"combatants"`
+}
+
+// MonsterName names the next monster of a kind, e.g. "Goblin 3" when there are already two goblins
+func (c *Combat) MonsterName(m *monster.Monster) string {
+	count := 0
+	for _, cb := range c.Combatants {
+		if cb.Monster == m.Index {
+			count++
+		}
+	}
+	return fmt.Sprintf("%s %d", m.Name, count+1)
+}
+
+// Add adds combatants, which must have names no one else in the fight has.
+// Once initiative has been rolled they roll it as they join.
+func (c *Combat) Add(roller *dice.Roller, combatants ...Combatant) ([]string, error) {
+	var lines []string
+	for _, cb := range combatants {
+		if _, err := c.Find(cb.Name); err == nil {
+			return lines, fmt.Errorf("%s is already in the fight", cb.Name)
+		}
+		if c.Round > 0 {
+			current := c.Current().Name
+			lines = append(lines, c.rollFor(&cb, roller))
+			c.Combatants = append(c.Combatants, cb)
+			c.sort()
+			c.Turn = slices.IndexFunc(c.Combatants, func(other Combatant) bool { return other.Name == current })
+			continue
+		}
+		c.Combatants = append(c.Combatants, cb)
+		lines = append(lines, fmt.Sprintf("%s joined the fight", cb.Name))
+	}
+	return lines, nil
+}
+
+// Find returns the combatant with a name, ignoring case
+func (c *Combat) Find(name string) (*Combatant, error) {
+	for i := range c.Combatants {
+		if strings.EqualFold(c.Combatants[i].Name, strings.TrimSpace(name)) {
+			return &c.Combatants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no one named %q is in the fight", name)
+}
+
+// Remove takes a combatant out of the fight, keeping the turn with whoever's turn it is.
+// Once everyone has left, the fight waits for initiative again.
+func (c *Combat) Remove(name string) (string, error) {
+	cb, err := c.Find(name)
+	if err != nil {
+		return "", err
+	}
+	removed := cb.Name
+	i := slices.IndexFunc(c.Combatants, func(other Combatant) bool { return other.Name == removed })
+	c.Combatants = slices.Delete(c.Combatants, i, i+1)
+	if i < c.Turn {
+		c.Turn--
+	}
+	if c.Turn >= len(c.Combatants) {
+		c.Turn = 0
+	}
+	if len(c.Combatants) == 0 {
+		c.Round = 0
+	}
+	return fmt.Sprintf("%s left the fight", removed), nil
+}
+
+// rollFor rolls a combatant's initiative, a d20 plus their Dexterity modifier
+func (c *Combat) rollFor(cb *Combatant, roller *dice.Roller) string {
+	roll := roller.Roll(20, "initiative: "+cb.Name)
+	cb.Initiative = roll + cb.DexMod
+	return fmt.Sprintf("%s rolled %d for initiative (%d %+d)", cb.Name, cb.Initiative, roll, cb.DexMod)
+}
+
+// sort orders the combatants by initiative, breaking ties by Dexterity modifier then name
+func (c *Combat) sort() {
+	slices.SortStableFunc(c.Combatants, func(a, b Combatant) int {
+		return cmp.Or(cmp.Compare(b.Initiative, a.Initiative), cmp.Compare(b.DexMod, a.DexMod), cmp.Compare(a.Name, b.Name))
+	})
+}
+
+// RollInitiative rolls initiative for everyone, sorts them into turn order and starts the first round
+func (c *Combat) RollInitiative(roller *dice.Roller) ([]string, error) {
+	if len(c.Combatants) == 0 {
+		return nil, fmt.Errorf("no one is in the fight")
+	}
+
+	lines := make([]string, len(c.Combatants))
+	for i := range c.Combatants {
+		lines[i] = c.rollFor(&c.Combatants[i], roller)
+	}
+	c.sort()
+	c.Round, c.Turn = 1, 0
+	return append(lines, c.TurnStart()...), nil
+}
+
+// Current returns the combatant whose turn it is, nil before initiative is rolled
+func (c *Combat) Current() *Combatant {
+	if c.Round == 0 || len(c.Combatants) == 0 {
+		return nil
+	}
+	return &c.Combatants[c.Turn]
+}
+
+// Next ends the current turn and starts the next one, skipping defeated combatants
+func (c *Combat) Next() ([]string, error) {
+	if c.Current() == nil {
+		return nil, fmt.Errorf("roll initiative first")
+	}
+	if !slices.ContainsFunc(c.Combatants, func(cb Combatant) bool { return !cb.Defeated() }) {
+		return nil, fmt.Errorf("everyone in the fight is defeated")
+	}
+
+	for {
+		c.Turn++
+		if c.Turn == len(c.Combatants) {
+			c.Turn = 0
+			c.Round++
+		}
+		if !c.Current().Defeated() {
+			return c.TurnStart(), nil
+		}
+	}
+}
+
+// TurnStart announces whose turn it is with reminders of what affects them
+func (c *Combat) TurnStart() []string {
+	cb := c.Current()
+	lines := []string{fmt.Sprintf("Round %d: %s's turn", c.Round, cb.Name)}
+
+	state := cb.State
+	switch {
+	case !cb.IsMonster() && state.HP == 0 && !state.Dead() && !state.DeathSaves.Stable:
+		lines = append(lines, "  - Dying: roll a death saving throw")
+	case !cb.IsMonster() && state.HP == 0 && state.DeathSaves.Stable:
+		lines = append(lines, "  - Unconscious and stable")
+	}
+	if state.Concentration != "" {
+		lines = append(lines, fmt.Sprintf("  - Concentrating on %s", state.Concentration))
+	}
+	for _, dc := range state.ConcentrationSaves {
+		lines = append(lines, fmt.Sprintf("  - Owes a DC %d Constitution save to keep concentrating", dc))
+	}
+	for _, name := range state.Conditions {
+		reminder := fmt.Sprintf("  - %s", name)
+		if conditions.EffectsOf(name).Incapacitated {
+			reminder += ": incapacitated, can't take actions or reactions"
+		}
+		lines = append(lines, reminder)
+	}
+	if speed := state.CurrentSpeed(); speed != state.BaseSpeed() {
+		lines = append(lines, fmt.Sprintf("  - Speed %d (base %d)", speed, state.BaseSpeed()))
+	}
+	return lines
+}
+
+// String renders the turn order with each combatant's HP, conditions and concentration
+func (c *Combat) String() string {
+	var sb strings.Builder
+	if c.Round == 0 {
+		fmt.Fprintf(&sb, "%s: waiting for initiative\n", c.Name)
+	} else {
+		fmt.Fprintf(&sb, "%s: round %d\n", c.Name, c.Round)
+	}
+
+	width := 0
+	for _, cb := range c.Combatants {
+		width = max(width, len(cb.Name))
+	}
+	for i, cb := range c.Combatants {
+		marker := " "
+		if c.Round > 0 && i == c.Turn {
+			marker = ">"
+		}
+		fmt.Fprintf(&sb, "%s %2d  %-*s  HP %d/%d", marker, cb.Initiative, width, cb.Name, cb.State.HP, cb.State.EffectiveMaxHP())
+		if cb.State.TempHP > 0 {
+			fmt.Fprintf(&sb, " (+%d temp)", cb.State.TempHP)
+		}
+
+		var notes []string
+		if cb.Defeated() {
+			notes = append(notes, "defeated")
+		}
+		notes = append(notes, cb.State.Conditions...)
+		if cb.State.Concentration != "" {
+			notes = append(notes, "concentrating on "+cb.State.Concentration)
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(&sb, "  [%s]", strings.Join(notes, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
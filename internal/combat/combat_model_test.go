@@ -0,0 +1,205 @@
+package combat_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/combat"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var goblin = monster.Monster{
+	Index:         "goblin",
+	Name:          "Goblin",
+	HitPoints:     7,
+	Speed:         monster.Speed{Walk: "30 ft."},
+	AbilityScores: abilities.AbilityScores{Dexterity: 14},
+}
+
+// CombatTestSuite covers turn order and per-combatant tracking in a fight
+type CombatTestSuite struct {
+	suite.Suite
+	combat *combat.Combat
+	roller *dice.Roller
+}
+
+func (suite *CombatTestSuite) SetupTest() {
+	leki := &character.Character{
+		Name:          "Leki",
+		Level:         5,
+		Class:         class.Class{Name: "Cleric", HitDie: 8},
+		AbilityScores: abilities.AbilityScores{Dexterity: 12},
+		Stats:         stats.Stats{HP: 38, Speed: 30},
+	}
+
+	suite.combat = &combat.Combat{Name: "ambush"}
+	suite.roller = dice.NewRoller(1)
+	_, err := suite.combat.Add(suite.roller,
+		combat.NewCharacterCombatant("characters/leki.json", leki, play.NewState(leki)),
+		combat.NewMonsterCombatant(suite.combat.MonsterName(&goblin), &goblin),
+	)
+	require.NoError(suite.T(), err)
+	_, err = suite.combat.Add(suite.roller, combat.NewMonsterCombatant(suite.combat.MonsterName(&goblin), &goblin))
+	require.NoError(suite.T(), err)
+}
+
+func TestCombatTestSuite(t *testing.T) {
+	suite.Run(t, new(CombatTestSuite))
+}
+
+func (suite *CombatTestSuite) TestAdd() {
+	names := []string{}
+	for _, cb := range suite.combat.Combatants {
+		names = append(names, cb.Name)
+	}
+	assert.Equal(suite.T(), []string{"Leki", "Goblin 1", "Goblin 2"}, names)
+	assert.Equal(suite.T(), 1, suite.combat.Combatants[0].DexMod)
+	assert.Equal(suite.T(), 2, suite.combat.Combatants[1].DexMod)
+	assert.True(suite.T(), suite.combat.Combatants[1].IsMonster())
+	assert.Equal(suite.T(), 7, suite.combat.Combatants[1].State.HP)
+
+	_, err := suite.combat.Add(suite.roller, combat.NewMonsterCombatant("goblin 1", &goblin))
+	assert.EqualError(suite.T(), err, "goblin 1 is already in the fight")
+}
+
+func (suite *CombatTestSuite) TestRollInitiative_SortsTurnOrder() {
+	lines, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), lines, 4)
+	assert.Equal(suite.T(), 1, suite.combat.Round)
+	for i := 1; i < len(suite.combat.Combatants); i++ {
+		assert.GreaterOrEqual(suite.T(), suite.combat.Combatants[i-1].Initiative, suite.combat.Combatants[i].Initiative)
+	}
+	assert.Contains(suite.T(), lines[3], "Round 1: "+suite.combat.Current().Name+"'s turn")
+}
+
+func (suite *CombatTestSuite) TestNext_AdvancesRoundsAndSkipsDefeatedMonsters() {
+	_, err := suite.combat.Next()
+	assert.EqualError(suite.T(), err, "roll initiative first")
+
+	_, err = suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	goblin1, err := suite.combat.Find("goblin 1")
+	require.NoError(suite.T(), err)
+	_, err = goblin1.State.Damage(10)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), goblin1.Defeated())
+
+	seen := map[string]int{}
+	for range 4 {
+		lines, err := suite.combat.Next()
+		require.NoError(suite.T(), err)
+		seen[suite.combat.Current().Name]++
+		assert.Contains(suite.T(), lines[0], suite.combat.Current().Name+"'s turn")
+	}
+	assert.Zero(suite.T(), seen["Goblin 1"])
+	assert.Equal(suite.T(), 3, suite.combat.Round)
+}
+
+func (suite *CombatTestSuite) TestTurnStart_Reminders() {
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	current := suite.combat.Current()
+	_, err = current.State.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+	current.State.Conditions = []string{"Stunned"}
+
+	lines := suite.combat.TurnStart()
+
+	assert.Contains(suite.T(), lines, "  - Concentrating on Bless")
+	assert.Contains(suite.T(), lines, "  - Stunned: incapacitated, can't take actions or reactions")
+	assert.Contains(suite.T(), lines, "  - Speed 0 (base 30)")
+}
+
+func (suite *CombatTestSuite) TestTurnStart_DyingCharacter() {
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	leki, err := suite.combat.Find("leki")
+	require.NoError(suite.T(), err)
+	_, err = leki.State.Damage(38)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), leki.Defeated())
+
+	for suite.combat.Current().Name != "Leki" {
+		_, err := suite.combat.Next()
+		require.NoError(suite.T(), err)
+	}
+	assert.Contains(suite.T(), suite.combat.TurnStart(), "  - Dying: roll a death saving throw")
+}
+
+func (suite *CombatTestSuite) TestAddAfterInitiative_KeepsTheTurn() {
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	_, err = suite.combat.Next()
+	require.NoError(suite.T(), err)
+	current := suite.combat.Current().Name
+
+	lines, err := suite.combat.Add(suite.roller, combat.NewMonsterCombatant(suite.combat.MonsterName(&goblin), &goblin))
+
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), lines[0], "Goblin 3 rolled")
+	assert.Len(suite.T(), suite.combat.Combatants, 4)
+	assert.Equal(suite.T(), current, suite.combat.Current().Name)
+}
+
+func (suite *CombatTestSuite) TestRemove_KeepsTheTurn() {
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	_, err = suite.combat.Next()
+	require.NoError(suite.T(), err)
+	current := suite.combat.Current().Name
+	first := suite.combat.Combatants[0].Name
+
+	summary, err := suite.combat.Remove(first)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first+" left the fight", summary)
+	assert.Equal(suite.T(), current, suite.combat.Current().Name)
+
+	_, err = suite.combat.Remove("owlbear")
+	assert.EqualError(suite.T(), err, `no one named "owlbear" is in the fight`)
+}
+
+func (suite *CombatTestSuite) TestRemoveEveryone_WaitsForInitiative() {
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	for _, name := range []string{"Leki", "Goblin 1", "Goblin 2"} {
+		_, err = suite.combat.Remove(name)
+		require.NoError(suite.T(), err)
+	}
+	assert.Equal(suite.T(), 0, suite.combat.Round)
+	assert.Nil(suite.T(), suite.combat.Current())
+
+	lines, err := suite.combat.Add(suite.roller, combat.NewMonsterCombatant("Goblin 3", &goblin))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Goblin 3 joined the fight"}, lines)
+	_, err = suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Goblin 3", suite.combat.Current().Name)
+}
+
+func (suite *CombatTestSuite) TestString() {
+	assert.Contains(suite.T(), suite.combat.String(), "ambush: waiting for initiative")
+
+	_, err := suite.combat.RollInitiative(suite.roller)
+	require.NoError(suite.T(), err)
+	goblin2, err := suite.combat.Find("Goblin 2")
+	require.NoError(suite.T(), err)
+	_, err = goblin2.State.Damage(7)
+	require.NoError(suite.T(), err)
+
+	out := suite.combat.String()
+	assert.Contains(suite.T(), out, "ambush: round 1")
+	assert.Contains(suite.T(), out, "Goblin 2  HP 0/7  [defeated]")
+	assert.Contains(suite.T(), out, "> ")
+}
@@ -0,0 +1,234 @@
+package combat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// help lists the commands of a combat session
+const help = `Commands:
+  roll                                 Roll initiative and start round 1
+  next                                 End the turn and start the next one
+  status                               Show the turn order
+  damage|heal|temp <who> <amount>      Change a combatant's hit points
+  condition add|remove <condition> <who>
+  concentrate <who> on <spell>         Start concentrating, ending any other concentration
+  drop <who>                           End concentration
+  concentration-save <who>             Roll a character's Constitution save to keep concentrating
+  death-save <who>                     Roll a death saving throw for a dying character
+  add <monster> [xN]                   Add SRD monsters, e.g. "add goblin x2"
+  remove <who>                         Take a combatant out of the fight
+  undo <who>                           Undo a combatant's last change
+  quit                                 Save and leave, the fight can be resumed later`
+
+// Session runs combat commands typed at a prompt, saving the fight after every change
+type Session struct {
+	Combat  *Combat
+	Fetcher core.Fetcher
+	Roller  *dice.Roller
+	Save    func(c *Combat) error
+	Out     io.Writer
+}
+
+// Run reads commands until quit or the end of the input
+func (s *Session) Run(in io.Reader) error {
+	fmt.Fprintln(s.Out, s.Combat)
+	fmt.Fprintln(s.Out, `Type "help" for commands.`)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(s.Out, "combat> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(s.Out)
+			return scanner.Err()
+		}
+		quit, err := s.Exec(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(s.Out, "✗ %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// Exec runs a single command, reporting whether the session should end
+func (s *Session) Exec(line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+	command, args := strings.ToLower(fields[0]), fields[1:]
+
+	var lines []string
+	var err error
+	switch command {
+	case "help":
+		fmt.Fprintln(s.Out, help)
+		return false, nil
+	case "status":
+		fmt.Fprintln(s.Out, s.Combat)
+		return false, nil
+	case "quit", "exit":
+		return true, nil
+	case "roll":
+		if s.Combat.Round > 0 {
+			return false, fmt.Errorf("initiative was already rolled, it's round %d", s.Combat.Round)
+		}
+		lines, err = s.Combat.RollInitiative(s.Roller)
+	case "next":
+		lines, err = s.Combat.Next()
+	case "damage", "heal", "temp":
+		lines, err = s.changeHP(command, args)
+	case "condition":
+		lines, err = s.condition(args)
+	case "concentrate":
+		lines, err = s.concentrate(args)
+	case "drop":
+		lines, err = s.withCombatant(strings.Join(args, " "), func(cb *Combatant) (string, error) {
+			return cb.State.EndConcentration()
+		})
+	case "concentration-save":
+		lines, err = s.withCombatant(strings.Join(args, " "), func(cb *Combatant) (string, error) {
+			if cb.Character == nil {
+				return "", fmt.Errorf("roll %s's Constitution save and drop its concentration if it fails", cb.Name)
+			}
+			return cb.State.ConcentrationSave(cb.Character, s.Roller)
+		})
+	case "death-save":
+		lines, err = s.withCombatant(strings.Join(args, " "), func(cb *Combatant) (string, error) {
+			if cb.IsMonster() {
+				return "", fmt.Errorf("monsters don't make death saving throws")
+			}
+			return cb.State.DeathSave(s.Roller)
+		})
+	case "undo":
+		lines, err = s.withCombatant(strings.Join(args, " "), func(cb *Combatant) (string, error) {
+			return cb.State.Undo()
+		})
+	case "add":
+		lines, err = s.addMonsters(strings.Join(args, " "))
+	case "remove":
+		var summary string
+		summary, err = s.Combat.Remove(strings.Join(args, " "))
+		lines = []string{"✓ " + summary}
+	default:
+		return false, fmt.Errorf("unknown command %q, type \"help\" for commands", command)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range lines {
+		fmt.Fprintln(s.Out, line)
+	}
+	return false, s.Save(s.Combat)
+}
+
+// withCombatant applies a change to a combatant's play state and describes it
+func (s *Session) withCombatant(name string, change func(cb *Combatant) (string, error)) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name the combatant")
+	}
+	cb, err := s.Combat.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := change(cb)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{fmt.Sprintf("✓ %s %s", cb.Name, summary)}
+	if cb.Defeated() {
+		lines = append(lines, fmt.Sprintf("  %s is defeated", cb.Name))
+	}
+	return lines, nil
+}
+
+// changeHP damages, heals or grants temporary HP, e.g. "damage goblin 2 7"
+func (s *Session) changeHP(command string, args []string) ([]string, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("usage: %s <who> <amount>", command)
+	}
+	amount, err := strconv.Atoi(args[len(args)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", args[len(args)-1])
+	}
+
+	return s.withCombatant(strings.Join(args[:len(args)-1], " "), func(cb *Combatant) (string, error) {
+		switch command {
+		case "damage":
+			return cb.State.Damage(amount)
+		case "heal":
+			return cb.State.Heal(amount)
+		default:
+			return cb.State.SetTempHP(amount)
+		}
+	})
+}
+
+// condition applies or removes an SRD condition, e.g. "condition add poisoned goblin 1"
+func (s *Session) condition(args []string) ([]string, error) {
+	if len(args) < 3 || (args[0] != "add" && args[0] != "remove") {
+		return nil, fmt.Errorf("usage: condition add|remove <condition> <who>")
+	}
+	name := strings.Join(args[2:], " ")
+
+	if args[0] == "remove" {
+		return s.withCombatant(name, func(cb *Combatant) (string, error) {
+			return cb.State.RemoveCondition(args[1])
+		})
+	}
+
+	var condition conditions.Condition
+	if err := conditions.FetchConditionWithFetcher(s.Fetcher, args[1], &condition); err != nil {
+		return nil, fmt.Errorf("unknown condition %q: %w", args[1], err)
+	}
+	return s.withCombatant(name, func(cb *Combatant) (string, error) {
+		return cb.State.AddCondition(condition)
+	})
+}
+
+// concentrate starts concentration, e.g. "concentrate leki on spirit guardians"
+func (s *Session) concentrate(args []string) ([]string, error) {
+	name, spell, ok := strings.Cut(strings.Join(args, " "), " on ")
+	if !ok {
+		return nil, fmt.Errorf("usage: concentrate <who> on <spell>")
+	}
+	return s.withCombatant(name, func(cb *Combatant) (string, error) {
+		return cb.State.Concentrate(strings.TrimSpace(spell))
+	})
+}
+
+// addMonsters fetches SRD monsters and adds them to the fight, e.g. "goblin x2"
+func (s *Session) addMonsters(entry string) ([]string, error) {
+	if entry == "" {
+		return nil, fmt.Errorf("usage: add <monster> [xN]")
+	}
+	name, count := template.ParseQuantity(entry)
+
+	var m monster.Monster
+	if err := monster.FetchMonsterWithFetcher(s.Fetcher, name, &m); err != nil {
+		return nil, fmt.Errorf("unknown monster %q: %w", name, err)
+	}
+
+	var lines []string
+	for range count {
+		added, err := s.Combat.Add(s.Roller, NewMonsterCombatant(s.Combat.MonsterName(&m), &m))
+		lines = append(lines, added...)
+		if err != nil {
+			return lines, err
+		}
+	}
+	return lines, nil
+}
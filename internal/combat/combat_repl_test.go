@@ -0,0 +1,140 @@
+package combat_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/combat"
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFetcher serves goblins and conditions without the network
+type MockFetcher struct{}
+
+func (m *MockFetcher) FetchJSON(property reference.Fetchable, input string) error {
+	switch d := property.(type) {
+	case *monster.Monster:
+		if input != "goblin" {
+			return errors.New("404 not found")
+		}
+		*d = goblin
+	case *conditions.Condition:
+		d.Index = input
+		d.Name = strings.ToUpper(input[:1]) + input[1:]
+	}
+	return nil
+}
+
+// newSession starts a session over an empty fight, counting saves
+func newSession(saves *int) (*combat.Session, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &combat.Session{
+		Combat:  &combat.Combat{Name: "ambush"},
+		Fetcher: &MockFetcher{},
+		Roller:  dice.NewRoller(1),
+		Save: func(*combat.Combat) error {
+			*saves++
+			return nil
+		},
+		Out: out,
+	}, out
+}
+
+func TestSession_Run(t *testing.T) {
+	saves := 0
+	session, out := newSession(&saves)
+
+	err := session.Run(strings.NewReader(strings.Join([]string{
+		"add goblin x2",
+		"roll",
+		"damage goblin 1 3",
+		"condition add poisoned goblin 2",
+		"concentrate goblin 2 on darkness",
+		"drop goblin 2",
+		"heal goblin 1 10",
+		"undo goblin 1",
+		"condition remove poisoned goblin 2",
+		"next",
+		"status",
+		"quit",
+		"damage goblin 1 99",
+	}, "\n")))
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, saves)
+	text := out.String()
+	assert.Contains(t, text, "Goblin 1 joined the fight")
+	assert.Contains(t, text, "Goblin 2 rolled")
+	assert.Contains(t, text, "✓ Goblin 1 took 3 damage")
+	assert.Contains(t, text, "✓ Goblin 2 is now poisoned")
+	assert.Contains(t, text, "✓ Goblin 2 is concentrating on darkness")
+	assert.Contains(t, text, "✓ Goblin 2 stopped concentrating on darkness")
+	assert.Contains(t, text, "✓ Goblin 1 healed 3 HP")
+	assert.Contains(t, text, `✓ Goblin 1 reverted "healed 3 HP"`)
+	assert.Contains(t, text, "✓ Goblin 2 is no longer poisoned")
+	assert.Contains(t, text, "Round 1: ")
+
+	goblin1, err := session.Combat.Find("Goblin 1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, goblin1.State.HP, "commands after quit aren't run")
+}
+
+func TestSession_Exec(t *testing.T) {
+	saves := 0
+	session, out := newSession(&saves)
+	_, err := session.Exec("add goblin")
+	require.NoError(t, err)
+
+	t.Run("reports errors without saving", func(t *testing.T) {
+		before := saves
+		for line, expected := range map[string]string{
+			"next":                        "roll initiative first",
+			"damage goblin":               "usage: damage <who> <amount>",
+			"damage goblin 1 lots":        `invalid amount "lots"`,
+			"heal owlbear 3":              `no one named "owlbear" is in the fight`,
+			"concentrate goblin 1":        "usage: concentrate <who> on <spell>",
+			"condition poison gob":        "usage: condition add|remove <condition> <who>",
+			"death-save goblin 1":         "monsters don't make death saving throws",
+			"add beholder":                `unknown monster "beholder": 404 not found`,
+			"fireball":                    `unknown command "fireball", type "help" for commands`,
+			"drop":                        "name the combatant",
+			"concentration-save goblin 1": "roll Goblin 1's Constitution save and drop its concentration if it fails",
+			"remove the dungeon cat":      `no one named "the dungeon cat" is in the fight`,
+		} {
+			_, err := session.Exec(line)
+			assert.EqualError(t, err, expected, line)
+		}
+		assert.Equal(t, before, saves)
+	})
+
+	t.Run("announces defeated monsters", func(t *testing.T) {
+		_, err := session.Exec("damage goblin 1 7")
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Goblin 1 is defeated")
+	})
+
+	t.Run("help and blank lines don't save", func(t *testing.T) {
+		before := saves
+		for _, line := range []string{"", "help", "status"} {
+			quit, err := session.Exec(line)
+			require.NoError(t, err)
+			assert.False(t, quit)
+		}
+		assert.Equal(t, before, saves)
+		assert.Contains(t, out.String(), "concentrate <who> on <spell>")
+	})
+
+	t.Run("can't roll initiative twice", func(t *testing.T) {
+		_, err := session.Exec("roll")
+		require.NoError(t, err)
+		_, err = session.Exec("roll")
+		assert.EqualError(t, err, "initiative was already rolled, it's round 1")
+	})
+}
@@ -0,0 +1,15 @@
+package conditions
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/core"
+)
+
+// FetchConditionWithFetcher fetches a condition by name using a custom fetcher (for testing)
+func FetchConditionWithFetcher(fetcher core.Fetcher, name string, condition *Condition) error {
+	return fetcher.FetchJSON(condition, name)
+}
+
+// FetchCondition fetches a condition by name using the default fetcher
+func FetchCondition(name string, condition *Condition) error {
+	return FetchConditionWithFetcher(core.DefaultFetcher, name, condition)
+}
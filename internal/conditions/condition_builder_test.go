@@ -0,0 +1,53 @@
+package conditions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockFetcherWithFixtures struct {
+	mock.Mock
+	t *testing.T
+}
+
+func (m *MockFetcherWithFixtures) FetchJSON(property reference.Fetchable, input string) error {
+	args := m.Called(property, input)
+
+	if args.Error(0) == nil && input != "" {
+		core.LoadFixtureInto(m.t, input+".json", property)
+	}
+
+	return args.Error(0)
+}
+
+func TestFetchConditionWithFetcher(t *testing.T) {
+	t.Run("loads the condition", func(t *testing.T) {
+		fetcher := &MockFetcherWithFixtures{t: t}
+		fetcher.On("FetchJSON", mock.AnythingOfType("*conditions.Condition"), "poisoned").Return(nil)
+
+		var condition conditions.Condition
+		err := conditions.FetchConditionWithFetcher(fetcher, "poisoned", &condition)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Poisoned", condition.Name)
+		assert.Len(t, condition.Desc, 1)
+		fetcher.AssertExpectations(t)
+	})
+
+	t.Run("returns fetch errors", func(t *testing.T) {
+		fetcher := &MockFetcherWithFixtures{t: t}
+		fetcher.On("FetchJSON", mock.Anything, "sleepy").Return(errors.New("404 not found"))
+
+		var condition conditions.Condition
+		err := conditions.FetchConditionWithFetcher(fetcher, "sleepy", &condition)
+
+		assert.EqualError(t, err, "404 not found")
+	})
+}
@@ -0,0 +1,98 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition represents a D&D 5e condition such as Poisoned or Prone
+type Condition struct {
+	Index string   `json:"index"`
+	Name  string   `json:"name"`
+	Desc  []string `json:"desc"`
+	URL   string   `json:"url"`
+}
+
+func (c *Condition) GetEndpoint() string {
+	return "conditions/"
+}
+
+func (c *Condition) Print() {
+	fmt.Printf("Condition: %s\n", c.Name)
+	for _, line := range c.Desc {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// Effects are the mechanical effects of conditions and exhaustion on a character
+type Effects struct {
+	CheckDisadvantage   bool // Ability checks
+	AttackDisadvantage  bool // The character's own attack rolls
+	AttackAdvantage     bool
+	SaveDisadvantage    bool // Every saving throw
+	DexSaveDisadvantage bool
+	AutoFailStrDexSaves bool
+	Incapacitated       bool
+	SpeedZero           bool
+	SpeedHalved         bool
+	HPMaxHalved         bool
+	Dead                bool
+}
+
+// Combine merges two sets of effects, keeping every flag either one sets
+func (e Effects) Combine(other Effects) Effects {
+	return Effects{
+		CheckDisadvantage:   e.CheckDisadvantage || other.CheckDisadvantage,
+		AttackDisadvantage:  e.AttackDisadvantage || other.AttackDisadvantage,
+		AttackAdvantage:     e.AttackAdvantage || other.AttackAdvantage,
+		SaveDisadvantage:    e.SaveDisadvantage || other.SaveDisadvantage,
+		DexSaveDisadvantage: e.DexSaveDisadvantage || other.DexSaveDisadvantage,
+		AutoFailStrDexSaves: e.AutoFailStrDexSaves || other.AutoFailStrDexSaves,
+		Incapacitated:       e.Incapacitated || other.Incapacitated,
+		SpeedZero:           e.SpeedZero || other.SpeedZero,
+		SpeedHalved:         e.SpeedHalved || other.SpeedHalved,
+		HPMaxHalved:         e.HPMaxHalved || other.HPMaxHalved,
+		Dead:                e.Dead || other.Dead,
+	}
+}
+
+// EffectsOf returns the effects of a condition on the affected character, by name or index.
+// Effects that only matter to others (e.g. attacks against a prone creature) aren't modelled.
+func EffectsOf(name string) Effects {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "blinded":
+		return Effects{AttackDisadvantage: true}
+	case "frightened":
+		return Effects{CheckDisadvantage: true, AttackDisadvantage: true}
+	case "grappled":
+		return Effects{SpeedZero: true}
+	case "incapacitated":
+		return Effects{Incapacitated: true}
+	case "invisible":
+		return Effects{AttackAdvantage: true}
+	case "paralyzed", "petrified", "stunned", "unconscious":
+		return Effects{Incapacitated: true, SpeedZero: true, AutoFailStrDexSaves: true}
+	case "poisoned":
+		return Effects{CheckDisadvantage: true, AttackDisadvantage: true}
+	case "prone":
+		return Effects{AttackDisadvantage: true}
+	case "restrained":
+		return Effects{SpeedZero: true, AttackDisadvantage: true, DexSaveDisadvantage: true}
+	default:
+		// Charmed and deafened have no effect on the character's own rolls
+		return Effects{}
+	}
+}
+
+// ExhaustionEffects returns the cumulative effects of an exhaustion level (0-6)
+func ExhaustionEffects(level int) Effects {
+	return Effects{
+		CheckDisadvantage:  level >= 1,
+		SpeedHalved:        level >= 2,
+		AttackDisadvantage: level >= 3,
+		SaveDisadvantage:   level >= 3,
+		HPMaxHalved:        level >= 4,
+		SpeedZero:          level >= 5,
+		Dead:               level >= 6,
+	}
+}
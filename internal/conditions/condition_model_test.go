@@ -0,0 +1,55 @@
+package conditions_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEndpoint(t *testing.T) {
+	c := &conditions.Condition{}
+	assert.Equal(t, "conditions/", c.GetEndpoint())
+}
+
+func TestEffectsOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected conditions.Effects
+	}{
+		{"Poisoned", conditions.Effects{CheckDisadvantage: true, AttackDisadvantage: true}},
+		{"grappled", conditions.Effects{SpeedZero: true}},
+		{"Restrained", conditions.Effects{SpeedZero: true, AttackDisadvantage: true, DexSaveDisadvantage: true}},
+		{"stunned", conditions.Effects{Incapacitated: true, SpeedZero: true, AutoFailStrDexSaves: true}},
+		{"invisible", conditions.Effects{AttackAdvantage: true}},
+		{"charmed", conditions.Effects{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, conditions.EffectsOf(tc.name))
+		})
+	}
+}
+
+func TestExhaustionEffects(t *testing.T) {
+	assert.Equal(t, conditions.Effects{}, conditions.ExhaustionEffects(0))
+	assert.Equal(t, conditions.Effects{CheckDisadvantage: true}, conditions.ExhaustionEffects(1))
+
+	four := conditions.ExhaustionEffects(4)
+	assert.True(t, four.SpeedHalved)
+	assert.True(t, four.SaveDisadvantage)
+	assert.True(t, four.HPMaxHalved)
+	assert.False(t, four.SpeedZero)
+
+	assert.True(t, conditions.ExhaustionEffects(6).Dead)
+}
+
+func TestEffectsCombine(t *testing.T) {
+	combined := conditions.EffectsOf("prone").Combine(conditions.ExhaustionEffects(2))
+
+	assert.True(t, combined.AttackDisadvantage)
+	assert.True(t, combined.CheckDisadvantage)
+	assert.True(t, combined.SpeedHalved)
+	assert.False(t, combined.SpeedZero)
+}
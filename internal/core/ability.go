@@ -1,5 +1,10 @@
 package core
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Ability Enum for easier & more consistent lookup and assignment
 type Ability int
 
@@ -16,3 +21,23 @@ const (
 func (a Ability) String() string {
 	return [...]string{"Strength", "Dexterity", "Constitution", "Intelligence", "Wisdom", "Charisma"}[a]
 }
+
+// ParseAbility converts a full name or 3-letter abbreviation (e.g. "wis", "Wisdom") into an Ability
+func ParseAbility(name string) (Ability, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "str", "strength":
+		return Strength, nil
+	case "dex", "dexterity":
+		return Dexterity, nil
+	case "con", "constitution":
+		return Constitution, nil
+	case "int", "intelligence":
+		return Intelligence, nil
+	case "wis", "wisdom":
+		return Wisdom, nil
+	case "cha", "charisma":
+		return Charisma, nil
+	default:
+		return Ability(0), fmt.Errorf("unknown ability %q", name)
+	}
+}
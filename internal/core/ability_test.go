@@ -197,3 +197,33 @@ func ExampleAbility_String() {
 	str := core.Wisdom.String()
 	_ = str // "Wisdom"
 }
+
+// TestParseAbility tests parsing abbreviations and full names into abilities
+func TestParseAbility(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected core.Ability
+	}{
+		{"str", core.Strength},
+		{"Dexterity", core.Dexterity},
+		{"CON", core.Constitution},
+		{"int", core.Intelligence},
+		{" wis ", core.Wisdom},
+		{"charisma", core.Charisma},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ability, err := core.ParseAbility(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ability)
+		})
+	}
+}
+
+// TestParseAbilityInvalid tests that unknown names return an error
+func TestParseAbilityInvalid(t *testing.T) {
+	_, err := core.ParseAbility("luck")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "luck")
+}
@@ -212,7 +212,7 @@ func TestFetchJSON_RealJSON_PaddedArmor(t *testing.T) {
 	// Basic fields
 	assert.Equal(t, "padded-armor", armor.Index)
 	assert.Equal(t, "Padded Armor", armor.Name)
-	assert.Equal(t, 8, armor.Weight)
+	assert.Equal(t, 8.0, armor.Weight)
 	assert.Equal(t, "/api/2014/equipment/padded-armor", armor.URL)
 
 	// Armor specific
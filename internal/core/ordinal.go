@@ -0,0 +1,18 @@
+package core
+
+import "strconv"
+
+// Ordinal renders a number as "1st", "2nd", "3rd", "11th", for spell and slot levels
+func Ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,14 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdinal(t *testing.T) {
+	for n, expected := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 17: "17th", 21: "21st"} {
+		assert.Equal(t, expected, core.Ordinal(n))
+	}
+}
@@ -0,0 +1,110 @@
+package damage
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/race"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// Types lists the indexes of the SRD damage types
+var Types = []string{
+	"acid", "bludgeoning", "cold", "fire", "force", "lightning", "necrotic",
+	"piercing", "poison", "psychic", "radiant", "slashing", "thunder",
+}
+
+// draconicAncestry maps a dragonborn's ancestry color to its damage type
+var draconicAncestry = map[string]string{
+	"black": "acid", "blue": "lightning", "brass": "fire", "bronze": "lightning", "copper": "acid",
+	"gold": "fire", "green": "poison", "red": "fire", "silver": "cold", "white": "cold",
+}
+
+// itemResistance matches magic items with a chosen resistance, e.g. "Ring of Resistance (Fire)"
+var itemResistance = regexp.MustCompile(`(?i)resistance \((\w+)\)`)
+
+// FetchDamageTypeWithFetcher fetches a damage type by name using a custom fetcher (for testing)
+func FetchDamageTypeWithFetcher(fetcher core.Fetcher, name string, damageType *DamageType) error {
+	return fetcher.FetchJSON(damageType, name)
+}
+
+// FetchDamageType fetches a damage type by name using the default fetcher
+func FetchDamageType(name string, damageType *DamageType) error {
+	return FetchDamageTypeWithFetcher(core.DefaultFetcher, name, damageType)
+}
+
+// TypeReference returns the SRD reference for a damage type index, empty when there's no index
+func TypeReference(index string) reference.Reference {
+	index = strings.ToLower(strings.TrimSpace(index))
+	if index == "" {
+		return reference.Reference{}
+	}
+	return reference.Reference{
+		Index: index,
+		Name:  strings.ToUpper(index[:1]) + index[1:],
+		URL:   "/api/2014/damage-types/" + index,
+	}
+}
+
+// defense builds a Defense against an SRD damage type index
+func defense(index string, kind Kind, source string) Defense {
+	return Defense{DamageType: TypeReference(index), Kind: kind, Source: source}
+}
+
+// TraitDefenses returns the defenses granted by a race trait.
+// Dragonborn pick their ancestry color through the subrace, e.g. subrace = "red".
+func TraitDefenses(trait reference.Reference, subrace string) Defenses {
+	switch trait.Index {
+	case "hellish-resistance":
+		return Defenses{defense("fire", Resistance, trait.Name)}
+	case "dwarven-resilience":
+		return Defenses{defense("poison", Resistance, trait.Name)}
+	case "damage-resistance":
+		color := strings.ToLower(strings.TrimSpace(subrace))
+		if damageType, ok := draconicAncestry[color]; ok {
+			return Defenses{defense(damageType, Resistance, trait.Name)}
+		}
+	}
+	return nil
+}
+
+// FeatureDefenses returns the defenses granted by class and subclass features at a level
+func FeatureDefenses(c *class.Class, subclass string, level int) Defenses {
+	switch {
+	case strings.EqualFold(c.Name, "monk") && level >= 10:
+		return Defenses{defense("poison", Immunity, "Purity of Body")}
+	case strings.EqualFold(c.Name, "druid") && strings.EqualFold(subclass, "land") && level >= 10:
+		return Defenses{defense("poison", Immunity, "Nature's Ward")}
+	}
+	return nil
+}
+
+// ItemDefenses returns the defenses granted by a magic item, by name
+func ItemDefenses(name string) Defenses {
+	if match := itemResistance.FindStringSubmatch(name); match != nil {
+		if damageType := strings.ToLower(match[1]); slices.Contains(Types, damageType) {
+			return Defenses{defense(damageType, Resistance, name)}
+		}
+	}
+	if strings.EqualFold(name, "Periapt of Proof against Poison") {
+		return Defenses{defense("poison", Immunity, name)}
+	}
+	return nil
+}
+
+// BuildDefenses derives a character's resistances, immunities and vulnerabilities
+// from race traits, class features and magic items
+func BuildDefenses(r *race.Race, subrace string, c *class.Class, subclass string, level int, items []string) Defenses {
+	var defenses Defenses
+	for _, trait := range r.Traits {
+		defenses = append(defenses, TraitDefenses(trait, subrace)...)
+	}
+	defenses = append(defenses, FeatureDefenses(c, subclass, level)...)
+	for _, item := range items {
+		defenses = append(defenses, ItemDefenses(item)...)
+	}
+	return defenses
+}
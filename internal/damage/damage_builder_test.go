@@ -0,0 +1,91 @@
+package damage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/kwford18/MKDIRagons/internal/race"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockFetcherWithFixtures struct {
+	mock.Mock
+	t *testing.T
+}
+
+func (m *MockFetcherWithFixtures) FetchJSON(property reference.Fetchable, input string) error {
+	args := m.Called(property, input)
+
+	if args.Error(0) == nil && input != "" {
+		core.LoadFixtureInto(m.t, input+".json", property)
+	}
+
+	return args.Error(0)
+}
+
+func TestFetchDamageTypeWithFetcher(t *testing.T) {
+	fetcher := &MockFetcherWithFixtures{t: t}
+	fetcher.On("FetchJSON", mock.AnythingOfType("*damage.DamageType"), "fire").Return(nil)
+	fetcher.On("FetchJSON", mock.Anything, "sparkly").Return(errors.New("404 not found"))
+
+	var fire damage.DamageType
+	require.NoError(t, damage.FetchDamageTypeWithFetcher(fetcher, "fire", &fire))
+	assert.Equal(t, "Fire", fire.Name)
+	assert.Equal(t, damage.TypeReference("fire"), fire.Reference())
+	assert.Equal(t, reference.Reference{}, damage.TypeReference(""))
+
+	var unknown damage.DamageType
+	assert.Error(t, damage.FetchDamageTypeWithFetcher(fetcher, "sparkly", &unknown))
+}
+
+func TestTraitDefenses(t *testing.T) {
+	hellish := reference.Reference{Index: "hellish-resistance", Name: "Hellish Resistance"}
+	resilience := reference.Reference{Index: "dwarven-resilience", Name: "Dwarven Resilience"}
+	draconic := reference.Reference{Index: "damage-resistance", Name: "Damage Resistance"}
+
+	assert.Equal(t, "fire", damage.TraitDefenses(hellish, "")[0].DamageType.Index)
+	assert.Equal(t, "poison", damage.TraitDefenses(resilience, "hill dwarf")[0].DamageType.Index)
+	assert.Equal(t, "cold", damage.TraitDefenses(draconic, "Silver")[0].DamageType.Index)
+	assert.Empty(t, damage.TraitDefenses(draconic, ""))
+	assert.Empty(t, damage.TraitDefenses(reference.Reference{Index: "darkvision"}, ""))
+}
+
+func TestFeatureDefenses(t *testing.T) {
+	monk := &class.Class{Name: "Monk"}
+	assert.Empty(t, damage.FeatureDefenses(monk, "", 9))
+	assert.Equal(t, damage.Immunity, damage.FeatureDefenses(monk, "", 10)[0].Kind)
+
+	druid := &class.Class{Name: "Druid"}
+	assert.Len(t, damage.FeatureDefenses(druid, "Land", 10), 1)
+	assert.Empty(t, damage.FeatureDefenses(druid, "Moon", 10))
+}
+
+func TestItemDefenses(t *testing.T) {
+	ring := damage.ItemDefenses("Ring of Resistance (Lightning)")
+	assert.Equal(t, "lightning", ring[0].DamageType.Index)
+	assert.Equal(t, "Ring of Resistance (Lightning)", ring[0].Source)
+
+	assert.Equal(t, damage.Immunity, damage.ItemDefenses("Periapt of Proof against Poison")[0].Kind)
+	assert.Empty(t, damage.ItemDefenses("Ring of Resistance (Cheese)"))
+	assert.Empty(t, damage.ItemDefenses("Abacus"))
+}
+
+func TestBuildDefenses(t *testing.T) {
+	tiefling := &race.Race{
+		Name:   "Tiefling",
+		Traits: []reference.Reference{{Index: "darkvision"}, {Index: "hellish-resistance", Name: "Hellish Resistance"}},
+	}
+
+	defenses := damage.BuildDefenses(tiefling, "", &class.Class{Name: "Monk"}, "", 10, []string{"Armor of Resistance (Cold)"})
+
+	require.Len(t, defenses, 3)
+	assert.Equal(t, "Hellish Resistance", defenses[0].Source)
+	assert.Equal(t, "Purity of Body", defenses[1].Source)
+	assert.Equal(t, "cold", defenses[2].DamageType.Index)
+}
@@ -0,0 +1,98 @@
+package damage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// DamageType represents an SRD damage type such as fire or slashing
+type DamageType struct {
+	Index string   `json:"index"`
+	Name  string   `json:"name"`
+	Desc  []string `json:"desc"`
+	URL   string   `json:"url"`
+}
+
+func (d *DamageType) GetEndpoint() string {
+	return "damage-types/"
+}
+
+// Reference returns the damage type as a reference, the form stored on characters
+func (d *DamageType) Reference() reference.Reference {
+	return reference.Reference{Index: d.Index, Name: d.Name, URL: d.URL}
+}
+
+// Kind is how a defense changes damage of its type
+type Kind string
+
+const (
+	Resistance    Kind = "resistance"    // Halves the damage
+	Immunity      Kind = "immunity"      // Prevents the damage
+	Vulnerability Kind = "vulnerability" // Doubles the damage
+)
+
+// Defense is a resistance, immunity or vulnerability to a damage type and where it comes from
+type Defense struct {
+	DamageType reference.Reference `json:"damage_type"`
+	Kind       Kind                `json:"kind"`
+	Source     string              `json:"source"`
+}
+
+// String renders the defense as e.g. "fire resistance from Hellish Resistance"
+func (d Defense) String() string {
+	return fmt.Sprintf("%s %s from %s", strings.ToLower(d.DamageType.Name), d.Kind, d.Source)
+}
+
+// Defenses is every defense a character has
+type Defenses []Defense
+
+// find returns the first defense of a kind against a damage type
+func (ds Defenses) find(damageType string, kind Kind) (Defense, bool) {
+	for _, d := range ds {
+		if d.Kind == kind && (strings.EqualFold(d.DamageType.Index, damageType) || strings.EqualFold(d.DamageType.Name, damageType)) {
+			return d, true
+		}
+	}
+	return Defense{}, false
+}
+
+// Apply adjusts an amount of damage for the defenses against its type, returning the reasons for any change.
+// Immunity prevents it, otherwise resistance halves (rounded down) and then vulnerability doubles it.
+// Several defenses of the same kind still only count once.
+func (ds Defenses) Apply(amount int, damageType string) (int, []string) {
+	if damageType == "" {
+		return amount, nil
+	}
+
+	if d, ok := ds.find(damageType, Immunity); ok {
+		return 0, []string{fmt.Sprintf("prevented by %s", d)}
+	}
+
+	var reasons []string
+	if d, ok := ds.find(damageType, Resistance); ok {
+		amount /= 2
+		reasons = append(reasons, fmt.Sprintf("halved by %s", d))
+	}
+	if d, ok := ds.find(damageType, Vulnerability); ok {
+		amount *= 2
+		reasons = append(reasons, fmt.Sprintf("doubled by %s", d))
+	}
+	return amount, reasons
+}
+
+// Print lists the defenses grouped by kind
+func (ds Defenses) Print() {
+	for _, kind := range []Kind{Resistance, Immunity, Vulnerability} {
+		var names []string
+		for _, d := range ds {
+			if d.Kind == kind {
+				names = append(names, fmt.Sprintf("%s (%s)", d.DamageType.Name, d.Source))
+			}
+		}
+		if len(names) > 0 {
+			fmt.Printf("Damage %s: %s\n", map[Kind]string{Resistance: "Resistances", Immunity: "Immunities", Vulnerability: "Vulnerabilities"}[kind], strings.Join(names, ", "))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package damage_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEndpoint(t *testing.T) {
+	d := &damage.DamageType{}
+	assert.Equal(t, "damage-types/", d.GetEndpoint())
+}
+
+func TestDefenseString(t *testing.T) {
+	d := damage.Defense{DamageType: damage.TypeReference("fire"), Kind: damage.Resistance, Source: "Hellish Resistance"}
+	assert.Equal(t, "fire resistance from Hellish Resistance", d.String())
+}
+
+func TestDefensesApply(t *testing.T) {
+	defenses := damage.Defenses{
+		{DamageType: damage.TypeReference("fire"), Kind: damage.Resistance, Source: "Hellish Resistance"},
+		{DamageType: damage.TypeReference("fire"), Kind: damage.Resistance, Source: "Ring of Resistance (Fire)"},
+		{DamageType: damage.TypeReference("poison"), Kind: damage.Immunity, Source: "Purity of Body"},
+		{DamageType: damage.TypeReference("cold"), Kind: damage.Vulnerability, Source: "Curse"},
+		{DamageType: damage.TypeReference("radiant"), Kind: damage.Resistance, Source: "Blessing"},
+		{DamageType: damage.TypeReference("radiant"), Kind: damage.Vulnerability, Source: "Curse"},
+	}
+
+	testCases := []struct {
+		name       string
+		amount     int
+		damageType string
+		expected   int
+		reasons    int
+	}{
+		{"resistance halves rounding down, once", 7, "fire", 3, 1},
+		{"matches by name", 7, "Fire", 3, 1},
+		{"immunity prevents", 20, "poison", 0, 1},
+		{"vulnerability doubles", 5, "cold", 10, 1},
+		{"resistance then vulnerability", 7, "radiant", 6, 2},
+		{"no defense", 7, "slashing", 7, 0},
+		{"untyped damage", 7, "", 7, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, reasons := defenses.Apply(tc.amount, tc.damageType)
+			assert.Equal(t, tc.expected, amount)
+			assert.Len(t, reasons, tc.reasons)
+		})
+	}
+
+	_, reasons := defenses.Apply(7, "fire")
+	assert.Equal(t, []string{"halved by fire resistance from Hellish Resistance"}, reasons)
+}
@@ -0,0 +1,355 @@
+package dice
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDice and maxExplosions keep a single expression from rolling forever
+const (
+	maxDice       = 1000
+	maxExplosions = 100
+)
+
+// Term is a single signed part of an expression: either a group of dice or a flat number
+type Term struct {
+	Sign     int    `json:"sign"`
+	Count    int    `json:"count,omitempty"` // Number of dice rolled, 0 for a flat number
+	Sides    int    `json:"sides,omitempty"`
+	Constant int    `json:"constant,omitempty"`
+	Keep     int    `json:"keep,omitempty"` // Number of dice kept, 0 keeps all of them
+	KeepLow  bool   `json:"keep_low,omitempty"`
+	Explode  bool   `json:"explode,omitempty"` // A die that rolls its max is rolled again and added
+	Text     string `json:"text"`
+}
+
+// Expression is a parsed dice expression such as "2d6+3", "4d6kh3", "1d20adv" or "d%"
+type Expression struct {
+	Source string `json:"source"`
+	Terms  []Term `json:"terms"`
+}
+
+// DieResult is one die of a term. Rolls holds more than one value when the die exploded.
+type DieResult struct {
+	Rolls   []int `json:"rolls"`
+	Value   int   `json:"value"`
+	Dropped bool  `json:"dropped,omitempty"`
+}
+
+// TermResult is the outcome of rolling a single term
+type TermResult struct {
+	Term  Term        `json:"term"`
+	Dice  []DieResult `json:"dice,omitempty"`
+	Value int         `json:"value"` // Signed contribution to the total
+}
+
+// Result is the outcome of rolling a full expression
+type Result struct {
+	Expression string       `json:"expression"`
+	Terms      []TermResult `json:"terms"`
+	Total      int          `json:"total"`
+}
+
+// readNumber reads the digits starting at pos, returning the value and the position after them
+func readNumber(s string, pos int) (int, int, bool) {
+	end := pos
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	if end == pos {
+		return 0, pos, false
+	}
+	n, err := strconv.Atoi(s[pos:end])
+	if err != nil {
+		return 0, pos, false
+	}
+	return n, end, true
+}
+
+// Parse turns a dice expression into an Expression.
+// Supported: NdS, d% (d100), flat numbers, + and -, khN/klN/kN (keep), dhN/dlN (drop),
+// ! (exploding) and adv/dis on a single d20.
+func Parse(expr string) (Expression, error) {
+	// Spaces are only allowed around + and -, so "1d6 2" isn't read as "1d62"
+	fields := strings.Fields(expr)
+	for i := 1; i < len(fields); i++ {
+		if !strings.HasSuffix(fields[i-1], "+") && !strings.HasSuffix(fields[i-1], "-") &&
+			!strings.HasPrefix(fields[i], "+") && !strings.HasPrefix(fields[i], "-") {
+			return Expression{}, fmt.Errorf("invalid dice expression %q: missing + or - between %q and %q", expr, fields[i-1], fields[i])
+		}
+	}
+
+	s := strings.ToLower(strings.Join(fields, ""))
+	if s == "" {
+		return Expression{}, fmt.Errorf("empty dice expression")
+	}
+
+	parsed := Expression{Source: expr}
+	pos := 0
+	for pos < len(s) {
+		start := pos
+		term := Term{Sign: 1}
+
+		// Sign, optional for the first term
+		if s[pos] == '+' || s[pos] == '-' {
+			if s[pos] == '-' {
+				term.Sign = -1
+			}
+			pos++
+		} else if len(parsed.Terms) > 0 {
+			return Expression{}, fmt.Errorf("invalid dice expression %q: expected + or - at %q", expr, s[pos:])
+		}
+
+		n, next, hasNumber := readNumber(s, pos)
+		pos = next
+
+		if pos < len(s) && s[pos] == 'd' {
+			// Dice group
+			pos++
+			term.Count = 1
+			if hasNumber {
+				term.Count = n
+			}
+
+			if pos < len(s) && s[pos] == '%' {
+				term.Sides = 100
+				pos++
+			} else {
+				sides, next, ok := readNumber(s, pos)
+				if !ok || sides < 1 {
+					return Expression{}, fmt.Errorf("invalid dice expression %q: missing die size", expr)
+				}
+				term.Sides = sides
+				pos = next
+			}
+
+			var err error
+			if pos, err = parseModifiers(s, pos, &term); err != nil {
+				return Expression{}, fmt.Errorf("invalid dice expression %q: %w", expr, err)
+			}
+
+			if term.Count < 1 || term.Count > maxDice {
+				return Expression{}, fmt.Errorf("invalid dice expression %q: dice count must be between 1 and %d", expr, maxDice)
+			}
+			if term.Keep > term.Count {
+				return Expression{}, fmt.Errorf("invalid dice expression %q: cannot keep %d of %d dice", expr, term.Keep, term.Count)
+			}
+		} else if hasNumber {
+			term.Constant = n
+		} else {
+			return Expression{}, fmt.Errorf("invalid dice expression %q at %q", expr, s[start:])
+		}
+
+		term.Text = strings.TrimLeft(s[start:pos], "+-")
+		parsed.Terms = append(parsed.Terms, term)
+	}
+
+	return parsed, nil
+}
+
+// parseModifiers reads keep/drop, exploding and advantage modifiers following a dice group
+func parseModifiers(s string, pos int, term *Term) (int, error) {
+	for pos < len(s) && s[pos] != '+' && s[pos] != '-' {
+		rest := s[pos:]
+		switch {
+		case strings.HasPrefix(rest, "adv"), strings.HasPrefix(rest, "dis"):
+			if term.Count != 1 || term.Keep != 0 {
+				return pos, fmt.Errorf("advantage and disadvantage apply to a single die")
+			}
+			term.Count, term.Keep, term.KeepLow = 2, 1, rest[0] == 'd'
+			pos += 3
+		case rest[0] == '!':
+			term.Explode = true
+			pos++
+		case strings.HasPrefix(rest, "kh"), strings.HasPrefix(rest, "kl"), strings.HasPrefix(rest, "dh"), strings.HasPrefix(rest, "dl"), rest[0] == 'k':
+			op := rest[:1]
+			width := 1
+			if len(rest) > 1 && (rest[1] == 'h' || rest[1] == 'l') {
+				op, width = rest[:2], 2
+			}
+			n, next, ok := readNumber(s, pos+width)
+			if !ok {
+				return pos, fmt.Errorf("missing count after %q", op)
+			}
+			switch op {
+			case "k", "kh":
+				term.Keep, term.KeepLow = n, false
+			case "kl":
+				term.Keep, term.KeepLow = n, true
+			case "dl":
+				term.Keep, term.KeepLow = term.Count-n, false
+			case "dh":
+				term.Keep, term.KeepLow = term.Count-n, true
+			}
+			if term.Keep < 1 {
+				return pos, fmt.Errorf("%q would drop every die", rest[:next-pos])
+			}
+			pos = next
+		default:
+			return pos, fmt.Errorf("unknown modifier %q", rest)
+		}
+	}
+	return pos, nil
+}
+
+// MustParse is like Parse but panics on an invalid expression. Intended for expressions built in code.
+func MustParse(expr string) Expression {
+	parsed, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Roll rolls every term of the expression, recording each die under label
+func (e Expression) Roll(roller *Roller, label string) Result {
+	result := Result{Expression: e.Source}
+
+	for _, term := range e.Terms {
+		termResult := TermResult{Term: term}
+
+		if term.Count == 0 {
+			termResult.Value = term.Sign * term.Constant
+		} else {
+			for i := 0; i < term.Count; i++ {
+				die := DieResult{}
+				roll := roller.Roll(term.Sides, label)
+				die.Rolls = append(die.Rolls, roll)
+				for explosions := 0; term.Explode && term.Sides > 1 && roll == term.Sides && explosions < maxExplosions; explosions++ {
+					roll = roller.Roll(term.Sides, label)
+					die.Rolls = append(die.Rolls, roll)
+				}
+				for _, r := range die.Rolls {
+					die.Value += r
+				}
+				termResult.Dice = append(termResult.Dice, die)
+			}
+
+			markDropped(termResult.Dice, term)
+
+			sum := 0
+			for _, die := range termResult.Dice {
+				if !die.Dropped {
+					sum += die.Value
+				}
+			}
+			termResult.Value = term.Sign * sum
+		}
+
+		result.Terms = append(result.Terms, termResult)
+		result.Total += termResult.Value
+	}
+
+	return result
+}
+
+// markDropped flags the dice a keep modifier discards, leaving rolling order intact
+func markDropped(dice []DieResult, term Term) {
+	if term.Keep == 0 || term.Keep >= len(dice) {
+		return
+	}
+
+	order := make([]int, len(dice))
+	for i := range order {
+		order[i] = i
+	}
+	// Sort indexes so the dice to keep come first
+	slices.SortStableFunc(order, func(a, b int) int {
+		if term.KeepLow {
+			return dice[a].Value - dice[b].Value
+		}
+		return dice[b].Value - dice[a].Value
+	})
+	for _, i := range order[term.Keep:] {
+		dice[i].Dropped = true
+	}
+}
+
+// Min returns the lowest total the expression can roll
+func (e Expression) Min() int {
+	total := 0
+	for _, term := range e.Terms {
+		if term.Count == 0 {
+			total += term.Sign * term.Constant
+			continue
+		}
+		kept := term.Count
+		if term.Keep > 0 {
+			kept = term.Keep
+		}
+		if term.Sign > 0 {
+			total += kept
+		} else {
+			total -= kept * term.Sides
+		}
+	}
+	return total
+}
+
+// Max returns the highest total the expression can roll, ignoring explosions
+func (e Expression) Max() int {
+	total := 0
+	for _, term := range e.Terms {
+		if term.Count == 0 {
+			total += term.Sign * term.Constant
+			continue
+		}
+		kept := term.Count
+		if term.Keep > 0 {
+			kept = term.Keep
+		}
+		if term.Sign > 0 {
+			total += kept * term.Sides
+		} else {
+			total -= kept
+		}
+	}
+	return total
+}
+
+// Eval parses expr and rolls it in one step
+func Eval(roller *Roller, expr, label string) (Result, error) {
+	parsed, err := Parse(expr)
+	if err != nil {
+		return Result{}, err
+	}
+	return parsed.Roll(roller, label), nil
+}
+
+// String renders the result as a breakdown, e.g. "4d6kh3 [6, 5, 3, (1)] + 3 = 17".
+// Dropped dice are wrapped in parentheses and exploded dice are joined with "!".
+func (r Result) String() string {
+	var sb strings.Builder
+	for i, term := range r.Terms {
+		switch {
+		case i > 0 && term.Term.Sign < 0:
+			sb.WriteString(" - ")
+		case i > 0:
+			sb.WriteString(" + ")
+		case term.Term.Sign < 0:
+			sb.WriteString("-")
+		}
+
+		if term.Term.Count == 0 {
+			sb.WriteString(strconv.Itoa(term.Term.Constant))
+			continue
+		}
+
+		dice := make([]string, len(term.Dice))
+		for j, die := range term.Dice {
+			rolls := make([]string, len(die.Rolls))
+			for k, roll := range die.Rolls {
+				rolls[k] = strconv.Itoa(roll)
+			}
+			dice[j] = strings.Join(rolls, "!")
+			if die.Dropped {
+				dice[j] = "(" + dice[j] + ")"
+			}
+		}
+		fmt.Fprintf(&sb, "%s [%s]", term.Term.Text, strings.Join(dice, ", "))
+	}
+	fmt.Fprintf(&sb, " = %d", r.Total)
+	return sb.String()
+}
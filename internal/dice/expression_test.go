@@ -0,0 +1,173 @@
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Valid(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		min, max int
+		terms    int
+	}{
+		{"1d8", 1, 8, 1},
+		{"2d6+3", 5, 15, 2},
+		{"8d6", 8, 48, 1},
+		{"d20", 1, 20, 1},
+		{"d%", 1, 100, 1},
+		{"4d6kh3", 3, 18, 1},
+		{"4d6dl1", 3, 18, 1},
+		{"2d20kl1", 1, 20, 1},
+		{"1d20adv", 1, 20, 1},
+		{"1d20dis+5", 6, 25, 2},
+		{"1d6!", 1, 6, 1},
+		{"1d10 - 2", -1, 8, 2},
+		{"-1+1d4", 0, 3, 2},
+		{"5", 5, 5, 1},
+		{"1D8 + 1D6", 2, 14, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := dice.Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Len(t, expr.Terms, tc.terms)
+			assert.Equal(t, tc.min, expr.Min())
+			assert.Equal(t, tc.max, expr.Max())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "d", "2d", "1d6+", "abc", "1d20x", "3d6kh4", "4d6dl4", "2d20adv", "1d6 2", "0d6", "1d6kh"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := dice.Parse(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse_AdvantageRollsTwoKeepsOne(t *testing.T) {
+	adv := dice.MustParse("1d20adv")
+	assert.Equal(t, 2, adv.Terms[0].Count)
+	assert.Equal(t, 1, adv.Terms[0].Keep)
+	assert.False(t, adv.Terms[0].KeepLow)
+
+	dis := dice.MustParse("1d20dis")
+	assert.True(t, dis.Terms[0].KeepLow)
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	assert.Panics(t, func() { dice.MustParse("nope") })
+}
+
+func TestRoll_SeededExact(t *testing.T) {
+	// Seed 42 rolls 3, 8 on d8
+	result, err := dice.Eval(dice.NewRoller(42), "2d8+3", "damage")
+
+	require.NoError(t, err)
+	assert.Equal(t, 14, result.Total)
+	require.Len(t, result.Terms, 2)
+	assert.Equal(t, 11, result.Terms[0].Value)
+	assert.Equal(t, 3, result.Terms[1].Value)
+	assert.Equal(t, "2d8+3", result.Expression)
+	assert.Equal(t, "2d8 [3, 8] + 3 = 14", result.String())
+}
+
+func TestRoll_KeepHighest(t *testing.T) {
+	roller := dice.NewRoller(3)
+
+	for i := 0; i < 50; i++ {
+		result := dice.MustParse("4d6kh3").Roll(roller, "stat")
+		term := result.Terms[0]
+		require.Len(t, term.Dice, 4)
+
+		dropped, lowest, sum := 0, 7, 0
+		for _, die := range term.Dice {
+			lowest = min(lowest, die.Value)
+			sum += die.Value
+			if die.Dropped {
+				dropped++
+			}
+		}
+		assert.Equal(t, 1, dropped)
+		assert.Equal(t, sum-lowest, result.Total)
+	}
+}
+
+func TestRoll_Disadvantage(t *testing.T) {
+	roller := dice.NewRoller(11)
+
+	for i := 0; i < 50; i++ {
+		result := dice.MustParse("1d20dis").Roll(roller, "check")
+		rolled := result.Terms[0].Dice
+		require.Len(t, rolled, 2)
+		assert.Equal(t, min(rolled[0].Value, rolled[1].Value), result.Total)
+	}
+}
+
+func TestRoll_Exploding(t *testing.T) {
+	roller := dice.NewRoller(5)
+	exploded := false
+
+	for i := 0; i < 100; i++ {
+		result := dice.MustParse("1d2!").Roll(roller, "boom")
+		die := result.Terms[0].Dice[0]
+
+		// Every roll but the last hit the max, the last one did not
+		for _, roll := range die.Rolls[:len(die.Rolls)-1] {
+			assert.Equal(t, 2, roll)
+		}
+		assert.Equal(t, 1, die.Rolls[len(die.Rolls)-1])
+		assert.Equal(t, die.Value, result.Total)
+		exploded = exploded || len(die.Rolls) > 1
+	}
+	assert.True(t, exploded, "at least one d2 should explode in 100 rolls")
+}
+
+func TestRoll_Percentile(t *testing.T) {
+	roller := dice.NewRoller(8)
+	for i := 0; i < 50; i++ {
+		result := dice.MustParse("d%").Roll(roller, "percent")
+		assert.GreaterOrEqual(t, result.Total, 1)
+		assert.LessOrEqual(t, result.Total, 100)
+	}
+}
+
+func TestRoll_LabelsLogged(t *testing.T) {
+	roller := dice.NewRoller(1)
+
+	dice.MustParse("3d4+1").Roll(roller, "magic missile")
+
+	log := roller.Log()
+	require.Len(t, log.Rolls, 3)
+	for _, roll := range log.Rolls {
+		assert.Equal(t, "magic missile", roll.Label)
+		assert.Equal(t, 4, roll.Sides)
+	}
+}
+
+func TestResult_String(t *testing.T) {
+	result := dice.Result{
+		Terms: []dice.TermResult{
+			{
+				Term: dice.Term{Sign: 1, Count: 4, Sides: 6, Keep: 3, Text: "4d6kh3"},
+				Dice: []dice.DieResult{
+					{Rolls: []int{6}, Value: 6},
+					{Rolls: []int{6, 2}, Value: 8},
+					{Rolls: []int{3}, Value: 3},
+					{Rolls: []int{1}, Value: 1, Dropped: true},
+				},
+				Value: 17,
+			},
+			{Term: dice.Term{Sign: -1, Constant: 2, Text: "2"}, Value: -2},
+		},
+		Total: 15,
+	}
+
+	assert.Equal(t, "4d6kh3 [6, 6!2, 3, (1)] - 2 = 15", result.String())
+}
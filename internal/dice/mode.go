@@ -0,0 +1,96 @@
+package dice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mode is whether a d20 is rolled normally, with advantage or with disadvantage
+type Mode int
+
+const (
+	Normal Mode = iota
+	Advantage
+	Disadvantage
+)
+
+func (m Mode) String() string {
+	return [...]string{"normal", "advantage", "disadvantage"}[m]
+}
+
+// MarshalText writes the mode by name in JSON output
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText reads a mode written by MarshalText
+func (m *Mode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "normal", "":
+		*m = Normal
+	case "advantage":
+		*m = Advantage
+	case "disadvantage":
+		*m = Disadvantage
+	default:
+		return fmt.Errorf("unknown roll mode %q", text)
+	}
+	return nil
+}
+
+// Resolve picks the mode once every source of advantage and disadvantage has been collected.
+// Any advantage and any disadvantage cancel out, regardless of how many sources of each.
+func Resolve(advantage, disadvantage bool) Mode {
+	switch {
+	case advantage && !disadvantage:
+		return Advantage
+	case disadvantage && !advantage:
+		return Disadvantage
+	default:
+		return Normal
+	}
+}
+
+// D20 builds the expression for a d20 roll with the given mode and bonus, e.g. "1d20adv+5"
+func D20(mode Mode, bonus int) string {
+	var sb strings.Builder
+	sb.WriteString("1d20")
+	switch mode {
+	case Advantage:
+		sb.WriteString("adv")
+	case Disadvantage:
+		sb.WriteString("dis")
+	}
+	if bonus != 0 {
+		fmt.Fprintf(&sb, "%+d", bonus)
+	}
+	return sb.String()
+}
+
+// Natural returns the kept value of the first die rolled, e.g. the d20 of an attack roll
+func (r Result) Natural() int {
+	for _, term := range r.Terms {
+		for _, die := range term.Dice {
+			if !die.Dropped {
+				return die.Value
+			}
+		}
+	}
+	return 0
+}
+
+// Crit returns a copy of the expression with the number of dice doubled, for critical hits
+func (e Expression) Crit() Expression {
+	crit := Expression{Source: e.Source, Terms: make([]Term, len(e.Terms))}
+	copy(crit.Terms, e.Terms)
+	for i, term := range crit.Terms {
+		if term.Count > 0 {
+			crit.Terms[i].Count *= 2
+			if term.Keep > 0 {
+				crit.Terms[i].Keep *= 2
+			}
+			crit.Terms[i].Text = fmt.Sprintf("%dd%d", crit.Terms[i].Count, term.Sides)
+		}
+	}
+	return crit
+}
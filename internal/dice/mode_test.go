@@ -0,0 +1,65 @@
+package dice_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, dice.Normal, dice.Resolve(false, false))
+	assert.Equal(t, dice.Advantage, dice.Resolve(true, false))
+	assert.Equal(t, dice.Disadvantage, dice.Resolve(false, true))
+	assert.Equal(t, dice.Normal, dice.Resolve(true, true))
+}
+
+func TestMode_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Mode dice.Mode `json:"mode"`
+	}{dice.Disadvantage})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mode":"disadvantage"}`, string(data))
+
+	var decoded struct {
+		Mode dice.Mode `json:"mode"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, dice.Disadvantage, decoded.Mode)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"mode":"lucky"}`), &decoded))
+}
+
+func TestD20(t *testing.T) {
+	assert.Equal(t, "1d20+5", dice.D20(dice.Normal, 5))
+	assert.Equal(t, "1d20adv-1", dice.D20(dice.Advantage, -1))
+	assert.Equal(t, "1d20dis", dice.D20(dice.Disadvantage, 0))
+
+	// Every built expression must parse
+	for _, expr := range []string{dice.D20(dice.Normal, 5), dice.D20(dice.Advantage, -1), dice.D20(dice.Disadvantage, 0)} {
+		_, err := dice.Parse(expr)
+		assert.NoError(t, err, expr)
+	}
+}
+
+func TestResult_Natural(t *testing.T) {
+	result := dice.Result{Terms: []dice.TermResult{
+		{Dice: []dice.DieResult{{Value: 4, Dropped: true}, {Value: 20}}},
+		{Value: 3},
+	}}
+	assert.Equal(t, 20, result.Natural())
+	assert.Equal(t, 0, dice.Result{}.Natural())
+}
+
+func TestExpression_Crit(t *testing.T) {
+	original := dice.MustParse("2d6+3")
+	crit := original.Crit()
+
+	assert.Equal(t, 4, crit.Terms[0].Count)
+	assert.Equal(t, 2, original.Terms[0].Count, "original expression is unchanged")
+	assert.Equal(t, 3, crit.Terms[1].Constant)
+	assert.Equal(t, 7, crit.Min())
+	assert.Equal(t, 27, crit.Max())
+}
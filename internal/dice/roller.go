@@ -0,0 +1,65 @@
+package dice
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// Roll records a single die roll
+type Roll struct {
+	Label  string `json:"label"`
+	Sides  int    `json:"sides"`
+	Result int    `json:"result"`
+}
+
+// Log is the seed of a Roller and every roll it made, in order
+type Log struct {
+	Seed  uint64 `json:"seed"`
+	Rolls []Roll `json:"rolls"`
+}
+
+// Roller rolls dice from a seeded source, logging each roll so results can be re-derived
+type Roller struct {
+	log Log
+	rng *rand.Rand
+}
+
+// NewRoller creates a Roller whose rolls are fully determined by seed
+func NewRoller(seed uint64) *Roller {
+	return &Roller{
+		log: Log{Seed: seed, Rolls: []Roll{}},
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// NewRandomRoller creates a Roller with a random seed, which is still recorded in its Log
+func NewRandomRoller() *Roller {
+	return NewRoller(rand.Uint64())
+}
+
+// SubSeed derives the seed of a separate stream of rolls, e.g. "starting gold", from one seed,
+// so rolls made for different things don't repeat each other
+func SubSeed(seed uint64, name string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return seed ^ h.Sum64()
+}
+
+// Roll rolls a single die with the given number of sides and records it under label
+func (r *Roller) Roll(sides int, label string) int {
+	result := r.rng.IntN(sides) + 1
+	r.log.Rolls = append(r.log.Rolls, Roll{Label: label, Sides: sides, Result: result})
+	return result
+}
+
+// Seed returns the seed the Roller was created with
+func (r *Roller) Seed() uint64 {
+	return r.log.Seed
+}
+
+// Log returns a copy of the seed and rolls made so far
+func (r *Roller) Log() Log {
+	rolls := make([]Roll, len(r.log.Rolls))
+	copy(rolls, r.log.Rolls)
+	return Log{Seed: r.log.Seed, Rolls: rolls}
+}
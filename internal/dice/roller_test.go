@@ -0,0 +1,68 @@
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoller_SameSeedSameRolls(t *testing.T) {
+	a := dice.NewRoller(1234)
+	b := dice.NewRoller(1234)
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, a.Roll(20, "d20"), b.Roll(20, "d20"))
+	}
+}
+
+func TestRoller_Range(t *testing.T) {
+	r := dice.NewRoller(7)
+
+	for i := 0; i < 200; i++ {
+		result := r.Roll(6, "d6")
+		assert.GreaterOrEqual(t, result, 1)
+		assert.LessOrEqual(t, result, 6)
+	}
+}
+
+func TestRoller_LogRecordsEveryRoll(t *testing.T) {
+	r := dice.NewRoller(99)
+
+	first := r.Roll(8, "HP level 2")
+	second := r.Roll(8, "HP level 3")
+
+	log := r.Log()
+	assert.Equal(t, uint64(99), log.Seed)
+	require.Len(t, log.Rolls, 2)
+	assert.Equal(t, dice.Roll{Label: "HP level 2", Sides: 8, Result: first}, log.Rolls[0])
+	assert.Equal(t, dice.Roll{Label: "HP level 3", Sides: 8, Result: second}, log.Rolls[1])
+}
+
+func TestRoller_LogIsACopy(t *testing.T) {
+	r := dice.NewRoller(5)
+	r.Roll(4, "d4")
+
+	log := r.Log()
+	log.Rolls[0].Result = 100
+
+	assert.NotEqual(t, 100, r.Log().Rolls[0].Result)
+}
+
+func TestNewRandomRoller_SeedIsReplayable(t *testing.T) {
+	r := dice.NewRandomRoller()
+	rolled := r.Roll(100, "d100")
+
+	replay := dice.NewRoller(r.Seed())
+	assert.Equal(t, rolled, replay.Roll(100, "d100"))
+}
+
+func TestSubSeed(t *testing.T) {
+	gold := dice.SubSeed(42, "starting gold")
+
+	assert.Equal(t, gold, dice.SubSeed(42, "starting gold"))
+	assert.NotEqual(t, uint64(42), gold)
+	assert.NotEqual(t, gold, dice.SubSeed(42, "other"))
+	assert.NotEqual(t, gold, dice.SubSeed(43, "starting gold"))
+}
@@ -0,0 +1,128 @@
+package encounter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// maxCandidates caps how many monsters of the wanted type a random encounter tries while filling its budget
+const maxCandidates = 12
+
+// BuildEncounterWithFetcher fetches the monsters of entries such as "Goblin x4" into an encounter,
+// using a custom fetcher (for testing)
+func BuildEncounterWithFetcher(fetcher core.Fetcher, entries []string) (*Encounter, error) {
+	e := &Encounter{}
+	for _, entry := range entries {
+		name, count := template.ParseQuantity(entry)
+		var m monster.Monster
+		if err := monster.FetchMonsterWithFetcher(fetcher, name, &m); err != nil {
+			return nil, fmt.Errorf("unknown monster %q: %w", name, err)
+		}
+		e.Add(m, count)
+	}
+	return e, nil
+}
+
+// BuildEncounter fetches the monsters of entries using the default fetcher
+func BuildEncounter(entries []string) (*Encounter, error) {
+	return BuildEncounterWithFetcher(core.DefaultFetcher, entries)
+}
+
+// Options narrow down the monsters of a random encounter
+type Options struct {
+	MinCR, MaxCR float64
+	Type         string // Monster type such as "undead", any type when empty
+	MaxMonsters  int    // 0 for no limit
+}
+
+// challengeRatings returns the challenge ratings within the options' range
+func (o Options) challengeRatings() []float64 {
+	var crs []float64
+	for _, cr := range monster.ChallengeRatings {
+		if cr >= o.MinCR && cr <= o.MaxCR {
+			crs = append(crs, cr)
+		}
+	}
+	return crs
+}
+
+// GenerateWithFetcher builds a random encounter of the target difficulty for a party of characters of
+// the given levels, using a custom fetcher (for testing). Monsters are picked at random from the
+// options' challenge ratings and type, and added until the adjusted XP reaches the target's threshold
+// without reaching the next one.
+func GenerateWithFetcher(fetcher core.Fetcher, levels []int, target Difficulty, opts Options, roller *dice.Roller) (*Encounter, error) {
+	thresholds, err := PartyThresholds(levels)
+	if err != nil {
+		return nil, err
+	}
+	if target == Trivial {
+		return nil, fmt.Errorf("pick an easy, medium, hard or deadly encounter")
+	}
+	ceiling := math.MaxInt
+	if target < Deadly {
+		ceiling = thresholds.Of(target + 1)
+	}
+
+	crs := opts.challengeRatings()
+	if len(crs) == 0 {
+		return nil, fmt.Errorf("no challenge ratings between %s and %s", monster.FormatCR(opts.MinCR), monster.FormatCR(opts.MaxCR))
+	}
+	var list monster.MonsterList
+	if err := monster.FetchMonsterListWithFetcher(fetcher, crs, &list); err != nil {
+		return nil, fmt.Errorf("failed to list monsters: %w", err)
+	}
+
+	// Shuffle the candidates with the roller so an encounter can be reproduced from its seed
+	candidates := list.Results
+	for i := len(candidates) - 1; i > 0; i-- {
+		j := roller.Roll(i+1, "encounter") - 1
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+
+	e := &Encounter{}
+	fetched := 0
+	for _, candidate := range candidates {
+		if fetched == maxCandidates {
+			break
+		}
+		var m monster.Monster
+		if err := monster.FetchMonsterWithFetcher(fetcher, candidate.Index, &m); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", candidate.Name, err)
+		}
+		// The monster list doesn't carry types, so monsters of other types are skipped without counting.
+		// So are CR 0 creatures worth no XP, such as a Frog, which can't fill any budget.
+		if (opts.Type != "" && !strings.EqualFold(m.Type, opts.Type)) || m.XP == 0 {
+			continue
+		}
+		fetched++
+
+		for opts.MaxMonsters == 0 || e.Count() < opts.MaxMonsters {
+			e.Add(m, 1)
+			if e.AdjustedXP(len(levels)) >= ceiling {
+				e.remove(m.Index)
+				break
+			}
+			if thresholds.Rate(e.AdjustedXP(len(levels))) == target {
+				return e, nil
+			}
+		}
+	}
+
+	kind := "monsters"
+	if opts.Type != "" {
+		kind = opts.Type + " monsters"
+	}
+	return nil, fmt.Errorf("couldn't build a %s encounter from %s of CR %s to %s, try widening the range",
+		target, kind, monster.FormatCR(opts.MinCR), monster.FormatCR(opts.MaxCR))
+}
+
+// Generate builds a random encounter using the default fetcher
+func Generate(levels []int, target Difficulty, opts Options, roller *dice.Roller) (*Encounter, error) {
+	return GenerateWithFetcher(core.DefaultFetcher, levels, target, opts, roller)
+}
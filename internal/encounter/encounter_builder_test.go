@@ -0,0 +1,137 @@
+package encounter_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/encounter"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFetcher serves monsters and the monster list from memory
+type MockFetcher struct {
+	Monsters map[string]monster.Monster
+	Lists    []string // Inputs the monster list was fetched with
+}
+
+func (m *MockFetcher) FetchJSON(property reference.Fetchable, input string) error {
+	switch d := property.(type) {
+	case *monster.MonsterList:
+		m.Lists = append(m.Lists, input)
+		var indexes []string
+		for index := range m.Monsters {
+			indexes = append(indexes, index)
+		}
+		slices.Sort(indexes)
+		for _, index := range indexes {
+			d.Results = append(d.Results, reference.Reference{Index: index, Name: m.Monsters[index].Name})
+		}
+		d.Count = len(d.Results)
+	case *monster.Monster:
+		found, ok := m.Monsters[input]
+		if !ok {
+			return errors.New("404 not found")
+		}
+		*d = found
+	}
+	return nil
+}
+
+func TestBuildEncounterWithFetcher(t *testing.T) {
+	fetcher := &MockFetcher{Monsters: map[string]monster.Monster{"goblin": goblin, "bugbear": bugbear}}
+
+	e, err := encounter.BuildEncounterWithFetcher(fetcher, []string{"goblin x4", "bugbear"})
+
+	require.NoError(t, err)
+	require.Len(t, e.Groups, 2)
+	assert.Equal(t, 4, e.Groups[0].Count)
+	assert.Equal(t, "Bugbear", e.Groups[1].Monster.Name)
+
+	_, err = encounter.BuildEncounterWithFetcher(fetcher, []string{"beholder"})
+	assert.ErrorContains(t, err, `unknown monster "beholder"`)
+}
+
+func TestGenerateWithFetcher(t *testing.T) {
+	zombie := monster.Monster{Index: "zombie", Name: "Zombie", Type: "undead", ChallengeRating: 0.25, XP: 50}
+	levels := []int{3, 3, 3, 3}
+
+	t.Run("fills the budget of every difficulty", func(t *testing.T) {
+		for _, target := range []encounter.Difficulty{encounter.Easy, encounter.Medium, encounter.Hard, encounter.Deadly} {
+			fetcher := &MockFetcher{Monsters: map[string]monster.Monster{"goblin": goblin, "bugbear": bugbear, "zombie": zombie}}
+
+			e, err := encounter.GenerateWithFetcher(fetcher, levels, target, encounter.Options{MaxCR: 1}, dice.NewRoller(7))
+
+			require.NoError(t, err, target)
+			rating, err := encounter.Rate(levels, e)
+			require.NoError(t, err)
+			assert.Equal(t, target, rating.Difficulty, rating.String())
+			assert.Equal(t, []string{"?challenge_rating=0,0.125,0.25,0.5,1"}, fetcher.Lists)
+		}
+	})
+
+	t.Run("filters by monster type", func(t *testing.T) {
+		fetcher := &MockFetcher{Monsters: map[string]monster.Monster{"goblin": goblin, "bugbear": bugbear, "zombie": zombie}}
+
+		e, err := encounter.GenerateWithFetcher(fetcher, levels, encounter.Medium, encounter.Options{MaxCR: 1, Type: "Undead"}, dice.NewRoller(1))
+
+		require.NoError(t, err)
+		require.Len(t, e.Groups, 1)
+		assert.Equal(t, "Zombie", e.Groups[0].Monster.Name)
+		assert.Equal(t, 6, e.Groups[0].Count)
+	})
+
+	t.Run("finds a rare type among many others", func(t *testing.T) {
+		monsters := map[string]monster.Monster{"gray-ooze": {Index: "gray-ooze", Name: "Gray Ooze", Type: "ooze", ChallengeRating: 0.5, XP: 100}}
+		for i := range 30 {
+			index := fmt.Sprintf("goblin-%02d", i)
+			monsters[index] = monster.Monster{Index: index, Name: "Goblin", Type: "humanoid", ChallengeRating: 0.25, XP: 50}
+		}
+
+		for seed := range uint64(10) {
+			e, err := encounter.GenerateWithFetcher(&MockFetcher{Monsters: monsters}, levels, encounter.Medium, encounter.Options{MaxCR: 1, Type: "ooze"}, dice.NewRoller(seed))
+
+			require.NoError(t, err, seed)
+			assert.Equal(t, "Gray Ooze", e.Groups[0].Monster.Name)
+		}
+	})
+
+	t.Run("skips monsters worth no XP", func(t *testing.T) {
+		frog := monster.Monster{Index: "frog", Name: "Frog", Type: "beast", XP: 0}
+
+		_, err := encounter.GenerateWithFetcher(&MockFetcher{Monsters: map[string]monster.Monster{"frog": frog}}, levels, encounter.Easy, encounter.Options{MaxCR: 1}, dice.NewRoller(1))
+		assert.EqualError(t, err, "couldn't build a easy encounter from monsters of CR 0 to 1, try widening the range")
+
+		e, err := encounter.GenerateWithFetcher(&MockFetcher{Monsters: map[string]monster.Monster{"frog": frog, "zombie": zombie}}, levels, encounter.Easy, encounter.Options{MaxCR: 1}, dice.NewRoller(1))
+		require.NoError(t, err)
+		assert.Equal(t, "Zombie", e.Groups[0].Monster.Name)
+	})
+
+	t.Run("is reproducible from the seed", func(t *testing.T) {
+		monsters := map[string]monster.Monster{"goblin": goblin, "bugbear": bugbear, "zombie": zombie}
+		first, err := encounter.GenerateWithFetcher(&MockFetcher{Monsters: monsters}, levels, encounter.Hard, encounter.Options{MaxCR: 1}, dice.NewRoller(42))
+		require.NoError(t, err)
+		second, err := encounter.GenerateWithFetcher(&MockFetcher{Monsters: monsters}, levels, encounter.Hard, encounter.Options{MaxCR: 1}, dice.NewRoller(42))
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("fails when the monsters can't fill the budget", func(t *testing.T) {
+		fetcher := &MockFetcher{Monsters: map[string]monster.Monster{"zombie": zombie}}
+
+		_, err := encounter.GenerateWithFetcher(fetcher, levels, encounter.Deadly, encounter.Options{MaxCR: 1, MaxMonsters: 3}, dice.NewRoller(1))
+
+		assert.EqualError(t, err, "couldn't build a deadly encounter from monsters of CR 0 to 1, try widening the range")
+	})
+
+	t.Run("rejects a trivial target", func(t *testing.T) {
+		_, err := encounter.GenerateWithFetcher(&MockFetcher{}, levels, encounter.Trivial, encounter.Options{MaxCR: 1}, dice.NewRoller(1))
+		assert.ErrorContains(t, err, "pick an easy, medium, hard or deadly encounter")
+	})
+}
@@ -0,0 +1,244 @@
+package encounter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/monster"
+)
+
+// Difficulty rates an encounter against a party's XP thresholds
+type Difficulty int
+
+const (
+	Trivial Difficulty = iota // Below the easy threshold
+	Easy
+	Medium
+	Hard
+	Deadly
+)
+
+var difficultyNames = []string{"trivial", "easy", "medium", "hard", "deadly"}
+
+func (d Difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// ParseDifficulty reads a difficulty name such as "hard", ignoring case
+func ParseDifficulty(name string) (Difficulty, error) {
+	for i, known := range difficultyNames {
+		if strings.EqualFold(strings.TrimSpace(name), known) {
+			return Difficulty(i), nil
+		}
+	}
+	return Trivial, fmt.Errorf("unknown difficulty %q, expected easy, medium, hard or deadly", name)
+}
+
+// xpThresholds are the easy, medium, hard and deadly XP thresholds of a character by level
+var xpThresholds = [20][4]int{
+	{25, 50, 75, 100},
+	{50, 100, 150, 200},
+	{75, 150, 225, 400},
+	{125, 250, 375, 500},
+	{250, 500, 750, 1100},
+	{300, 600, 900, 1400},
+	{350, 750, 1100, 1700},
+	{450, 900, 1400, 2100},
+	{550, 1100, 1600, 2400},
+	{600, 1200, 1900, 2800},
+	{800, 1600, 2400, 3600},
+	{1000, 2000, 3000, 4500},
+	{1100, 2200, 3400, 5100},
+	{1250, 2500, 3800, 5700},
+	{1400, 2800, 4300, 6400},
+	{1600, 3200, 4800, 7200},
+	{2000, 3900, 5900, 8800},
+	{2100, 4200, 6300, 9500},
+	{2400, 4900, 7300, 10900},
+	{2800, 5700, 8500, 12700},
+}
+
+// Thresholds are the adjusted XP an encounter needs to be easy, medium, hard or deadly
+type Thresholds struct {
+	Easy, Medium, Hard, Deadly int
+}
+
+// PartyThresholds adds up the XP thresholds of every character in the party by level
+func PartyThresholds(levels []int) (Thresholds, error) {
+	if len(levels) == 0 {
+		return Thresholds{}, fmt.Errorf("the party has no characters")
+	}
+
+	var t Thresholds
+	for _, level := range levels {
+		if level < 1 || level > 20 {
+			return Thresholds{}, fmt.Errorf("invalid character level %d", level)
+		}
+		row := xpThresholds[level-1]
+		t.Easy += row[0]
+		t.Medium += row[1]
+		t.Hard += row[2]
+		t.Deadly += row[3]
+	}
+	return t, nil
+}
+
+// Of returns the threshold of a difficulty, 0 for trivial
+func (t Thresholds) Of(d Difficulty) int {
+	return [...]int{0, t.Easy, t.Medium, t.Hard, t.Deadly}[d]
+}
+
+// Rate returns the highest difficulty whose threshold the adjusted XP reaches
+func (t Thresholds) Rate(adjustedXP int) Difficulty {
+	for d := Deadly; d > Trivial; d-- {
+		if adjustedXP >= t.Of(d) {
+			return d
+		}
+	}
+	return Trivial
+}
+
+// multipliers are the encounter multipliers, from a party of six or more facing one monster
+// to a party of fewer than three facing fifteen or more
+var multipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// Multiplier returns how much a group of monsters' XP counts for, e.g. x2 for three to six monsters.
+// Parties of fewer than three use the next multiplier up and parties of six or more the next one down.
+func Multiplier(monsters, partySize int) float64 {
+	var step int
+	switch {
+	case monsters <= 1:
+		step = 1
+	case monsters == 2:
+		step = 2
+	case monsters <= 6:
+		step = 3
+	case monsters <= 10:
+		step = 4
+	case monsters <= 14:
+		step = 5
+	default:
+		step = 6
+	}
+
+	switch {
+	case partySize < 3:
+		step++
+	case partySize >= 6:
+		step--
+	}
+	return multipliers[step]
+}
+
+// Group is a number of the same monster in an encounter, e.g. four goblins
+type Group struct {
+	Monster monster.Monster
+	Count   int
+}
+
+// Encounter is the monsters a party faces
+type Encounter struct {
+	Groups []Group
+}
+
+// Add adds count of a monster, growing its group if the encounter already has one
+func (e *Encounter) Add(m monster.Monster, count int) {
+	for i := range e.Groups {
+		if e.Groups[i].Monster.Index == m.Index {
+			e.Groups[i].Count += count
+			return
+		}
+	}
+	e.Groups = append(e.Groups, Group{Monster: m, Count: count})
+}
+
+// remove takes one of a monster out of the encounter, dropping its group when it's the last
+func (e *Encounter) remove(index string) {
+	for i := range e.Groups {
+		if e.Groups[i].Monster.Index != index {
+			continue
+		}
+		e.Groups[i].Count--
+		if e.Groups[i].Count == 0 {
+			e.Groups = append(e.Groups[:i], e.Groups[i+1:]...)
+		}
+		return
+	}
+}
+
+// Count returns the number of monsters
+func (e *Encounter) Count() int {
+	total := 0
+	for _, group := range e.Groups {
+		total += group.Count
+	}
+	return total
+}
+
+// XP returns the monsters' total XP before the multiplier
+func (e *Encounter) XP() int {
+	total := 0
+	for _, group := range e.Groups {
+		total += group.Monster.XP * group.Count
+	}
+	return total
+}
+
+// AdjustedXP returns the XP after the multiple monster multiplier for a party size
+func (e *Encounter) AdjustedXP(partySize int) int {
+	return int(float64(e.XP()) * Multiplier(e.Count(), partySize))
+}
+
+// Rating is how difficult an encounter is for a party
+type Rating struct {
+	Levels     []int
+	Thresholds Thresholds
+	Encounter  *Encounter
+	Multiplier float64
+	AdjustedXP int
+	Difficulty Difficulty
+}
+
+// Rate rates an encounter for a party of characters of the given levels
+func Rate(levels []int, e *Encounter) (Rating, error) {
+	thresholds, err := PartyThresholds(levels)
+	if err != nil {
+		return Rating{}, err
+	}
+	if e.Count() == 0 {
+		return Rating{}, fmt.Errorf("the encounter has no monsters")
+	}
+
+	adjusted := e.AdjustedXP(len(levels))
+	return Rating{
+		Levels:     levels,
+		Thresholds: thresholds,
+		Encounter:  e,
+		Multiplier: Multiplier(e.Count(), len(levels)),
+		AdjustedXP: adjusted,
+		Difficulty: thresholds.Rate(adjusted),
+	}, nil
+}
+
+// String renders the party's thresholds, the monsters and the rating
+func (r Rating) String() string {
+	var sb strings.Builder
+
+	levels := make([]string, len(r.Levels))
+	for i, level := range r.Levels {
+		levels[i] = fmt.Sprintf("%d", level)
+	}
+	fmt.Fprintf(&sb, "Party of %d (levels %s)\n", len(r.Levels), strings.Join(levels, ", "))
+	fmt.Fprintf(&sb, "XP Thresholds: easy %d, medium %d, hard %d, deadly %d\n",
+		r.Thresholds.Easy, r.Thresholds.Medium, r.Thresholds.Hard, r.Thresholds.Deadly)
+
+	sb.WriteString("Monsters:\n")
+	for _, group := range r.Encounter.Groups {
+		m := group.Monster
+		fmt.Fprintf(&sb, "  - %s x%d (CR %s, %s, %d XP each)\n", m.Name, group.Count, monster.FormatCR(m.ChallengeRating), m.Type, m.XP)
+	}
+
+	fmt.Fprintf(&sb, "XP: %d x%g = %d adjusted XP\n", r.Encounter.XP(), r.Multiplier, r.AdjustedXP)
+	fmt.Fprintf(&sb, "Difficulty: %s", r.Difficulty)
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package encounter_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/encounter"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	goblin  = monster.Monster{Index: "goblin", Name: "Goblin", Type: "humanoid", ChallengeRating: 0.25, XP: 50}
+	bugbear = monster.Monster{Index: "bugbear", Name: "Bugbear", Type: "humanoid", ChallengeRating: 1, XP: 200}
+)
+
+func TestPartyThresholds(t *testing.T) {
+	thresholds, err := encounter.PartyThresholds([]int{3, 3, 3, 2})
+	require.NoError(t, err)
+	assert.Equal(t, encounter.Thresholds{Easy: 275, Medium: 550, Hard: 825, Deadly: 1400}, thresholds)
+
+	_, err = encounter.PartyThresholds(nil)
+	assert.EqualError(t, err, "the party has no characters")
+
+	_, err = encounter.PartyThresholds([]int{21})
+	assert.EqualError(t, err, "invalid character level 21")
+}
+
+func TestThresholds_Rate(t *testing.T) {
+	thresholds := encounter.Thresholds{Easy: 100, Medium: 200, Hard: 300, Deadly: 400}
+	tests := map[int]encounter.Difficulty{
+		99: encounter.Trivial, 100: encounter.Easy, 250: encounter.Medium, 300: encounter.Hard, 5000: encounter.Deadly,
+	}
+	for xp, expected := range tests {
+		assert.Equal(t, expected, thresholds.Rate(xp), xp)
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	tests := []struct {
+		monsters, partySize int
+		expected            float64
+	}{
+		{1, 4, 1},
+		{2, 4, 1.5},
+		{3, 4, 2},
+		{6, 4, 2},
+		{7, 4, 2.5},
+		{11, 4, 3},
+		{15, 4, 4},
+		// Small parties use the next multiplier up, large parties the next one down
+		{1, 2, 1.5},
+		{15, 2, 5},
+		{1, 6, 0.5},
+		{4, 6, 1.5},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, encounter.Multiplier(tt.monsters, tt.partySize), "%d monsters, party of %d", tt.monsters, tt.partySize)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	d, err := encounter.ParseDifficulty(" Hard ")
+	require.NoError(t, err)
+	assert.Equal(t, encounter.Hard, d)
+	assert.Equal(t, "hard", d.String())
+
+	_, err = encounter.ParseDifficulty("impossible")
+	assert.ErrorContains(t, err, "unknown difficulty")
+}
+
+func TestRate(t *testing.T) {
+	e := &encounter.Encounter{}
+	e.Add(goblin, 3)
+	e.Add(bugbear, 1)
+	e.Add(goblin, 2)
+
+	rating, err := encounter.Rate([]int{3, 3, 3, 3}, e)
+
+	require.NoError(t, err)
+	assert.Equal(t, 6, e.Count())
+	assert.Equal(t, 450, e.XP())
+	assert.Equal(t, 2.0, rating.Multiplier)
+	assert.Equal(t, 900, rating.AdjustedXP)
+	assert.Equal(t, encounter.Hard, rating.Difficulty)
+	assert.Equal(t, `Party of 4 (levels 3, 3, 3, 3)
+XP Thresholds: easy 300, medium 600, hard 900, deadly 1600
+Monsters:
+  - Goblin x5 (CR 1/4, humanoid, 50 XP each)
+  - Bugbear x1 (CR 1, humanoid, 200 XP each)
+XP: 450 x2 = 900 adjusted XP
+Difficulty: hard`, rating.String())
+
+	_, err = encounter.Rate([]int{3}, &encounter.Encounter{})
+	assert.EqualError(t, err, "the encounter has no monsters")
+}
@@ -0,0 +1,98 @@
+package inventory
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// Valuable is a gem, art object or other treasure carried for its worth, e.g. a diamond for Revivify
+type Valuable struct {
+	Name  string `json:"name"`
+	GP    int    `json:"gp"`              // Worth of one
+	Count int    `json:"count,omitempty"` // How many are carried, 0 means one
+}
+
+// valuableWorth matches the worth after a valuable's name, e.g. the " (300 gp)" of "Diamond (300 gp)"
+var valuableWorth = regexp.MustCompile(`(?i)^(.+?)\s*\(([\d,]+)\s*gp\)$`)
+
+// ParseValuable parses a template entry such as "Diamond (300 gp)" or "Pearl (100 gp) x2"
+func ParseValuable(entry string) (Valuable, error) {
+	name, count := template.ParseQuantity(entry)
+	match := valuableWorth.FindStringSubmatch(name)
+	if match == nil {
+		return Valuable{}, fmt.Errorf("valuable %q needs its worth, e.g. \"Diamond (300 gp)\"", entry)
+	}
+	gp, err := strconv.Atoi(strings.ReplaceAll(match[2], ",", ""))
+	if err != nil {
+		return Valuable{}, fmt.Errorf("invalid worth in valuable %q", entry)
+	}
+
+	valuable := Valuable{Name: match[1], GP: gp}
+	if count > 1 {
+		valuable.Count = count
+	}
+	return valuable, nil
+}
+
+// Carried returns how many of the valuable the character carries
+func (v Valuable) Carried() int {
+	return max(1, v.Count)
+}
+
+// String renders the valuable as it's written in the template, e.g. "Pearl (100 gp) x2"
+func (v Valuable) String() string {
+	if v.Carried() > 1 {
+		return fmt.Sprintf("%s (%d gp) x%d", v.Name, v.GP, v.Carried())
+	}
+	return fmt.Sprintf("%s (%d gp)", v.Name, v.GP)
+}
+
+// SpendValuable removes a valuable used up as a material component, or as many as its count
+func (inv *Inventory) SpendValuable(spent Valuable) {
+	for i := range inv.Valuables {
+		v := &inv.Valuables[i]
+		if !strings.EqualFold(v.Name, spent.Name) || v.GP != spent.GP {
+			continue
+		}
+		left := v.Carried() - spent.Carried()
+		if left <= 0 {
+			inv.Valuables = slices.Delete(inv.Valuables, i, i+1)
+			return
+		}
+		v.Count = 0
+		if left > 1 {
+			v.Count = left
+		}
+		return
+	}
+}
+
+// Kinds of spellcasting focus, by the SRD gear category they're in
+const (
+	FocusArcane     = "arcane-foci"
+	FocusDruidic    = "druidic-foci"
+	FocusHoly       = "holy-symbols"
+	FocusInstrument = "musical-instrument" // A bard's focus
+	ComponentPouch  = "component-pouch"
+)
+
+// Focus returns the kind of spellcasting focus the item is, or "" when it isn't one
+func (i *Item) Focus() string {
+	switch {
+	case i.Index == ComponentPouch:
+		return ComponentPouch
+	case strings.EqualFold(i.ToolCategory, "musical instrument"):
+		return FocusInstrument
+	case i.GearCategory != nil:
+		switch i.GearCategory.Index {
+		case FocusArcane, FocusDruidic, FocusHoly:
+			return i.GearCategory.Index
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValuable(t *testing.T) {
+	valuable, err := inventory.ParseValuable("Diamond (300 gp)")
+	require.NoError(t, err)
+	assert.Equal(t, inventory.Valuable{Name: "Diamond", GP: 300}, valuable)
+	assert.Equal(t, "Diamond (300 gp)", valuable.String())
+
+	valuable, err = inventory.ParseValuable("Black Pearl (1,000 gp) x2")
+	require.NoError(t, err)
+	assert.Equal(t, inventory.Valuable{Name: "Black Pearl", GP: 1000, Count: 2}, valuable)
+	assert.Equal(t, "Black Pearl (1000 gp) x2", valuable.String())
+
+	_, err = inventory.ParseValuable("Diamond")
+	assert.EqualError(t, err, `valuable "Diamond" needs its worth, e.g. "Diamond (300 gp)"`)
+}
+
+func TestItem_Focus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		item     inventory.Item
+		expected string
+	}{
+		{"Component pouch", inventory.Item{BaseEquipment: inventory.BaseEquipment{Index: "component-pouch"}}, inventory.ComponentPouch},
+		{"Holy symbol", inventory.Item{GearCategory: &reference.Reference{Index: "holy-symbols"}}, inventory.FocusHoly},
+		{"Arcane focus", inventory.Item{GearCategory: &reference.Reference{Index: "arcane-foci"}}, inventory.FocusArcane},
+		{"Lute", inventory.Item{ToolCategory: "Musical Instrument"}, inventory.FocusInstrument},
+		{"Torch", inventory.Item{GearCategory: &reference.Reference{Index: "standard-gear"}}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.item.Focus())
+		})
+	}
+}
+
+func TestSpendValuable(t *testing.T) {
+	inv := inventory.Inventory{Valuables: []inventory.Valuable{{Name: "Diamond", GP: 300}, {Name: "Pearl", GP: 100, Count: 3}}}
+
+	inv.SpendValuable(inventory.Valuable{Name: "pearl", GP: 100, Count: 2})
+	inv.SpendValuable(inventory.Valuable{Name: "Diamond", GP: 300})
+	inv.SpendValuable(inventory.Valuable{Name: "Ruby", GP: 1000})
+
+	assert.Equal(t, []inventory.Valuable{{Name: "Pearl", GP: 100}}, inv.Valuables)
+}
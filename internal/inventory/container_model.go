@@ -0,0 +1,227 @@
+package inventory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// Content is a piece of equipment that comes in a pack, e.g. the 10 torches of an Explorer's Pack
+type Content struct {
+	Item     reference.Reference `json:"item"`
+	Quantity int                 `json:"quantity"`
+}
+
+// containerCapacity is how many pounds of gear each SRD container holds
+var containerCapacity = map[string]float64{
+	"backpack": 30,
+	"basket":   40,
+	"chest":    300,
+	"pouch":    6,
+	"sack":     30,
+}
+
+// IsPack reports whether the item is an equipment pack such as an Explorer's Pack
+func (i *Item) IsPack() bool {
+	return i.GearCategory != nil && i.GearCategory.Index == "equipment-packs"
+}
+
+// path identifies stowed equipment by the chain of containers it's in, e.g. "explorers-pack/backpack"
+// for the backpack that came in an Explorer's Pack, apart from a loose "backpack"
+func (b *BaseEquipment) path() string {
+	if b.Container == "" {
+		return b.Index
+	}
+	return b.Container + "/" + b.Index
+}
+
+// lookup returns the equipment that best matches an index, name or path, ignoring case and dashes.
+// A path such as "explorers-pack/backpack" matches exactly, otherwise loose equipment comes before stowed.
+func lookup(name string, candidates []*BaseEquipment) *BaseEquipment {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+	for _, equipment := range candidates {
+		if equipment.path() == name {
+			return equipment
+		}
+	}
+	var stowed *BaseEquipment
+	for _, equipment := range candidates {
+		if equipment.Index == name || strings.ReplaceAll(strings.ToLower(equipment.Name), " ", "-") == name {
+			if equipment.Container == "" {
+				return equipment
+			}
+			if stowed == nil {
+				stowed = equipment
+			}
+		}
+	}
+	return stowed
+}
+
+// container returns the carried item that can hold equipment with an index, name or path, if any
+func (inv *Inventory) container(name string) *BaseEquipment {
+	var containers []*BaseEquipment
+	for i := range inv.Items {
+		item := &inv.Items[i]
+		if _, ok := containerCapacity[item.Index]; ok || item.IsPack() {
+			containers = append(containers, &item.BaseEquipment)
+		}
+	}
+	return lookup(name, containers)
+}
+
+// isPack reports whether the carried item at a path is an equipment pack
+func (inv *Inventory) isPack(path string) bool {
+	if path == "" {
+		return false
+	}
+	for i := range inv.Items {
+		if inv.Items[i].path() == path {
+			return inv.Items[i].IsPack()
+		}
+	}
+	return false
+}
+
+// contents returns the equipment stowed directly in the container at a path
+func (inv *Inventory) contents(path string) []*BaseEquipment {
+	var stowed []*BaseEquipment
+	for _, equipment := range inv.equipment() {
+		if equipment.Container == path {
+			stowed = append(stowed, equipment)
+		}
+	}
+	return stowed
+}
+
+// heldWeight returns the weight of equipment and everything stowed in it, including nested containers
+func (inv *Inventory) heldWeight(b *BaseEquipment) float64 {
+	total := b.TotalWeight()
+	for _, stowed := range inv.contents(b.path()) {
+		total += inv.heldWeight(stowed)
+	}
+	return total
+}
+
+// ContainerWeight returns the weight of a container and everything stowed in it, including nested containers
+func (inv *Inventory) ContainerWeight(name string) float64 {
+	if equipment := inv.find(name); equipment != nil {
+		return inv.heldWeight(equipment)
+	}
+	return 0
+}
+
+// Capacity returns how many pounds a container holds, 0 when it isn't limited (packs)
+func (inv *Inventory) Capacity(index string) float64 {
+	return containerCapacity[index]
+}
+
+// move puts equipment into the container at a path, or takes it out when the path is empty.
+// It stacks onto the same equipment already there, along with anything stowed in it.
+func (inv *Inventory) move(b *BaseEquipment, container string) {
+	from := BaseEquipment{Index: b.Index, Container: b.Container}
+	to := BaseEquipment{Index: b.Index, Container: container}
+	if from.path() == to.path() {
+		return
+	}
+
+	existing := inv.stack(to)
+	if existing == nil {
+		b.Container = container
+	}
+	// Moving or stacking can shift the slices, so each step looks the equipment up again
+	inv.release(from.path(), to.path())
+	if existing != nil {
+		moved := inv.stack(from)
+		inv.stack(to).Count = inv.stack(to).Carried() + moved.Carried()
+		inv.drop(moved)
+	}
+}
+
+// release moves everything stowed in the container at one path into the container at another
+func (inv *Inventory) release(from, to string) {
+	for contents := inv.contents(from); len(contents) > 0; contents = inv.contents(from) {
+		inv.move(contents[0], to)
+	}
+}
+
+// drop deletes a piece of carried equipment from whichever section holds it
+func (inv *Inventory) drop(b *BaseEquipment) {
+	for i := range inv.Armor {
+		if &inv.Armor[i].BaseEquipment == b {
+			inv.Armor = slices.Delete(inv.Armor, i, i+1)
+			return
+		}
+	}
+	for i := range inv.Weapons {
+		if &inv.Weapons[i].BaseEquipment == b {
+			inv.Weapons = slices.Delete(inv.Weapons, i, i+1)
+			return
+		}
+	}
+	for i := range inv.Items {
+		if &inv.Items[i].BaseEquipment == b {
+			inv.Items = slices.Delete(inv.Items, i, i+1)
+			return
+		}
+	}
+}
+
+// Stow moves carried equipment into a container, or takes it out when container is empty.
+// Either can be named by path, e.g. "explorers-pack/backpack", to tell apart equipment with the same index.
+func (inv *Inventory) Stow(name, container string) (string, error) {
+	equipment := inv.find(name)
+	if equipment == nil {
+		return "", fmt.Errorf("no %q in the inventory", name)
+	}
+
+	if container == "" {
+		if equipment.Container == "" {
+			return "", fmt.Errorf("%s isn't stowed", equipment.Name)
+		}
+		summary := fmt.Sprintf("took out %s", equipment.DisplayName())
+		inv.move(equipment, "")
+		return summary, nil
+	}
+
+	target := inv.container(container)
+	if target == nil {
+		return "", fmt.Errorf("no container %q in the inventory", container)
+	}
+	// A container can't be stowed inside itself or anything it holds
+	if target.path() == equipment.path() || strings.HasPrefix(target.path(), equipment.path()+"/") {
+		return "", fmt.Errorf("can't stow %s inside itself", equipment.Name)
+	}
+	if equipment.Container == target.path() {
+		return "", fmt.Errorf("%s is already in the %s", equipment.Name, target.Name)
+	}
+	if capacity := inv.Capacity(target.Index); capacity > 0 {
+		if inv.heldWeight(target)-target.TotalWeight()+inv.heldWeight(equipment) > capacity {
+			return "", fmt.Errorf("%s doesn't fit in the %s (holds %s)", equipment.Name, target.Name, FormatWeight(capacity))
+		}
+	}
+
+	summary := fmt.Sprintf("stowed %s in the %s", equipment.DisplayName(), target.Name)
+	inv.move(equipment, target.path())
+	return summary, nil
+}
+
+// printStowed prints equipment and, for containers, what's stowed in it with the rolled up weight
+func (inv *Inventory) printStowed(equipment *BaseEquipment, indent string) {
+	contents := inv.contents(equipment.path())
+	if len(contents) == 0 {
+		fmt.Printf("%s- %s\n", indent, equipment.DisplayName())
+		return
+	}
+
+	weight := FormatWeight(inv.heldWeight(equipment))
+	if capacity := inv.Capacity(equipment.Index); capacity > 0 {
+		weight += " / " + FormatWeight(capacity)
+	}
+	fmt.Printf("%s- %s (%s):\n", indent, equipment.DisplayName(), weight)
+	for _, stowed := range contents {
+		inv.printStowed(stowed, indent+strings.Repeat(" ", 4))
+	}
+}
@@ -0,0 +1,138 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gear(index, name string, weight float64) inventory.Item {
+	return inventory.Item{BaseEquipment: inventory.BaseEquipment{Index: index, Name: name, Weight: weight}}
+}
+
+func containerInventory() *inventory.Inventory {
+	pack := gear("explorers-pack", "Explorer's Pack", 0)
+	pack.GearCategory = &reference.Reference{Index: "equipment-packs"}
+	backpack := gear("backpack", "Backpack", 5)
+	backpack.Container = "explorers-pack"
+
+	return &inventory.Inventory{
+		Weapons: []inventory.Weapon{{BaseEquipment: inventory.BaseEquipment{Index: "dagger", Name: "Dagger", Weight: 1}}},
+		Items:   []inventory.Item{pack, backpack, gear("hempen-rope-50-feet", "Rope, hempen (50 feet)", 10), gear("anvil", "Anvil", 35)},
+	}
+}
+
+func TestStow(t *testing.T) {
+	inv := containerInventory()
+
+	summary, err := inv.Stow("Rope, hempen (50 feet)", "backpack")
+	require.NoError(t, err)
+	assert.Equal(t, "stowed Rope, hempen (50 feet) in the Backpack", summary)
+
+	_, err = inv.Stow("dagger", "backpack")
+	require.NoError(t, err)
+	assert.Equal(t, "explorers-pack/backpack", inv.Weapons[0].Container)
+
+	assert.Equal(t, 16.0, inv.ContainerWeight("backpack"))
+	assert.Equal(t, 16.0, inv.ContainerWeight("explorers-pack"))
+	assert.Equal(t, 51.0, inv.Weight())
+
+	summary, err = inv.Stow("dagger", "")
+	require.NoError(t, err)
+	assert.Equal(t, "took out Dagger", summary)
+	assert.Equal(t, 15.0, inv.ContainerWeight("backpack"))
+}
+
+func TestStowErrors(t *testing.T) {
+	inv := containerInventory()
+
+	testCases := []struct {
+		name      string
+		item      string
+		container string
+		err       string
+	}{
+		{"unknown item", "lute", "backpack", `no "lute" in the inventory`},
+		{"not a container", "dagger", "anvil", `no container "anvil" in the inventory`},
+		{"over capacity", "anvil", "backpack", "Anvil doesn't fit in the Backpack (holds 30 lb)"},
+		{"inside itself", "explorers-pack", "backpack", "can't stow Explorer's Pack inside itself"},
+		{"not stowed", "anvil", "", "Anvil isn't stowed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := inv.Stow(tc.item, tc.container)
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		})
+	}
+}
+
+func TestContainersWithTheSameIndex(t *testing.T) {
+	inv := containerInventory()
+	torch := gear("torch", "Torch", 1)
+	torch.Count = 5
+	packed := gear("torch", "Torch", 1)
+	packed.Count, packed.Container = 10, "explorers-pack"
+	inv.Items = append([]inventory.Item{torch}, inv.Items...)
+	inv.Items = append(inv.Items, packed, gear("backpack", "Backpack", 5))
+
+	assert.Equal(t, 15.0, inv.ContainerWeight("explorers-pack"))
+	assert.Equal(t, 5.0, inv.ContainerWeight("backpack"))
+
+	_, err := inv.Stow("hempen-rope-50-feet", "backpack")
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, inv.ContainerWeight("backpack"), "the loose backpack comes first")
+	assert.Equal(t, 15.0, inv.ContainerWeight("explorers-pack"))
+
+	summary, err := inv.Stow("explorers-pack/torch", "explorers-pack/backpack")
+	require.NoError(t, err)
+	assert.Equal(t, "stowed Torch x10 in the Backpack", summary)
+	assert.Equal(t, 15.0, inv.ContainerWeight("explorers-pack/backpack"))
+	assert.Equal(t, 15.0, inv.ContainerWeight("explorers-pack"))
+	assert.Equal(t, 5.0, inv.ContainerWeight("torch"))
+
+	_, err = inv.Stow("explorers-pack/backpack", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, inv.ContainerWeight("explorers-pack"))
+	assert.Equal(t, 30.0, inv.ContainerWeight("backpack"), "stacks onto the loose backpack with what it holds")
+
+	var torches []int
+	for _, item := range inv.Items {
+		if item.Index == "torch" {
+			torches = append(torches, item.Carried())
+			if item.Container != "" {
+				assert.Equal(t, "backpack", item.Container)
+			}
+		}
+	}
+	assert.Equal(t, []int{5, 10}, torches)
+}
+
+func TestAddStacksPerContainer(t *testing.T) {
+	inv := containerInventory()
+	packed := gear("hempen-rope-50-feet", "Rope, hempen (50 feet)", 10)
+	packed.Container = "backpack"
+
+	inv.Add(inventory.Inventory{Items: []inventory.Item{packed, packed}})
+
+	require.Len(t, inv.Items, 5)
+	assert.Equal(t, 2, inv.Items[4].Count)
+	assert.Equal(t, 0, inv.Items[2].Count)
+}
+
+func TestSellContainerTakesContentsOut(t *testing.T) {
+	inv := containerInventory()
+	_, err := inv.Stow("anvil", "explorers-pack")
+	require.NoError(t, err)
+
+	_, err = inv.Sell("explorers-pack", 1)
+	require.NoError(t, err)
+
+	for _, item := range inv.Items {
+		assert.Empty(t, item.Container, item.Name)
+	}
+}
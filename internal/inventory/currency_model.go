@@ -0,0 +1,166 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// coinValues is the worth of each coin in copper pieces
+var coinValues = map[string]int{"cp": 1, "sp": 10, "ep": 50, "gp": 100, "pp": 1000}
+
+// Wallet holds a character's coins
+type Wallet struct {
+	CP int `json:"cp"`
+	SP int `json:"sp"`
+	EP int `json:"ep"`
+	GP int `json:"gp"`
+	PP int `json:"pp"`
+}
+
+// coin is a pointer to one of a wallet's coin counts and the coin's worth in copper
+type coin struct {
+	count *int
+	value int
+}
+
+// coins returns the wallet's coins from the least to the most valuable
+func (w *Wallet) coins() []coin {
+	return []coin{{&w.CP, 1}, {&w.SP, 10}, {&w.EP, 50}, {&w.GP, 100}, {&w.PP, 1000}}
+}
+
+// Copper returns the wallet's total worth in copper pieces
+func (w Wallet) Copper() int {
+	total := 0
+	for _, coin := range w.coins() {
+		total += *coin.count * coin.value
+	}
+	return total
+}
+
+// Add puts copper pieces into the wallet as gold, silver and copper coins
+func (w *Wallet) Add(copper int) {
+	w.GP += copper / 100
+	w.SP += copper % 100 / 10
+	w.CP += copper % 10
+}
+
+// Spend pays an amount in copper pieces, using the smallest coins first and breaking a larger coin for change
+func (w *Wallet) Spend(copper int) error {
+	if copper > w.Copper() {
+		return fmt.Errorf("costs %s but only %s is available", FormatCopper(copper), w)
+	}
+
+	remaining := copper
+	for _, coin := range w.coins() {
+		used := min(*coin.count, remaining/coin.value)
+		*coin.count -= used
+		remaining -= used * coin.value
+	}
+
+	// Every coin left is worth more than what's still owed, so one of the smallest pays it off
+	if remaining > 0 {
+		for _, coin := range w.coins() {
+			if *coin.count > 0 {
+				*coin.count--
+				w.Add(coin.value - remaining)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// String lists the coins from the most valuable, e.g. "12 gp, 5 sp"
+func (w Wallet) String() string {
+	var parts []string
+	for _, coin := range []struct {
+		count int
+		unit  string
+	}{{w.PP, "pp"}, {w.GP, "gp"}, {w.EP, "ep"}, {w.SP, "sp"}, {w.CP, "cp"}} {
+		if coin.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", coin.count, coin.unit))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 gp"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatCopper renders an amount of copper pieces in the largest exact unit of gp, sp or cp
+func FormatCopper(copper int) string {
+	switch {
+	case copper%100 == 0:
+		return fmt.Sprintf("%d gp", copper/100)
+	case copper%10 == 0:
+		return fmt.Sprintf("%d sp", copper/10)
+	default:
+		return fmt.Sprintf("%d cp", copper)
+	}
+}
+
+// Copper returns the cost in copper pieces
+func (c Cost) Copper() int {
+	return c.Quantity * coinValues[strings.ToLower(c.Unit)]
+}
+
+// TotalCost returns the cost in copper pieces of everything carried, scaling bundles and rounding up
+func (b *BaseEquipment) TotalCost() int {
+	bundle := max(1, b.Quantity)
+	return (b.Cost.Copper()*b.Carried() + bundle - 1) / bundle
+}
+
+// GearCost returns the total cost in copper pieces of all armor, weapons and items.
+// Equipment that came in a pack is paid for by the pack's cost.
+func (inv *Inventory) GearCost() int {
+	total := 0
+	for _, equipment := range inv.equipment() {
+		if !inv.isPack(equipment.Container) {
+			total += equipment.TotalCost()
+		}
+	}
+	return total
+}
+
+// PayForGear spends the cost of all gear from the wallet, failing if it doesn't fit the budget
+func (inv *Inventory) PayForGear() error {
+	if err := inv.Wallet.Spend(inv.GearCost()); err != nil {
+		return fmt.Errorf("starting gear %w", err)
+	}
+	return nil
+}
+
+// Buy pays for purchased equipment and adds it to the inventory, returning the price in copper pieces
+func (inv *Inventory) Buy(goods Inventory) (int, error) {
+	price := goods.GearCost()
+	if err := inv.Wallet.Spend(price); err != nil {
+		return 0, err
+	}
+
+	inv.Add(goods)
+	return price, nil
+}
+
+// Sell removes count of a carried piece of equipment and adds half its cost to the wallet,
+// returning the amount received in copper pieces
+func (inv *Inventory) Sell(name string, count int) (int, error) {
+	equipment := inv.find(name)
+	if equipment == nil {
+		return 0, fmt.Errorf("no %q in the inventory", name)
+	}
+	if count > equipment.Carried() {
+		return 0, fmt.Errorf("only carrying %d %s", equipment.Carried(), equipment.Name)
+	}
+
+	sold := *equipment
+	sold.Count = count
+	received := sold.TotalCost() / 2
+	inv.Wallet.Add(received)
+
+	if left := equipment.Carried() - count; left > 0 {
+		equipment.Count = left
+	} else {
+		inv.remove(equipment)
+	}
+	return received, nil
+}
@@ -0,0 +1,119 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletCopper(t *testing.T) {
+	w := inventory.Wallet{CP: 3, SP: 2, EP: 1, GP: 4, PP: 1}
+	assert.Equal(t, 1473, w.Copper())
+	assert.Equal(t, "1 pp, 4 gp, 1 ep, 2 sp, 3 cp", w.String())
+	assert.Equal(t, "0 gp", inventory.Wallet{}.String())
+}
+
+func TestWalletSpend(t *testing.T) {
+	testCases := []struct {
+		name   string
+		wallet inventory.Wallet
+		cost   int
+		want   inventory.Wallet
+	}{
+		{"exact coins", inventory.Wallet{GP: 5}, 200, inventory.Wallet{GP: 3}},
+		{"smallest coins first", inventory.Wallet{SP: 10, GP: 1}, 100, inventory.Wallet{GP: 1}},
+		{"change from gold", inventory.Wallet{GP: 1}, 25, inventory.Wallet{SP: 7, CP: 5}},
+		{"change from platinum", inventory.Wallet{CP: 5, PP: 1}, 150, inventory.Wallet{GP: 8, SP: 5, CP: 5}},
+		{"electrum", inventory.Wallet{EP: 3}, 120, inventory.Wallet{SP: 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := tc.wallet
+			require.NoError(t, w.Spend(tc.cost))
+			assert.Equal(t, tc.want, w)
+			assert.Equal(t, tc.wallet.Copper()-tc.cost, w.Copper())
+		})
+	}
+}
+
+func TestWalletSpendTooMuch(t *testing.T) {
+	w := inventory.Wallet{GP: 1}
+	err := w.Spend(150)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "costs 15 sp but only 1 gp is available")
+	assert.Equal(t, inventory.Wallet{GP: 1}, w)
+}
+
+func TestFormatCopper(t *testing.T) {
+	assert.Equal(t, "25 gp", inventory.FormatCopper(2500))
+	assert.Equal(t, "5 sp", inventory.FormatCopper(50))
+	assert.Equal(t, "7 cp", inventory.FormatCopper(7))
+}
+
+func TestTotalCost(t *testing.T) {
+	arrows := inventory.BaseEquipment{Cost: inventory.Cost{Quantity: 1, Unit: "gp"}, Quantity: 20, Count: 30}
+	assert.Equal(t, 150, arrows.TotalCost())
+
+	dagger := inventory.BaseEquipment{Cost: inventory.Cost{Quantity: 2, Unit: "gp"}, Count: 2}
+	assert.Equal(t, 400, dagger.TotalCost())
+
+	inv := inventory.Inventory{
+		Weapons: []inventory.Weapon{{BaseEquipment: dagger}},
+		Items:   []inventory.Item{{BaseEquipment: arrows}},
+	}
+	assert.Equal(t, 550, inv.GearCost())
+}
+
+func TestPayForGear(t *testing.T) {
+	inv := inventory.Inventory{
+		Armor:  []inventory.Armor{{BaseEquipment: inventory.BaseEquipment{Cost: inventory.Cost{Quantity: 10, Unit: "gp"}}}},
+		Wallet: inventory.Wallet{GP: 15},
+	}
+	require.NoError(t, inv.PayForGear())
+	assert.Equal(t, inventory.Wallet{GP: 5}, inv.Wallet)
+
+	err := inv.PayForGear()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "starting gear costs 10 gp")
+}
+
+func TestBuyAndSell(t *testing.T) {
+	dagger := inventory.BaseEquipment{Index: "dagger", Name: "Dagger", Cost: inventory.Cost{Quantity: 2, Unit: "gp"}}
+	inv := inventory.Inventory{
+		Weapons: []inventory.Weapon{{BaseEquipment: dagger}},
+		Wallet:  inventory.Wallet{GP: 10},
+	}
+
+	bought := dagger
+	bought.Count = 2
+	price, err := inv.Buy(inventory.Inventory{Weapons: []inventory.Weapon{{BaseEquipment: bought}}})
+	require.NoError(t, err)
+	assert.Equal(t, 400, price)
+	require.Len(t, inv.Weapons, 1)
+	assert.Equal(t, 3, inv.Weapons[0].Count)
+	assert.Equal(t, inventory.Wallet{GP: 6}, inv.Wallet)
+
+	received, err := inv.Sell("dagger", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 100, received)
+	assert.Equal(t, 2, inv.Weapons[0].Count)
+
+	_, err = inv.Sell("Dagger", 3)
+	assert.Error(t, err)
+
+	_, err = inv.Sell("Dagger", 2)
+	require.NoError(t, err)
+	assert.Empty(t, inv.Weapons)
+	assert.Equal(t, inventory.Wallet{GP: 9}, inv.Wallet)
+
+	_, err = inv.Sell("longsword", 1)
+	assert.Error(t, err)
+
+	_, err = inv.Buy(inventory.Inventory{Armor: []inventory.Armor{{BaseEquipment: inventory.BaseEquipment{Cost: inventory.Cost{Quantity: 1500, Unit: "gp"}}}}})
+	assert.Error(t, err)
+	assert.Empty(t, inv.Armor)
+}
@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Encumbrance levels under the variant encumbrance rule
+const (
+	Unencumbered      = "unencumbered"
+	Encumbered        = "encumbered"         // Speed -10
+	HeavilyEncumbered = "heavily encumbered" // Speed -20, disadvantage on Str, Dex & Con rolls and attacks
+	OverCapacity      = "over capacity"      // Can only push, drag or lift, speed drops to 5
+)
+
+// Encumbrance is a character's carried weight against what they can carry
+type Encumbrance struct {
+	Carried      float64 `json:"carried"`
+	Capacity     float64 `json:"capacity"`   // 15 x Strength, scaled by size
+	Encumbered   float64 `json:"encumbered"` // Above 5 x Strength the character is encumbered
+	Heavily      float64 `json:"heavily"`    // Above 10 x Strength the character is heavily encumbered
+	Level        string  `json:"level"`
+	SpeedPenalty int     `json:"speed_penalty"` // Feet of speed lost, over capacity speed is 5 instead
+}
+
+// sizeMultiplier scales carrying capacity by creature size. Small and Medium creatures carry the same.
+func sizeMultiplier(size string) float64 {
+	switch strings.ToLower(size) {
+	case "tiny":
+		return 0.5
+	case "large":
+		return 2
+	case "huge":
+		return 4
+	case "gargantuan":
+		return 8
+	default:
+		return 1
+	}
+}
+
+// BuildEncumbrance computes carrying capacity and the variant encumbrance level for carried weight
+func BuildEncumbrance(strength int, size string, carried float64) Encumbrance {
+	multiplier := sizeMultiplier(size)
+	e := Encumbrance{
+		Carried:    carried,
+		Capacity:   15 * float64(strength) * multiplier,
+		Encumbered: 5 * float64(strength) * multiplier,
+		Heavily:    10 * float64(strength) * multiplier,
+		Level:      Unencumbered,
+	}
+
+	switch {
+	case carried > e.Capacity:
+		e.Level = OverCapacity
+	case carried > e.Heavily:
+		e.Level = HeavilyEncumbered
+		e.SpeedPenalty = 20
+	case carried > e.Encumbered:
+		e.Level = Encumbered
+		e.SpeedPenalty = 10
+	}
+	return e
+}
+
+// Speed applies the encumbrance penalty to a walking speed
+func (e Encumbrance) Speed(base int) int {
+	if e.Level == OverCapacity {
+		return min(base, 5)
+	}
+	return max(0, base-e.SpeedPenalty)
+}
+
+func (e Encumbrance) Print() {
+	fmt.Printf("Carrying: %s / %s", FormatWeight(e.Carried), FormatWeight(e.Capacity))
+	if e.Level != Unencumbered {
+		fmt.Printf(" (%s", e.Level)
+		if e.SpeedPenalty > 0 {
+			fmt.Printf(", speed -%d", e.SpeedPenalty)
+		}
+		fmt.Print(")")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,44 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildEncumbrance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strength int
+		size     string
+		carried  float64
+		level    string
+		speed    int
+	}{
+		{"light load", 10, "Medium", 50, inventory.Unencumbered, 30},
+		{"encumbered", 10, "Medium", 51, inventory.Encumbered, 20},
+		{"heavily encumbered", 10, "Medium", 101, inventory.HeavilyEncumbered, 10},
+		{"over capacity", 10, "Medium", 151, inventory.OverCapacity, 5},
+		{"small carries like medium", 10, "Small", 60, inventory.Encumbered, 20},
+		{"large doubles thresholds", 10, "Large", 150, inventory.Encumbered, 20},
+		{"tiny halves thresholds", 10, "Tiny", 30, inventory.Encumbered, 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := inventory.BuildEncumbrance(tc.strength, tc.size, tc.carried)
+			assert.Equal(t, tc.level, e.Level)
+			assert.Equal(t, tc.speed, e.Speed(30))
+		})
+	}
+}
+
+func TestEncumbranceCapacity(t *testing.T) {
+	e := inventory.BuildEncumbrance(14, "Medium", 0)
+
+	assert.Equal(t, 210.0, e.Capacity)
+	assert.Equal(t, 70.0, e.Encumbered)
+	assert.Equal(t, 140.0, e.Heavily)
+	assert.Equal(t, 0, e.SpeedPenalty)
+}
@@ -1,9 +1,11 @@
 package inventory
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/kwford18/MKDIRagons/internal/core"
 	"github.com/kwford18/MKDIRagons/template"
-	"sync"
 )
 
 // FetchInventoryWithFetcher allows using a custom fetcher for testing
@@ -15,6 +17,15 @@ func FetchInventoryWithFetcher(fetcher core.Fetcher, base *template.Character, i
 		panic("FetchInventoryWithFetcher: nil Inventory provided")
 	}
 
+	inv.Wallet = Wallet(base.Inventory.Wallet)
+	for _, entry := range base.Inventory.Valuables {
+		valuable, err := ParseValuable(entry)
+		if err != nil {
+			return err
+		}
+		inv.Valuables = append(inv.Valuables, valuable)
+	}
+
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	errs := make(chan error, len(base.Inventory.Armor)+len(base.Inventory.Weapons)+len(base.Inventory.Items))
@@ -24,11 +35,15 @@ func FetchInventoryWithFetcher(fetcher core.Fetcher, base *template.Character, i
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
+			name, count := template.ParseQuantity(name)
 			var armor Armor
 			if err := fetcher.FetchJSON(&armor, name); err != nil {
 				errs <- err
 				return
 			}
+			if count > 1 {
+				armor.Count = count
+			}
 			mu.Lock()
 			inv.Armor = append(inv.Armor, armor)
 			mu.Unlock()
@@ -40,11 +55,15 @@ func FetchInventoryWithFetcher(fetcher core.Fetcher, base *template.Character, i
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
+			name, count := template.ParseQuantity(name)
 			var weapon Weapon
 			if err := fetcher.FetchJSON(&weapon, name); err != nil {
 				errs <- err
 				return
 			}
+			if count > 1 {
+				weapon.Count = count
+			}
 			mu.Lock()
 			inv.Weapons = append(inv.Weapons, weapon)
 			mu.Unlock()
@@ -56,11 +75,15 @@ func FetchInventoryWithFetcher(fetcher core.Fetcher, base *template.Character, i
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
+			name, count := template.ParseQuantity(name)
 			var item Item
 			if err := fetcher.FetchJSON(&item, name); err != nil {
 				errs <- err
 				return
 			}
+			if count > 1 {
+				item.Count = count
+			}
 			mu.Lock()
 			inv.Items = append(inv.Items, item)
 			mu.Unlock()
@@ -73,10 +96,76 @@ func FetchInventoryWithFetcher(fetcher core.Fetcher, base *template.Character, i
 	if err, ok := <-errs; ok {
 		return err
 	}
-	return nil
+	return ExpandPacksWithFetcher(fetcher, inv)
 }
 
 // FetchInventory uses the default fetcher for production
 func FetchInventory(base *template.Character, inv *Inventory) error {
 	return FetchInventoryWithFetcher(core.DefaultFetcher, base, inv)
 }
+
+// FetchEquipmentWithFetcher fetches one inventory entry such as "Arrow x20" into the section
+// of an inventory that matches its equipment category, using a custom fetcher (for testing)
+func FetchEquipmentWithFetcher(fetcher core.Fetcher, entry string) (Inventory, error) {
+	var goods Inventory
+	name, count := template.ParseQuantity(entry)
+	var item Item
+	if err := fetcher.FetchJSON(&item, name); err != nil {
+		return goods, err
+	}
+	if count > 1 {
+		item.Count = count
+	}
+
+	switch item.EquipmentCategory.Index {
+	case "armor":
+		armor := Armor{BaseEquipment: item.BaseEquipment}
+		if err := fetcher.FetchJSON(&armor, name); err != nil {
+			return goods, err
+		}
+		armor.Count = item.Count
+		goods.Armor = append(goods.Armor, armor)
+	case "weapon":
+		weapon := Weapon{BaseEquipment: item.BaseEquipment}
+		if err := fetcher.FetchJSON(&weapon, name); err != nil {
+			return goods, err
+		}
+		weapon.Count = item.Count
+		goods.Weapons = append(goods.Weapons, weapon)
+	default:
+		goods.Items = append(goods.Items, item)
+	}
+	return goods, ExpandPacksWithFetcher(fetcher, &goods)
+}
+
+// ExpandPacksWithFetcher fetches the contents of each equipment pack into the inventory, stowed in the pack.
+// The pack stays as the container grouping them, with its weight moved to its contents.
+func ExpandPacksWithFetcher(fetcher core.Fetcher, inv *Inventory) error {
+	var packs []Item
+	for _, item := range inv.Items {
+		if item.IsPack() && len(inv.contents(item.path())) == 0 {
+			packs = append(packs, item)
+		}
+	}
+
+	for _, pack := range packs {
+		for _, content := range pack.Contents {
+			entry := fmt.Sprintf("%s x%d", content.Item.Index, max(1, content.Quantity)*pack.Carried())
+			goods, err := FetchEquipmentWithFetcher(fetcher, entry)
+			if err != nil {
+				return err
+			}
+			for _, equipment := range goods.equipment() {
+				equipment.Container = pack.path()
+			}
+			inv.Add(goods)
+		}
+		inv.stack(pack.BaseEquipment).Weight = 0
+	}
+	return nil
+}
+
+// FetchEquipment fetches one inventory entry using the default fetcher
+func FetchEquipment(entry string) (Inventory, error) {
+	return FetchEquipmentWithFetcher(core.DefaultFetcher, entry)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/kwford18/MKDIRagons/template"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -811,3 +812,97 @@ func getItemIndexes(inv *inventory.Inventory) []string {
 	}
 	return indexes
 }
+
+func (suite *InventoryBuilderTestSuite) TestFetchInventoryWithFetcher_Quantities() {
+	quantityCharacter := &template.Character{
+		Name: "Test",
+		Inventory: template.Inventory{
+			Weapons: []string{"dagger x3"},
+			Items:   []string{"abacus"},
+		},
+	}
+
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Weapon"), "dagger").Return(nil)
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), "abacus").Return(nil)
+
+	err := inventory.FetchInventoryWithFetcher(suite.fixtureBasedFetcher, quantityCharacter, suite.inventory)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), suite.inventory.Weapons, 1)
+	assert.Equal(suite.T(), 3, suite.inventory.Weapons[0].Count)
+	assert.Equal(suite.T(), 3.0, suite.inventory.WeaponWeight())
+	assert.Equal(suite.T(), 0, suite.inventory.Items[0].Count)
+	assert.Equal(suite.T(), 2.0, suite.inventory.ItemWeight())
+}
+
+func (suite *InventoryBuilderTestSuite) TestFetchInventoryWithFetcher_Wallet() {
+	walletCharacter := &template.Character{
+		Name:      "Test",
+		Inventory: template.Inventory{Wallet: template.Wallet{GP: 15, SP: 3}},
+	}
+
+	err := inventory.FetchInventoryWithFetcher(suite.fixtureBasedFetcher, walletCharacter, suite.inventory)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), inventory.Wallet{GP: 15, SP: 3}, suite.inventory.Wallet)
+}
+
+func (suite *InventoryBuilderTestSuite) TestFetchInventoryWithFetcher_Valuables() {
+	base := &template.Character{
+		Name:      "Test",
+		Inventory: template.Inventory{Valuables: []string{"Diamond (300 gp)", "Pearl (100 gp) x2"}},
+	}
+
+	err := inventory.FetchInventoryWithFetcher(suite.fixtureBasedFetcher, base, suite.inventory)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []inventory.Valuable{{Name: "Diamond", GP: 300}, {Name: "Pearl", GP: 100, Count: 2}}, suite.inventory.Valuables)
+
+	base.Inventory.Valuables = []string{"Diamond"}
+	err = inventory.FetchInventoryWithFetcher(suite.fixtureBasedFetcher, base, &inventory.Inventory{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *InventoryBuilderTestSuite) TestFetchEquipmentWithFetcher() {
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), "dagger").Return(nil)
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Weapon"), "dagger").Return(nil)
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), "abacus").Return(nil)
+
+	goods, err := inventory.FetchEquipmentWithFetcher(suite.fixtureBasedFetcher, "dagger x2")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), goods.Weapons, 1)
+	assert.Empty(suite.T(), goods.Items)
+	assert.Equal(suite.T(), 2, goods.Weapons[0].Count)
+	assert.Equal(suite.T(), "1d4", goods.Weapons[0].Damage.DamageDice)
+
+	goods, err = inventory.FetchEquipmentWithFetcher(suite.fixtureBasedFetcher, "abacus")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), goods.Items, 1)
+	assert.Equal(suite.T(), 200, goods.GearCost())
+}
+
+func (suite *InventoryBuilderTestSuite) TestFetchInventoryWithFetcher_ExpandsPacks() {
+	packCharacter := &template.Character{
+		Name:      "Test",
+		Inventory: template.Inventory{Items: []string{"explorers-pack"}},
+	}
+	suite.fixtureBasedFetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), mock.Anything).Return(nil)
+
+	err := inventory.FetchInventoryWithFetcher(suite.fixtureBasedFetcher, packCharacter, suite.inventory)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), suite.inventory.Items, 4)
+	pack := suite.inventory.Items[0]
+	assert.Equal(suite.T(), "explorers-pack", pack.Index)
+	assert.Zero(suite.T(), pack.Weight)
+	for _, item := range suite.inventory.Items[1:] {
+		assert.Equal(suite.T(), "explorers-pack", item.Container)
+	}
+	assert.Equal(suite.T(), "Torch x10", suite.inventory.Items[2].DisplayName())
+	assert.Equal(suite.T(), 17.0, suite.inventory.ContainerWeight("explorers-pack"))
+	assert.Equal(suite.T(), 1000, suite.inventory.GearCost())
+
+	// Expanding again leaves the unpacked pack alone
+	require.NoError(suite.T(), inventory.ExpandPacksWithFetcher(suite.fixtureBasedFetcher, suite.inventory))
+	assert.Len(suite.T(), suite.inventory.Items, 4)
+}
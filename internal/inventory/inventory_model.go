@@ -2,6 +2,9 @@ package inventory
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/kwford18/MKDIRagons/internal/reference"
 )
@@ -14,16 +17,56 @@ type BaseEquipment struct {
 	Name              string                `json:"name"`
 	EquipmentCategory reference.Reference   `json:"equipment_category"`
 	Cost              Cost                  `json:"cost"`
-	Weight            int                   `json:"weight"`
+	Weight            float64               `json:"weight"`
+	Quantity          int                   `json:"quantity,omitempty"` // Bundle size the cost and weight are for, e.g. 20 arrows
+	Count             int                   `json:"count,omitempty"`    // How many are carried, 0 means one
 	URL               string                `json:"url"`
 	Properties        []reference.Reference `json:"properties"`
-	Contents          []reference.Reference `json:"contents"`
+	Contents          []Content             `json:"contents"`
+	Container         string                `json:"container,omitempty"` // Path of the container or pack it's stowed in, e.g. "explorers-pack/backpack"
+}
+
+// HasProperty reports whether the equipment has a property such as "finesse" or "thrown"
+func (b *BaseEquipment) HasProperty(index string) bool {
+	for _, property := range b.Properties {
+		if strings.EqualFold(property.Index, index) {
+			return true
+		}
+	}
+	return false
+}
+
+// Carried returns how many of the equipment the character carries
+func (b *BaseEquipment) Carried() int {
+	if b.Count < 1 {
+		return 1
+	}
+	return b.Count
+}
+
+// TotalWeight returns the weight of everything carried, scaling bundles such as 20 arrows per pound
+func (b *BaseEquipment) TotalWeight() float64 {
+	return b.Weight * float64(b.Carried()) / float64(max(1, b.Quantity))
+}
+
+// DisplayName returns the name with the carried count, e.g. "Arrow x20"
+func (b *BaseEquipment) DisplayName() string {
+	if b.Carried() > 1 {
+		return fmt.Sprintf("%s x%d", b.Name, b.Carried())
+	}
+	return b.Name
+}
+
+// FormatWeight renders a weight in pounds without trailing zeros, e.g. "2.5 lb"
+func FormatWeight(weight float64) string {
+	return strconv.FormatFloat(math.Round(weight*100)/100, 'f', -1, 64) + " lb"
 }
 
 // Item struct for items like abacus, amulet, alchemist fire
 type Item struct {
 	BaseEquipment
 	GearCategory    *reference.Reference `json:"gear_category,omitempty"`
+	ToolCategory    string               `json:"tool_category,omitempty"` // e.g. "Musical Instrument"
 	VehicleCategory string               `json:"vehicle_category,omitempty"`
 }
 
@@ -68,10 +111,130 @@ type WeaponRange struct {
 	Long   int `json:"long"`
 }
 
+// EquipmentCategory is an SRD group of equipment such as martial weapons
+type EquipmentCategory struct {
+	Index     string                `json:"index"`
+	Name      string                `json:"name"`
+	Equipment []reference.Reference `json:"equipment"`
+	URL       string                `json:"url"`
+}
+
+func (c *EquipmentCategory) GetEndpoint() string {
+	return "equipment-categories/"
+}
+
+// Find returns the category's equipment with a name or index, ignoring case
+func (c *EquipmentCategory) Find(name string) (reference.Reference, bool) {
+	for _, equipment := range c.Equipment {
+		if strings.EqualFold(equipment.Name, name) || strings.EqualFold(equipment.Index, strings.ReplaceAll(strings.TrimSpace(name), " ", "-")) {
+			return equipment, true
+		}
+	}
+	return reference.Reference{}, false
+}
+
 type Inventory struct {
-	Items   []Item
-	Armor   []Armor
-	Weapons []Weapon
+	Items      []Item
+	Armor      []Armor
+	Weapons    []Weapon
+	MagicItems []MagicItem `json:",omitempty"`
+	Valuables  []Valuable  `json:",omitempty"`
+	Wallet     Wallet
+}
+
+// ArmorWeight returns the weight of all armor
+func (inv *Inventory) ArmorWeight() float64 {
+	total := 0.0
+	for i := range inv.Armor {
+		total += inv.Armor[i].TotalWeight()
+	}
+	return total
+}
+
+// WeaponWeight returns the weight of all weapons
+func (inv *Inventory) WeaponWeight() float64 {
+	total := 0.0
+	for i := range inv.Weapons {
+		total += inv.Weapons[i].TotalWeight()
+	}
+	return total
+}
+
+// ItemWeight returns the weight of all other items
+func (inv *Inventory) ItemWeight() float64 {
+	total := 0.0
+	for i := range inv.Items {
+		total += inv.Items[i].TotalWeight()
+	}
+	return total
+}
+
+// Weight returns the total carried weight
+func (inv *Inventory) Weight() float64 {
+	return inv.ArmorWeight() + inv.WeaponWeight() + inv.ItemWeight()
+}
+
+// equipment returns the base equipment of every armor, weapon and item
+func (inv *Inventory) equipment() []*BaseEquipment {
+	var all []*BaseEquipment
+	for i := range inv.Armor {
+		all = append(all, &inv.Armor[i].BaseEquipment)
+	}
+	for i := range inv.Weapons {
+		all = append(all, &inv.Weapons[i].BaseEquipment)
+	}
+	for i := range inv.Items {
+		all = append(all, &inv.Items[i].BaseEquipment)
+	}
+	return all
+}
+
+// Add puts equipment into the inventory, stacking it onto the same equipment already in the same container
+func (inv *Inventory) Add(goods Inventory) {
+	for _, armor := range goods.Armor {
+		if existing := inv.stack(armor.BaseEquipment); existing != nil {
+			existing.Count = existing.Carried() + armor.Carried()
+		} else {
+			inv.Armor = append(inv.Armor, armor)
+		}
+	}
+	for _, weapon := range goods.Weapons {
+		if existing := inv.stack(weapon.BaseEquipment); existing != nil {
+			existing.Count = existing.Carried() + weapon.Carried()
+		} else {
+			inv.Weapons = append(inv.Weapons, weapon)
+		}
+	}
+	for _, item := range goods.Items {
+		if existing := inv.stack(item.BaseEquipment); existing != nil {
+			existing.Count = existing.Carried() + item.Carried()
+		} else {
+			inv.Items = append(inv.Items, item)
+		}
+	}
+}
+
+// stack returns the carried equipment new equipment stacks onto: the same equipment in the same container
+func (inv *Inventory) stack(b BaseEquipment) *BaseEquipment {
+	for _, equipment := range inv.equipment() {
+		if equipment.Index == b.Index && equipment.Container == b.Container {
+			return equipment
+		}
+	}
+	return nil
+}
+
+// find returns the carried equipment with an index, name or path, ignoring case and dashes
+func (inv *Inventory) find(name string) *BaseEquipment {
+	return lookup(name, inv.equipment())
+}
+
+// remove deletes carried equipment from whichever section holds it.
+// Anything stowed in it is taken out.
+func (inv *Inventory) remove(b *BaseEquipment) {
+	key := BaseEquipment{Index: b.Index, Container: b.Container}
+	inv.release(key.path(), "")
+	inv.drop(inv.stack(key))
 }
 
 // GetEndpoint Fetchable Method
@@ -81,23 +244,51 @@ func (inv *Inventory) GetEndpoint() string {
 
 // Print Fetchable method
 func (inv *Inventory) Print() {
-	fmt.Printf("Inventory contains: %d armor, %d weapons, %d items\n",
-		len(inv.Armor), len(inv.Weapons), len(inv.Items))
+	fmt.Printf("Inventory contains: %d armor, %d weapons, %d items (%s)\n",
+		len(inv.Armor), len(inv.Weapons), len(inv.Items), FormatWeight(inv.Weight()))
 
-	fmt.Printf("    - Armor (1st equipped): \n")
+	fmt.Printf("    - Armor (1st equipped, %s): \n", FormatWeight(inv.ArmorWeight()))
 	for _, armor := range inv.Armor {
-		fmt.Printf("	- %s\n", armor.Name)
+		if armor.Container == "" {
+			fmt.Printf("	- %s\n", armor.DisplayName())
+		}
 	}
 
-	fmt.Printf("    - Weapons: \n")
-	for _, weapons := range inv.Weapons {
-		fmt.Printf("	- %s\n", weapons.Name)
+	fmt.Printf("    - Weapons (%s): \n", FormatWeight(inv.WeaponWeight()))
+	for i := range inv.Weapons {
+		if inv.Weapons[i].Container == "" {
+			fmt.Printf("	- %s\n", inv.weaponLabel(&inv.Weapons[i]))
+		}
 	}
 
-	fmt.Printf("    - Items: \n")
+	fmt.Printf("    - Items (%s): \n", FormatWeight(inv.ItemWeight()))
 	for _, items := range inv.Items {
-		fmt.Printf("	- %s\n", items.Name)
+		if items.Container == "" {
+			inv.printStowed(&items.BaseEquipment, "	")
+		}
+	}
+
+	if len(inv.MagicItems) > 0 {
+		fmt.Printf("    - Magic Items (attuned %d/%d): \n", inv.Attuned(), MaxAttuned)
+		for _, item := range inv.MagicItems {
+			attunement := ""
+			if item.Attuned {
+				attunement = ", attuned"
+			} else if item.RequiresAttunement() {
+				attunement = ", not attuned"
+			}
+			fmt.Printf("	- %s (%s%s)\n", item.Name, strings.ToLower(item.Rarity.Name), attunement)
+		}
 	}
+
+	if len(inv.Valuables) > 0 {
+		fmt.Println("    - Valuables: ")
+		for _, valuable := range inv.Valuables {
+			fmt.Printf("	- %s\n", valuable)
+		}
+	}
+
+	fmt.Printf("    - Wallet: %s\n", inv.Wallet)
 }
 
 func (i *Item) GetEndpoint() string {
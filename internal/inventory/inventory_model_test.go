@@ -325,13 +325,14 @@ func (suite *InventoryTestSuite) TestBaseEquipmentProperties() {
 func (suite *InventoryTestSuite) TestBaseEquipmentContents() {
 	pack := inventory.Item{
 		BaseEquipment: inventory.BaseEquipment{
-			Contents: []reference.Reference{
-				{Index: "rope", Name: "Rope"},
-				{Index: "torch", Name: "Torch"},
+			Contents: []inventory.Content{
+				{Item: reference.Reference{Index: "hempen-rope-50-feet", Name: "Rope, hempen (50 feet)"}, Quantity: 1},
+				{Item: reference.Reference{Index: "torch", Name: "Torch"}, Quantity: 10},
 			},
 		},
 	}
 	assert.Len(suite.T(), pack.Contents, 2)
+	assert.Equal(suite.T(), 10, pack.Contents[1].Quantity)
 }
 
 // Test edge cases
@@ -378,3 +379,50 @@ func (suite *InventoryTestSuite) TestArmorStrengthMinimum() {
 	}
 	assert.Equal(suite.T(), 15, plateArmor.StrMinimum)
 }
+
+func (suite *InventoryTestSuite) TestHasProperty() {
+	dagger := inventory.Weapon{
+		BaseEquipment: inventory.BaseEquipment{
+			Name: "Dagger",
+			Properties: []reference.Reference{
+				{Index: "finesse", Name: "Finesse"},
+				{Index: "thrown", Name: "Thrown"},
+			},
+		},
+	}
+
+	assert.True(suite.T(), dagger.HasProperty("finesse"))
+	assert.True(suite.T(), dagger.HasProperty("Thrown"))
+	assert.False(suite.T(), dagger.HasProperty("heavy"))
+}
+
+func (suite *InventoryTestSuite) TestTotalWeight() {
+	arrows := inventory.Item{BaseEquipment: inventory.BaseEquipment{Name: "Arrow", Weight: 1, Quantity: 20, Count: 40}}
+	dagger := inventory.Weapon{BaseEquipment: inventory.BaseEquipment{Name: "Dagger", Weight: 1, Count: 3}}
+	rope := inventory.Item{BaseEquipment: inventory.BaseEquipment{Name: "Rope", Weight: 10}}
+
+	assert.Equal(suite.T(), 2.0, arrows.TotalWeight())
+	assert.Equal(suite.T(), 3.0, dagger.TotalWeight())
+	assert.Equal(suite.T(), 10.0, rope.TotalWeight())
+	assert.Equal(suite.T(), "Arrow x40", arrows.DisplayName())
+	assert.Equal(suite.T(), "Rope", rope.DisplayName())
+}
+
+func (suite *InventoryTestSuite) TestInventoryWeight() {
+	inv := inventory.Inventory{
+		Armor:   []inventory.Armor{{BaseEquipment: inventory.BaseEquipment{Weight: 8}}},
+		Weapons: []inventory.Weapon{{BaseEquipment: inventory.BaseEquipment{Weight: 3}}, {BaseEquipment: inventory.BaseEquipment{Weight: 1, Count: 2}}},
+		Items:   []inventory.Item{{BaseEquipment: inventory.BaseEquipment{Weight: 1.5, Quantity: 20, Count: 10}}},
+	}
+
+	assert.Equal(suite.T(), 8.0, inv.ArmorWeight())
+	assert.Equal(suite.T(), 5.0, inv.WeaponWeight())
+	assert.Equal(suite.T(), 0.75, inv.ItemWeight())
+	assert.Equal(suite.T(), 13.75, inv.Weight())
+}
+
+func (suite *InventoryTestSuite) TestFormatWeight() {
+	assert.Equal(suite.T(), "10 lb", inventory.FormatWeight(10))
+	assert.Equal(suite.T(), "2.5 lb", inventory.FormatWeight(2.5))
+	assert.Equal(suite.T(), "0.33 lb", inventory.FormatWeight(1.0/3))
+}
@@ -0,0 +1,105 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/template"
+)
+
+// FetchMagicItemWithFetcher fetches one magic item entry using a custom fetcher (for testing).
+// "+1 Longsword" fetches the "weapon-1" variant and the longsword it enhances, which is returned as goods
+// so it can be added to the inventory when the character doesn't carry one already.
+func FetchMagicItemWithFetcher(fetcher core.Fetcher, entry string) (MagicItem, Inventory, error) {
+	var item MagicItem
+	var goods Inventory
+
+	bonus, name := ParseEnhancement(entry)
+	if bonus > 0 {
+		var err error
+		if goods, err = FetchEquipmentWithFetcher(fetcher, name); err != nil {
+			return item, goods, err
+		}
+
+		var kind string
+		switch {
+		case len(goods.Weapons) > 0:
+			kind, name = "weapon", goods.Weapons[0].Name
+		case len(goods.Armor) > 0:
+			kind, name = "armor", goods.Armor[0].Name
+		default:
+			return item, goods, fmt.Errorf("%s is neither a weapon nor armor", name)
+		}
+		if err := fetcher.FetchJSON(&item, fmt.Sprintf("%s-%d", kind, bonus)); err != nil {
+			return item, goods, err
+		}
+		item.Name = fmt.Sprintf("+%d %s", bonus, name)
+		item.Base, item.Bonus = name, bonus
+		return item, goods, nil
+	}
+
+	if err := fetcher.FetchJSON(&item, name); err != nil {
+		return item, goods, err
+	}
+	if len(item.Variants) > 0 {
+		names := make([]string, len(item.Variants))
+		for i, variant := range item.Variants {
+			names[i] = variant.Name
+		}
+		return item, goods, fmt.Errorf("%s has variants, list one of: %s", item.Name, strings.Join(names, ", "))
+	}
+	return item, goods, nil
+}
+
+// FetchMagicItemsWithFetcher fetches the template's magic items into the inventory and attunes the character
+// to the ones listed as attuned, using a custom fetcher (for testing)
+func FetchMagicItemsWithFetcher(fetcher core.Fetcher, base *template.Character, inv *Inventory) error {
+	entries := base.MagicItems.Items
+	items := make([]MagicItem, len(entries))
+	bases := make([]Inventory, len(entries))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(entries))
+
+	// Fetch all magic items in parallel, keeping the template's order
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry string) {
+			defer wg.Done()
+			item, goods, err := FetchMagicItemWithFetcher(fetcher, entry)
+			if err != nil {
+				errs <- err
+				return
+			}
+			items[i], bases[i] = item, goods
+		}(i, entry)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return err
+	}
+
+	for i, item := range items {
+		inv.MagicItems = append(inv.MagicItems, item)
+		if item.Base != "" && inv.find(item.Base) == nil {
+			inv.Add(bases[i])
+		}
+	}
+
+	for _, name := range base.MagicItems.Attuned {
+		if _, err := inv.Attune(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchMagicItems fetches the template's magic items using the default fetcher
+func FetchMagicItems(base *template.Character, inv *Inventory) error {
+	return FetchMagicItemsWithFetcher(core.DefaultFetcher, base, inv)
+}
@@ -0,0 +1,97 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MagicItemBuilderTestSuite struct {
+	suite.Suite
+	fetcher *MockFetcherWithFixtures
+}
+
+func (suite *MagicItemBuilderTestSuite) SetupTest() {
+	suite.fetcher = NewMockFetcherWithFixtures(suite.T())
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.MagicItem"), mock.Anything).Return(nil)
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), mock.Anything).Return(nil)
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Weapon"), mock.Anything).Return(nil)
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItem() {
+	item, goods, err := inventory.FetchMagicItemWithFetcher(suite.fetcher, "cloak-of-protection")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Cloak of Protection", item.Name)
+	assert.Equal(suite.T(), "Uncommon", item.Rarity.Name)
+	assert.True(suite.T(), item.RequiresAttunement())
+	assert.Empty(suite.T(), goods.Weapons)
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItem_Enhancement() {
+	item, goods, err := inventory.FetchMagicItemWithFetcher(suite.fetcher, "+1 longsword")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "+1 Longsword", item.Name)
+	assert.Equal(suite.T(), "weapon-1", item.Index)
+	assert.Equal(suite.T(), "Longsword", item.Base)
+	assert.Equal(suite.T(), 1, item.Bonus)
+	require.Len(suite.T(), goods.Weapons, 1)
+	suite.fetcher.AssertCalled(suite.T(), "FetchJSON", mock.AnythingOfType("*inventory.MagicItem"), "weapon-1")
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItem_Variants() {
+	_, _, err := inventory.FetchMagicItemWithFetcher(suite.fetcher, "belt-of-giant-strength")
+
+	require.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Belt of Giant Strength has variants, list one of: Belt of Hill Giant Strength, Belt of Fire Giant Strength", err.Error())
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItems() {
+	base := &template.Character{MagicItems: template.MagicItems{
+		Items:   []string{"+1 longsword", "cloak-of-protection", "gauntlets-of-ogre-power", "bag-of-holding"},
+		Attuned: []string{"Cloak of Protection"},
+	}}
+	inv := &inventory.Inventory{}
+
+	err := inventory.FetchMagicItemsWithFetcher(suite.fetcher, base, inv)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), inv.MagicItems, 4)
+	assert.Equal(suite.T(), "+1 Longsword", inv.MagicItems[0].Name)
+	assert.True(suite.T(), inv.MagicItems[1].Attuned)
+	assert.False(suite.T(), inv.MagicItems[2].Attuned)
+	assert.Equal(suite.T(), 1, inv.Attuned())
+	require.Len(suite.T(), inv.Weapons, 1)
+	assert.Equal(suite.T(), "Longsword", inv.Weapons[0].Name)
+	assert.Equal(suite.T(), 1, inv.MagicEffects().AC)
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItems_KeepsCarriedBase() {
+	base := &template.Character{MagicItems: template.MagicItems{Items: []string{"+1 longsword"}}}
+	inv := &inventory.Inventory{Weapons: []inventory.Weapon{{BaseEquipment: inventory.BaseEquipment{Index: "longsword", Name: "Longsword"}}}}
+
+	require.NoError(suite.T(), inventory.FetchMagicItemsWithFetcher(suite.fetcher, base, inv))
+	assert.Len(suite.T(), inv.Weapons, 1)
+}
+
+func (suite *MagicItemBuilderTestSuite) TestFetchMagicItems_AttunementErrors() {
+	base := &template.Character{MagicItems: template.MagicItems{
+		Items:   []string{"bag-of-holding"},
+		Attuned: []string{"Bag of Holding"},
+	}}
+
+	err := inventory.FetchMagicItemsWithFetcher(suite.fetcher, base, &inventory.Inventory{})
+
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "doesn't require attunement")
+}
+
+func TestMagicItemBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(MagicItemBuilderTestSuite))
+}
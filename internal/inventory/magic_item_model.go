@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// MaxAttuned is how many magic items a character can be attuned to at once
+const MaxAttuned = 3
+
+// MagicItem is an SRD magic item, such as a Cloak of Protection or a +1 weapon
+type MagicItem struct {
+	Index             string                `json:"index"`
+	Name              string                `json:"name"`
+	EquipmentCategory reference.Reference   `json:"equipment_category"`
+	Rarity            Rarity                `json:"rarity"`
+	Variants          []reference.Reference `json:"variants"`
+	Variant           bool                  `json:"variant"`
+	Desc              []string              `json:"desc"`
+	URL               string                `json:"url"`
+	Base              string                `json:"base,omitempty"`  // Weapon or armor a +N variant enhances, e.g. "Longsword"
+	Bonus             int                   `json:"bonus,omitempty"` // The N of a +N weapon or armor
+	Attuned           bool                  `json:"attuned,omitempty"`
+}
+
+type Rarity struct {
+	Name string `json:"name"`
+}
+
+func (m *MagicItem) GetEndpoint() string {
+	return "magic-items/"
+}
+
+func (m *MagicItem) Print() {
+	fmt.Printf("Magic Item: %s (%s)\n", m.Name, strings.ToLower(m.Rarity.Name))
+}
+
+// enhancement matches a +N weapon or armor entry, e.g. "+1 Longsword"
+var enhancement = regexp.MustCompile(`^\+([1-3])\s+(.+)$`)
+
+// ParseEnhancement splits an entry such as "+1 Longsword" into its bonus and base equipment.
+// Other entries return a bonus of 0.
+func ParseEnhancement(entry string) (int, string) {
+	if match := enhancement.FindStringSubmatch(strings.TrimSpace(entry)); match != nil {
+		bonus, _ := strconv.Atoi(match[1])
+		return bonus, match[2]
+	}
+	return 0, strings.TrimSpace(entry)
+}
+
+// RequiresAttunement reports whether the item only works while attuned, from its SRD description
+func (m *MagicItem) RequiresAttunement() bool {
+	return len(m.Desc) > 0 && strings.Contains(strings.ToLower(m.Desc[0]), "requires attunement")
+}
+
+// Active reports whether the item's effects apply: it doesn't need attunement or the character is attuned
+func (m *MagicItem) Active() bool {
+	return m.Attuned || !m.RequiresAttunement()
+}
+
+// MagicEffects are the mechanical bonuses of magic items that apply to every roll of a kind
+type MagicEffects struct {
+	AC          int                  // Bonus to AC
+	UnarmoredAC int                  // Bonus to AC while wearing no armor, e.g. Bracers of Defense
+	Saves       int                  // Bonus to every saving throw
+	Checks      int                  // Bonus to every ability check
+	Scores      map[core.Ability]int // Abilities set to a score, e.g. Strength 19 from Gauntlets of Ogre Power
+}
+
+// magicEffects is the effects of SRD magic items by index.
+// Bonuses of +N weapons and armor only apply to their base equipment, so they aren't listed here.
+var magicEffects = map[string]MagicEffects{
+	"cloak-of-protection":          {AC: 1, Saves: 1},
+	"ring-of-protection":           {AC: 1, Saves: 1},
+	"bracers-of-defense":           {UnarmoredAC: 2},
+	"stone-of-good-luck-luckstone": {Saves: 1, Checks: 1},
+	"gauntlets-of-ogre-power":      {Scores: map[core.Ability]int{core.Strength: 19}},
+	"headband-of-intellect":        {Scores: map[core.Ability]int{core.Intelligence: 19}},
+	"amulet-of-health":             {Scores: map[core.Ability]int{core.Constitution: 19}},
+	"belt-of-hill-giant-strength":  {Scores: map[core.Ability]int{core.Strength: 21}},
+	"belt-of-stone-giant-strength": {Scores: map[core.Ability]int{core.Strength: 23}},
+	"belt-of-frost-giant-strength": {Scores: map[core.Ability]int{core.Strength: 23}},
+	"belt-of-fire-giant-strength":  {Scores: map[core.Ability]int{core.Strength: 25}},
+	"belt-of-cloud-giant-strength": {Scores: map[core.Ability]int{core.Strength: 27}},
+	"belt-of-storm-giant-strength": {Scores: map[core.Ability]int{core.Strength: 29}},
+}
+
+// Effects returns the item's bonuses that apply to every roll of a kind
+func (m *MagicItem) Effects() MagicEffects {
+	return magicEffects[m.Index]
+}
+
+// Combine adds the bonuses of two sets of effects, keeping the highest score each ability is set to
+func (e MagicEffects) Combine(other MagicEffects) MagicEffects {
+	combined := MagicEffects{
+		AC:          e.AC + other.AC,
+		UnarmoredAC: e.UnarmoredAC + other.UnarmoredAC,
+		Saves:       e.Saves + other.Saves,
+		Checks:      e.Checks + other.Checks,
+	}
+	for _, scores := range []map[core.Ability]int{e.Scores, other.Scores} {
+		for ability, score := range scores {
+			if combined.Scores == nil {
+				combined.Scores = map[core.Ability]int{}
+			}
+			combined.Scores[ability] = max(combined.Scores[ability], score)
+		}
+	}
+	return combined
+}
+
+// MagicEffects combines the effects of every active magic item
+func (inv *Inventory) MagicEffects() MagicEffects {
+	var effects MagicEffects
+	for i := range inv.MagicItems {
+		if inv.MagicItems[i].Active() {
+			effects = effects.Combine(inv.MagicItems[i].Effects())
+		}
+	}
+	return effects
+}
+
+// EnhancementBonus returns the bonus of an active +N magic item enhancing equipment with a name, or 0
+func (inv *Inventory) EnhancementBonus(name string) int {
+	bonus := 0
+	for i := range inv.MagicItems {
+		item := &inv.MagicItems[i]
+		if item.Active() && strings.EqualFold(item.Base, name) {
+			bonus = max(bonus, item.Bonus)
+		}
+	}
+	return bonus
+}
+
+// findMagicItem returns the carried magic item with a name or index, ignoring case
+func (inv *Inventory) findMagicItem(name string) *MagicItem {
+	for i := range inv.MagicItems {
+		item := &inv.MagicItems[i]
+		if strings.EqualFold(item.Name, name) || strings.EqualFold(item.Index, strings.ReplaceAll(strings.TrimSpace(name), " ", "-")) {
+			return item
+		}
+	}
+	return nil
+}
+
+// Attuned returns how many magic items the character is attuned to
+func (inv *Inventory) Attuned() int {
+	count := 0
+	for _, item := range inv.MagicItems {
+		if item.Attuned {
+			count++
+		}
+	}
+	return count
+}
+
+// Attune attunes the character to a carried magic item, up to MaxAttuned items
+func (inv *Inventory) Attune(name string) (string, error) {
+	item := inv.findMagicItem(name)
+	switch {
+	case item == nil:
+		return "", fmt.Errorf("no magic item %q in the inventory", name)
+	case !item.RequiresAttunement():
+		return "", fmt.Errorf("%s doesn't require attunement", item.Name)
+	case item.Attuned:
+		return "", fmt.Errorf("already attuned to %s", item.Name)
+	case inv.Attuned() >= MaxAttuned:
+		return "", fmt.Errorf("can't attune to %s, already attuned to %d items", item.Name, MaxAttuned)
+	}
+
+	item.Attuned = true
+	return fmt.Sprintf("attuned to %s (%d/%d)", item.Name, inv.Attuned(), MaxAttuned), nil
+}
+
+// Unattune ends the character's attunement to a magic item
+func (inv *Inventory) Unattune(name string) (string, error) {
+	item := inv.findMagicItem(name)
+	if item == nil || !item.Attuned {
+		return "", fmt.Errorf("not attuned to %q", name)
+	}
+
+	item.Attuned = false
+	return fmt.Sprintf("ended attunement to %s (%d/%d)", item.Name, inv.Attuned(), MaxAttuned), nil
+}
@@ -0,0 +1,124 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func magicItem(index, name string, attunement bool) inventory.MagicItem {
+	desc := "Wondrous item, uncommon"
+	if attunement {
+		desc += " (requires attunement)"
+	}
+	return inventory.MagicItem{Index: index, Name: name, Desc: []string{desc}}
+}
+
+func TestParseEnhancement(t *testing.T) {
+	testCases := []struct {
+		entry string
+		bonus int
+		base  string
+	}{
+		{"+1 Longsword", 1, "Longsword"},
+		{" +3 Plate Armor ", 3, "Plate Armor"},
+		{"+4 Longsword", 0, "+4 Longsword"},
+		{"Cloak of Protection", 0, "Cloak of Protection"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.entry, func(t *testing.T) {
+			bonus, base := inventory.ParseEnhancement(tc.entry)
+			assert.Equal(t, tc.bonus, bonus)
+			assert.Equal(t, tc.base, base)
+		})
+	}
+}
+
+func TestMagicItemActive(t *testing.T) {
+	cloak := magicItem("cloak-of-protection", "Cloak of Protection", true)
+	bag := magicItem("bag-of-holding", "Bag of Holding", false)
+
+	assert.True(t, cloak.RequiresAttunement())
+	assert.False(t, cloak.Active())
+	cloak.Attuned = true
+	assert.True(t, cloak.Active())
+	assert.True(t, bag.Active())
+}
+
+func TestMagicEffects(t *testing.T) {
+	cloak := magicItem("cloak-of-protection", "Cloak of Protection", true)
+	cloak.Attuned = true
+	ring := magicItem("ring-of-protection", "Ring of Protection", true)
+	ring.Attuned = true
+	gauntlets := magicItem("gauntlets-of-ogre-power", "Gauntlets of Ogre Power", true)
+	gauntlets.Attuned = true
+	belt := magicItem("belt-of-hill-giant-strength", "Belt of Hill Giant Strength", true)
+
+	inv := inventory.Inventory{MagicItems: []inventory.MagicItem{cloak, ring, gauntlets, belt}}
+	effects := inv.MagicEffects()
+
+	assert.Equal(t, 2, effects.AC)
+	assert.Equal(t, 2, effects.Saves)
+	assert.Equal(t, map[core.Ability]int{core.Strength: 19}, effects.Scores)
+
+	// Attuning to the belt raises Strength above the gauntlets
+	inv.MagicItems[3].Attuned = true
+	assert.Equal(t, 21, inv.MagicEffects().Scores[core.Strength])
+}
+
+func TestEnhancementBonus(t *testing.T) {
+	inv := inventory.Inventory{MagicItems: []inventory.MagicItem{
+		{Index: "weapon-1", Name: "+1 Longsword", Base: "Longsword", Bonus: 1, Desc: []string{"Weapon (any), uncommon"}},
+	}}
+
+	assert.Equal(t, 1, inv.EnhancementBonus("longsword"))
+	assert.Equal(t, 0, inv.EnhancementBonus("Dagger"))
+}
+
+func TestAttunement(t *testing.T) {
+	inv := inventory.Inventory{MagicItems: []inventory.MagicItem{
+		magicItem("cloak-of-protection", "Cloak of Protection", true),
+		magicItem("ring-of-protection", "Ring of Protection", true),
+		magicItem("gauntlets-of-ogre-power", "Gauntlets of Ogre Power", true),
+		magicItem("amulet-of-health", "Amulet of Health", true),
+		magicItem("bag-of-holding", "Bag of Holding", false),
+	}}
+
+	summary, err := inv.Attune("cloak of protection")
+	require.NoError(t, err)
+	assert.Equal(t, "attuned to Cloak of Protection (1/3)", summary)
+	_, err = inv.Attune("Ring of Protection")
+	require.NoError(t, err)
+	_, err = inv.Attune("gauntlets-of-ogre-power")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		item string
+		err  string
+	}{
+		{"over the limit", "Amulet of Health", "can't attune to Amulet of Health, already attuned to 3 items"},
+		{"already attuned", "Cloak of Protection", "already attuned to Cloak of Protection"},
+		{"no attunement needed", "Bag of Holding", "Bag of Holding doesn't require attunement"},
+		{"not carried", "Vorpal Sword", `no magic item "Vorpal Sword" in the inventory`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := inv.Attune(tc.item)
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		})
+	}
+
+	summary, err = inv.Unattune("Cloak of Protection")
+	require.NoError(t, err)
+	assert.Equal(t, "ended attunement to Cloak of Protection (2/3)", summary)
+	_, err = inv.Unattune("Cloak of Protection")
+	assert.Error(t, err)
+	_, err = inv.Attune("Amulet of Health")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,144 @@
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// startingEquipment collects the equipment a class's starting choices resolve to
+type startingEquipment struct {
+	fetcher core.Fetcher
+	picks   []string
+	counts  map[string]int
+	order   []string
+}
+
+// add records count of a piece of equipment, merging repeats
+func (s *startingEquipment) add(equipment reference.Reference, count int) {
+	if _, ok := s.counts[equipment.Index]; !ok {
+		s.order = append(s.order, equipment.Index)
+	}
+	s.counts[equipment.Index] += max(1, count)
+}
+
+// pickFrom takes the next pick and checks it belongs to an equipment category
+func (s *startingEquipment) pickFrom(category reference.Reference, choose int) error {
+	var fetched EquipmentCategory
+	if err := FetchEquipmentCategoryWithFetcher(s.fetcher, category.Index, &fetched); err != nil {
+		return err
+	}
+
+	for range max(1, choose) {
+		if len(s.picks) == 0 {
+			return fmt.Errorf("no starting_equipment_picks entry for a choice of %s", category.Name)
+		}
+		pick := s.picks[0]
+		s.picks = s.picks[1:]
+
+		equipment, ok := fetched.Find(pick)
+		if !ok {
+			return fmt.Errorf("%q is not one of the %s", pick, category.Name)
+		}
+		s.add(equipment, 1)
+	}
+	return nil
+}
+
+// option resolves a chosen option, descending into "multiple" options and category choices
+func (s *startingEquipment) option(o class.Option) error {
+	switch o.OptionType {
+	case "counted_reference":
+		s.add(*o.Of, o.Count)
+	case "multiple":
+		for _, item := range o.Items {
+			if err := s.option(item); err != nil {
+				return err
+			}
+		}
+	case "choice":
+		if o.Choice == nil || o.Choice.From.EquipmentCategory.Index == "" {
+			return fmt.Errorf("unsupported starting equipment choice %q", o)
+		}
+		return s.pickFrom(o.Choice.From.EquipmentCategory, o.Choice.Choose)
+	default:
+		return fmt.Errorf("unsupported starting equipment option %q", o.OptionType)
+	}
+	return nil
+}
+
+// ResolveStartingEquipmentWithFetcher resolves a class's fixed starting equipment and the chosen options into
+// inventory entries such as "javelin x4". choices holds the option taken from each option group and
+// picks names the equipment chosen for category choices like "any martial weapon", in order.
+func ResolveStartingEquipmentWithFetcher(fetcher core.Fetcher, c *class.Class, choices []int, picks []string) ([]string, error) {
+	if len(choices) != len(c.StartingEquipmentOptions) {
+		return nil, fmt.Errorf("%s has %d starting equipment choices but %d were given",
+			c.Name, len(c.StartingEquipmentOptions), len(choices))
+	}
+
+	s := &startingEquipment{fetcher: fetcher, picks: picks, counts: map[string]int{}}
+	for _, equipment := range c.StartingEquipment {
+		s.add(equipment.Equipment, equipment.Quantity)
+	}
+
+	for i, group := range c.StartingEquipmentOptions {
+		// Some groups are a single choice from a whole category, e.g. a druidic focus
+		if group.From.EquipmentCategory != nil {
+			if err := s.pickFrom(*group.From.EquipmentCategory, group.Choose); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		choice := choices[i]
+		if choice < 0 || choice >= len(group.From.Options) {
+			return nil, fmt.Errorf("starting equipment choice %d for %q must be between 0 and %d",
+				choice, group.Desc, len(group.From.Options)-1)
+		}
+		if err := s.option(group.From.Options[choice]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.picks) > 0 {
+		return nil, fmt.Errorf("unused starting_equipment_picks: %v", s.picks)
+	}
+
+	entries := make([]string, len(s.order))
+	for i, index := range s.order {
+		entries[i] = index
+		if count := s.counts[index]; count > 1 {
+			entries[i] = fmt.Sprintf("%s x%d", index, count)
+		}
+	}
+	return entries, nil
+}
+
+// AddStartingEquipmentWithFetcher resolves a class's starting equipment and adds it to the inventory,
+// stacking it onto equipment already listed in the template
+func AddStartingEquipmentWithFetcher(fetcher core.Fetcher, c *class.Class, choices []int, picks []string, inv *Inventory) error {
+	entries, err := ResolveStartingEquipmentWithFetcher(fetcher, c, choices, picks)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		goods, err := FetchEquipmentWithFetcher(fetcher, entry)
+		if err != nil {
+			return err
+		}
+		inv.Add(goods)
+	}
+	return nil
+}
+
+// FetchEquipmentCategoryWithFetcher fetches an equipment category by index using a custom fetcher (for testing)
+func FetchEquipmentCategoryWithFetcher(fetcher core.Fetcher, index string, category *EquipmentCategory) error {
+	return fetcher.FetchJSON(category, index)
+}
+
+// FetchEquipmentCategory fetches an equipment category by index using the default fetcher
+func FetchEquipmentCategory(index string, category *EquipmentCategory) error {
+	return FetchEquipmentCategoryWithFetcher(core.DefaultFetcher, index, category)
+}
@@ -0,0 +1,134 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StartingEquipmentTestSuite struct {
+	suite.Suite
+	fetcher *MockFetcherWithFixtures
+	class   *class.Class
+}
+
+func equipmentRef(index, name string) *reference.Reference {
+	return &reference.Reference{Index: index, Name: name, URL: "/api/2014/equipment/" + index}
+}
+
+func (suite *StartingEquipmentTestSuite) SetupTest() {
+	suite.fetcher = NewMockFetcherWithFixtures(suite.T())
+	martialMelee := reference.Reference{Index: "martial-melee-weapons", Name: "Martial Melee Weapons"}
+
+	suite.class = &class.Class{
+		Name: "Fighter",
+		StartingEquipment: []class.StartingEquipment{
+			{Equipment: *equipmentRef("dagger", "Dagger"), Quantity: 2},
+		},
+		StartingEquipmentOptions: []class.EquipmentOptionGroup{
+			{
+				Desc:   "(a) leather armor or (b) plate armor and an abacus",
+				Choose: 1,
+				From: class.OptionGroup{Options: []class.Option{
+					{OptionType: "counted_reference", Count: 1, Of: equipmentRef("leather-armor", "Leather Armor")},
+					{OptionType: "multiple", Items: []class.Option{
+						{OptionType: "counted_reference", Count: 1, Of: equipmentRef("plate-armor", "Plate Armor")},
+						{OptionType: "counted_reference", Count: 1, Of: equipmentRef("abacus", "Abacus")},
+					}},
+				}},
+			},
+			{
+				Desc:   "(a) a dagger or (b) any martial melee weapon",
+				Choose: 1,
+				From: class.OptionGroup{Options: []class.Option{
+					{OptionType: "counted_reference", Count: 1, Of: equipmentRef("dagger", "Dagger")},
+					{OptionType: "choice", Choice: &class.ChoiceGroup{
+						Desc:   "any martial melee weapon",
+						Choose: 1,
+						From:   class.EquipmentCategory{OptionSetType: "equipment_category", EquipmentCategory: martialMelee},
+					}},
+				}},
+			},
+			{
+				Desc:   "martial melee weapon",
+				Choose: 1,
+				From:   class.OptionGroup{OptionSetType: "equipment_category", EquipmentCategory: &martialMelee},
+			},
+		},
+	}
+}
+
+func (suite *StartingEquipmentTestSuite) TestResolveStartingEquipment() {
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.EquipmentCategory"), "martial-melee-weapons").Return(nil)
+
+	entries, err := inventory.ResolveStartingEquipmentWithFetcher(suite.fetcher, suite.class, []int{0, 1, 0}, []string{"Longsword", "longsword"})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"dagger x2", "leather-armor", "longsword x2"}, entries)
+}
+
+func (suite *StartingEquipmentTestSuite) TestResolveStartingEquipment_MergesQuantities() {
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.EquipmentCategory"), "martial-melee-weapons").Return(nil)
+
+	entries, err := inventory.ResolveStartingEquipmentWithFetcher(suite.fetcher, suite.class, []int{1, 0, 0}, []string{"Rapier"})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"dagger x3", "plate-armor", "abacus", "rapier"}, entries)
+}
+
+func (suite *StartingEquipmentTestSuite) TestResolveStartingEquipment_Errors() {
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.EquipmentCategory"), "martial-melee-weapons").Return(nil)
+
+	testCases := []struct {
+		name    string
+		choices []int
+		picks   []string
+		err     string
+	}{
+		{"wrong number of choices", []int{0}, nil, "Fighter has 3 starting equipment choices but 1 were given"},
+		{"choice out of range", []int{2, 0, 0}, []string{"Rapier"}, "must be between 0 and 1"},
+		{"missing pick", []int{0, 0, 0}, nil, "no starting_equipment_picks entry for a choice of Martial Melee Weapons"},
+		{"pick outside the category", []int{0, 0, 0}, []string{"Dagger"}, `"Dagger" is not one of the Martial Melee Weapons`},
+		{"unused picks", []int{0, 0, 0}, []string{"Rapier", "Greataxe"}, "unused starting_equipment_picks"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := inventory.ResolveStartingEquipmentWithFetcher(suite.fetcher, suite.class, tc.choices, tc.picks)
+			require.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), tc.err)
+		})
+	}
+}
+
+func (suite *StartingEquipmentTestSuite) TestAddStartingEquipment() {
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.EquipmentCategory"), "martial-melee-weapons").Return(nil)
+	for _, name := range []string{"dagger", "leather-armor", "longsword"} {
+		suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Item"), name).Return(nil)
+	}
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Weapon"), mock.Anything).Return(nil)
+	suite.fetcher.On("FetchJSON", mock.AnythingOfType("*inventory.Armor"), "leather-armor").Return(nil)
+
+	inv := &inventory.Inventory{
+		Weapons: []inventory.Weapon{{BaseEquipment: inventory.BaseEquipment{Index: "dagger", Name: "Dagger"}}},
+	}
+	err := inventory.AddStartingEquipmentWithFetcher(suite.fetcher, suite.class, []int{0, 1, 0}, []string{"Longsword", "Longsword"}, inv)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), inv.Weapons, 2)
+	assert.Equal(suite.T(), "Dagger x3", inv.Weapons[0].DisplayName())
+	assert.Equal(suite.T(), "Longsword x2", inv.Weapons[1].DisplayName())
+	require.Len(suite.T(), inv.Armor, 1)
+	assert.Equal(suite.T(), "Leather Armor", inv.Armor[0].Name)
+	assert.Empty(suite.T(), inv.Items)
+}
+
+func TestStartingEquipmentTestSuite(t *testing.T) {
+	suite.Run(t, new(StartingEquipmentTestSuite))
+}
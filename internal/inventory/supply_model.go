@@ -0,0 +1,121 @@
+package inventory
+
+import "fmt"
+
+// Supply kinds, for deciding how a supply is spent and recovered
+const (
+	SupplyAmmunition = "ammunition"
+	SupplyThrown     = "thrown"
+	SupplyConsumable = "consumable"
+)
+
+// weaponAmmunition is the SRD ammunition each weapon with the ammunition property fires, by index
+var weaponAmmunition = map[string]string{
+	"blowgun":        "blowgun-needle",
+	"crossbow-hand":  "crossbow-bolt",
+	"crossbow-heavy": "crossbow-bolt",
+	"crossbow-light": "crossbow-bolt",
+	"longbow":        "arrow",
+	"shortbow":       "arrow",
+	"sling":          "sling-bullet",
+}
+
+// consumableUses is how many uses each SRD consumable has, e.g. the 10 uses of a Healer's Kit
+var consumableUses = map[string]int{
+	"acid-vial":                 1,
+	"alchemists-fire-flask":     1,
+	"antitoxin-vial":            1,
+	"ball-bearings-bag-of-1000": 1,
+	"caltrops-bag-of-20":        1,
+	"candle":                    1,
+	"healers-kit":               10,
+	"holy-water-flask":          1,
+	"oil-flask":                 1,
+	"poison-basic-vial":         1,
+	"potion-of-healing":         1,
+	"rations-1-day":             1,
+	"torch":                     1,
+}
+
+// Supply is carried equipment that gets spent during play: ammunition, thrown weapons and consumables
+type Supply struct {
+	Index string
+	Name  string
+	Kind  string
+	Count int // Arrows carried, or uses left across every consumable carried
+}
+
+// Ammunition returns the index of the ammunition the weapon fires, or "" when it doesn't use any
+func (w *Weapon) Ammunition() string {
+	if !w.HasProperty("ammunition") {
+		return ""
+	}
+	return weaponAmmunition[w.Index]
+}
+
+// IsAmmunition reports whether the item is ammunition such as arrows or sling bullets
+func (i *Item) IsAmmunition() bool {
+	return i.GearCategory != nil && i.GearCategory.Index == "ammunition"
+}
+
+// Uses returns how many uses a consumable has, 0 when the item isn't consumed
+func (i *Item) Uses() int {
+	return consumableUses[i.Index]
+}
+
+// AmmunitionFor returns the carried ammunition a weapon fires, if any
+func (inv *Inventory) AmmunitionFor(weapon *Weapon) *Item {
+	index := weapon.Ammunition()
+	if index == "" {
+		return nil
+	}
+	for i := range inv.Items {
+		if inv.Items[i].Index == index {
+			return &inv.Items[i]
+		}
+	}
+	return nil
+}
+
+// Supplies lists the carried ammunition, thrown weapons and consumables. The same item stacked
+// in several places, e.g. torches carried loose and in a backpack, is one supply.
+func (inv *Inventory) Supplies() []Supply {
+	var supplies []Supply
+	add := func(supply Supply) {
+		for i := range supplies {
+			if supplies[i].Index == supply.Index {
+				supplies[i].Count += supply.Count
+				return
+			}
+		}
+		supplies = append(supplies, supply)
+	}
+
+	for _, item := range inv.Items {
+		if item.IsAmmunition() {
+			add(Supply{Index: item.Index, Name: item.Name, Kind: SupplyAmmunition, Count: item.Carried()})
+		}
+	}
+	for _, weapon := range inv.Weapons {
+		if weapon.HasProperty("thrown") {
+			add(Supply{Index: weapon.Index, Name: weapon.Name, Kind: SupplyThrown, Count: weapon.Carried()})
+		}
+	}
+	for _, item := range inv.Items {
+		if uses := item.Uses(); uses > 0 {
+			add(Supply{Index: item.Index, Name: item.Name, Kind: SupplyConsumable, Count: item.Carried() * uses})
+		}
+	}
+	return supplies
+}
+
+// weaponLabel returns a weapon's display name with the ammunition it fires, e.g. "Longbow (Arrow x20)"
+func (inv *Inventory) weaponLabel(weapon *Weapon) string {
+	if weapon.Ammunition() == "" {
+		return weapon.DisplayName()
+	}
+	if ammo := inv.AmmunitionFor(weapon); ammo != nil {
+		return fmt.Sprintf("%s (%s)", weapon.DisplayName(), ammo.DisplayName())
+	}
+	return fmt.Sprintf("%s (no ammunition)", weapon.DisplayName())
+}
@@ -0,0 +1,66 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func supplyInventory() *inventory.Inventory {
+	arrows := gear("arrow", "Arrow", 1)
+	arrows.GearCategory = &reference.Reference{Index: "ammunition"}
+	arrows.Quantity, arrows.Count = 20, 20
+	kit := gear("healers-kit", "Healer's Kit", 3)
+	fire := gear("alchemists-fire-flask", "Alchemist's fire (flask)", 1)
+	fire.Count = 2
+
+	return &inventory.Inventory{
+		Weapons: []inventory.Weapon{
+			{BaseEquipment: inventory.BaseEquipment{Index: "longbow", Name: "Longbow", Properties: []reference.Reference{{Index: "ammunition"}}}},
+			{BaseEquipment: inventory.BaseEquipment{Index: "sling", Name: "Sling", Properties: []reference.Reference{{Index: "ammunition"}}}},
+			{BaseEquipment: inventory.BaseEquipment{Index: "javelin", Name: "Javelin", Count: 4, Properties: []reference.Reference{{Index: "thrown"}}}},
+		},
+		Items: []inventory.Item{arrows, kit, fire, gear("torch", "Torch", 1)},
+	}
+}
+
+func TestAmmunitionFor(t *testing.T) {
+	inv := supplyInventory()
+
+	assert.Equal(t, "arrow", inv.Weapons[0].Ammunition())
+	ammo := inv.AmmunitionFor(&inv.Weapons[0])
+	require.NotNil(t, ammo)
+	assert.Equal(t, "Arrow", ammo.Name)
+
+	// Slings fire bullets, which aren't carried
+	assert.Equal(t, "sling-bullet", inv.Weapons[1].Ammunition())
+	assert.Nil(t, inv.AmmunitionFor(&inv.Weapons[1]))
+	assert.Empty(t, inv.Weapons[2].Ammunition())
+}
+
+func TestSupplies(t *testing.T) {
+	inv := supplyInventory()
+
+	assert.Equal(t, []inventory.Supply{
+		{Index: "arrow", Name: "Arrow", Kind: inventory.SupplyAmmunition, Count: 20},
+		{Index: "javelin", Name: "Javelin", Kind: inventory.SupplyThrown, Count: 4},
+		{Index: "healers-kit", Name: "Healer's Kit", Kind: inventory.SupplyConsumable, Count: 10},
+		{Index: "alchemists-fire-flask", Name: "Alchemist's fire (flask)", Kind: inventory.SupplyConsumable, Count: 2},
+		{Index: "torch", Name: "Torch", Kind: inventory.SupplyConsumable, Count: 1},
+	}, inv.Supplies())
+}
+
+func TestSupplies_StackedInSeveralPlaces(t *testing.T) {
+	loose := gear("torch", "Torch", 1)
+	loose.Count = 2
+	packed := gear("torch", "Torch", 1)
+	packed.Count, packed.Container = 10, "backpack"
+	inv := &inventory.Inventory{Items: []inventory.Item{loose, gear("backpack", "Backpack", 5), packed}}
+
+	assert.Equal(t, []inventory.Supply{
+		{Index: "torch", Name: "Torch", Kind: inventory.SupplyConsumable, Count: 12},
+	}, inv.Supplies())
+}
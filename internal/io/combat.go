@@ -0,0 +1,65 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kwford18/MKDIRagons/internal/combat"
+)
+
+// LoadCombat reads a saved fight. Characters pick up their latest play state, so HP changed
+// between sessions with the play commands carries into the fight.
+func LoadCombat(path string) (*combat.Combat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+
+	var c combat.Combat
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("could not decode JSON: %w", err)
+	}
+
+	for i := range c.Combatants {
+		cb := &c.Combatants[i]
+		if cb.IsMonster() {
+			continue
+		}
+		char, err := LoadCharacter(cb.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", cb.Path, err)
+		}
+		if cb.State, err = LoadState(StatePath(cb.Path), char); err != nil {
+			return nil, fmt.Errorf("failed to load the play state of %s: %w", cb.Path, err)
+		}
+		cb.Character = char
+	}
+	return &c, nil
+}
+
+// WriteCombat writes the fight as pretty JSON and each character's play state next to their JSON
+func WriteCombat(c *combat.Combat, path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal combat: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	for _, cb := range c.Combatants {
+		if cb.IsMonster() {
+			continue
+		}
+		if err := WriteState(cb.State, StatePath(cb.Path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package io_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/combat"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombat(t *testing.T) {
+	// newFight puts a copy of the fixture character and a wolf into a fight in a temporary directory
+	newFight := func(t *testing.T) (*combat.Combat, string, string) {
+		dir := t.TempDir()
+		charPath := filepath.Join(dir, "leki.json")
+		data, err := os.ReadFile(filepath.Join("testdata", "valid_character.json"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(charPath, data, 0644))
+
+		char, err := io.LoadCharacter(charPath)
+		require.NoError(t, err)
+		state, err := io.LoadState(io.StatePath(charPath), char)
+		require.NoError(t, err)
+
+		wolf := &monster.Monster{Index: "wolf", Name: "Wolf", HitPoints: 11}
+		c := &combat.Combat{Name: "ambush", Round: 2, Combatants: []combat.Combatant{
+			combat.NewCharacterCombatant(charPath, char, state),
+			combat.NewMonsterCombatant("Wolf 1", wolf),
+		}}
+		return c, filepath.Join(dir, "combats", "ambush.json"), charPath
+	}
+
+	t.Run("round trips and writes character states", func(t *testing.T) {
+		c, path, charPath := newFight(t)
+		_, err := c.Combatants[0].State.Damage(5)
+		require.NoError(t, err)
+		_, err = c.Combatants[1].State.Damage(4)
+		require.NoError(t, err)
+
+		require.NoError(t, io.WriteCombat(c, path))
+		loaded, err := io.LoadCombat(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, loaded.Round)
+		assert.Equal(t, 7, loaded.Combatants[1].State.HP)
+		assert.Equal(t, c.Combatants[0].State.HP, loaded.Combatants[0].State.HP)
+		assert.FileExists(t, io.StatePath(charPath))
+	})
+
+	t.Run("characters pick up changes made between sessions", func(t *testing.T) {
+		c, path, charPath := newFight(t)
+		require.NoError(t, io.WriteCombat(c, path))
+
+		char, err := io.LoadCharacter(charPath)
+		require.NoError(t, err)
+		state, err := io.LoadState(io.StatePath(charPath), char)
+		require.NoError(t, err)
+		_, err = state.Damage(3)
+		require.NoError(t, err)
+		require.NoError(t, io.WriteState(state, io.StatePath(charPath)))
+
+		loaded, err := io.LoadCombat(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, state.HP, loaded.Combatants[0].State.HP)
+	})
+
+	t.Run("fails when the fight does not exist", func(t *testing.T) {
+		_, err := io.LoadCombat(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorContains(t, err, "could not open file")
+	})
+}
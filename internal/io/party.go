@@ -0,0 +1,67 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kwford18/MKDIRagons/internal/party"
+)
+
+// LoadParty reads a saved party
+func LoadParty(path string) (*party.Party, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+
+	var p party.Party
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not decode JSON: %w", err)
+	}
+	return &p, nil
+}
+
+// WriteParty writes the party as pretty JSON, creating its directory and overwriting the file
+func WriteParty(p *party.Party, path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal party: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadMembers loads every member's character and play state
+func LoadMembers(p *party.Party) ([]party.Member, error) {
+	members := make([]party.Member, len(p.Members))
+	for i, path := range p.Members {
+		char, err := LoadCharacter(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+		state, err := LoadState(StatePath(path), char)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the play state of %s: %w", path, err)
+		}
+		members[i] = party.Member{Character: char, State: state}
+	}
+	return members, nil
+}
+
+// WriteMemberStates saves every member's play state next to their character
+func WriteMemberStates(p *party.Party, members []party.Member) error {
+	for i, path := range p.Members {
+		if err := WriteState(members[i].State, StatePath(path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
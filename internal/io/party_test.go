@@ -0,0 +1,49 @@
+package io_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/party"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParty(t *testing.T) {
+	t.Run("round trips through JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "parties", "tuesday.json")
+		p := &party.Party{Name: "tuesday", Members: []string{"testdata/valid_character.json"}}
+
+		require.NoError(t, io.WriteParty(p, path))
+		loaded, err := io.LoadParty(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, p, loaded)
+	})
+
+	t.Run("fails when the party does not exist", func(t *testing.T) {
+		_, err := io.LoadParty(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorContains(t, err, "could not open file")
+	})
+
+	t.Run("loads members with fresh play states", func(t *testing.T) {
+		p := &party.Party{Name: "tuesday", Members: []string{"testdata/valid_character.json"}}
+
+		members, err := io.LoadMembers(p)
+
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, "Leki", members[0].Character.Name)
+		assert.Equal(t, members[0].State.MaxHP, members[0].State.HP)
+	})
+
+	t.Run("fails on a missing member", func(t *testing.T) {
+		p := &party.Party{Name: "tuesday", Members: []string{"testdata/nobody.json"}}
+
+		_, err := io.LoadMembers(p)
+
+		assert.ErrorContains(t, err, "failed to load testdata/nobody.json")
+	})
+}
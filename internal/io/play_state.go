@@ -0,0 +1,52 @@
+package io
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/play"
+)
+
+// StatePath returns where the play state of a character JSON is kept, e.g. characters/leki.state.json
+func StatePath(characterPath string) string {
+	return strings.TrimSuffix(characterPath, ".json") + ".state.json"
+}
+
+// LoadState reads the play state of a character, starting a fresh one if none has been saved yet.
+// The state is synced with the character so level ups are picked up.
+func LoadState(path string, char *character.Character) (*play.State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return play.NewState(char), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+
+	var state play.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("could not decode JSON: %w", err)
+	}
+	state.Sync(char)
+
+	return &state, nil
+}
+
+// WriteState writes the play state as pretty JSON, overwriting it
+func WriteState(state *play.State, path string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal play state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
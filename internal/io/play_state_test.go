@@ -0,0 +1,60 @@
+package io_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayState(t *testing.T) {
+	char := &character.Character{
+		Name:  "Leki",
+		Level: 1,
+		Class: class.Class{Name: "Cleric", HitDie: 8},
+		Stats: stats.Stats{HP: 10},
+	}
+
+	t.Run("state path sits next to the character", func(t *testing.T) {
+		assert.Equal(t, "characters/leki.state.json", io.StatePath("characters/leki.json"))
+	})
+
+	t.Run("missing state starts fresh", func(t *testing.T) {
+		state, err := io.LoadState(filepath.Join(t.TempDir(), "leki.state.json"), char)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10, state.HP)
+		assert.Empty(t, state.History)
+	})
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "leki.state.json")
+		state, err := io.LoadState(path, char)
+		require.NoError(t, err)
+		_, err = state.Damage(4)
+		require.NoError(t, err)
+
+		require.NoError(t, io.WriteState(state, path))
+		loaded, err := io.LoadState(path, char)
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, loaded.HP)
+		require.Len(t, loaded.History, 1)
+		assert.Equal(t, 10, loaded.History[0].Before.HP)
+	})
+
+	t.Run("fails on malformed JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.state.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+		_, err := io.LoadState(path, char)
+
+		assert.ErrorContains(t, err, "could not decode JSON")
+	})
+}
@@ -37,6 +37,16 @@ func SaveJSON(character *character.Character, dirPath string) error {
 	// Join directory and filename
 	filePath := filepath.Join(cleanDir, fileName)
 
+	if err := WriteJSON(character, filePath); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Character saved to: %s\n", filePath)
+	return nil
+}
+
+// WriteJSON writes the character as pretty JSON to an exact file path, overwriting it
+func WriteJSON(character *character.Character, filePath string) error {
 	// Convert character struct to pretty JSON
 	data, err := json.MarshalIndent(character, "", "  ")
 	if err != nil {
@@ -55,6 +65,5 @@ func SaveJSON(character *character.Character, dirPath string) error {
 		return err
 	}
 
-	fmt.Printf("✓ Character saved to: %s\n", filePath)
 	return nil
 }
@@ -114,4 +114,22 @@ func TestSaveJSON(t *testing.T) {
 		assert.Equal(t, 99, savedChar.Level)
 		assert.Equal(t, "Wizard", savedChar.Class.Name)
 	})
+
+	t.Run("writes to an exact file path", func(t *testing.T) {
+		char := &character.Character{
+			Name:  "exact",
+			Level: 3,
+			Class: class.Class{Name: "Rogue"},
+		}
+		filePath := filepath.Join(t.TempDir(), "custom-name.json")
+
+		err := io.WriteJSON(char, filePath)
+		require.NoError(t, err)
+
+		loaded, err := io.LoadCharacter(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "exact", loaded.Name)
+		assert.Equal(t, 3, loaded.Level)
+		assert.Equal(t, "Rogue", loaded.Class.Name)
+	})
 }
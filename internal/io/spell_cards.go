@@ -0,0 +1,26 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kwford18/MKDIRagons/internal/cards"
+)
+
+// WriteSpellCards renders spell cards to an HTML page at path
+func WriteSpellCards(list []cards.Card, title, path string) error {
+	var buf bytes.Buffer
+	if err := cards.Render(&buf, title, list); err != nil {
+		return fmt.Errorf("failed to render spell cards: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package io_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/cards"
+	"github.com/kwford18/MKDIRagons/internal/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSpellCards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spellcards", "leki.html")
+
+	require.NoError(t, io.WriteSpellCards([]cards.Card{{Name: "Bless"}}, "Leki", path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<h2>Bless</h2>")
+}
@@ -0,0 +1,33 @@
+package monster
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+)
+
+// FetchMonsterWithFetcher fetches a monster by name using a custom fetcher (for testing)
+func FetchMonsterWithFetcher(fetcher core.Fetcher, name string, monster *Monster) error {
+	return fetcher.FetchJSON(monster, name)
+}
+
+// FetchMonster fetches a monster by name using the default fetcher
+func FetchMonster(name string, monster *Monster) error {
+	return FetchMonsterWithFetcher(core.DefaultFetcher, name, monster)
+}
+
+// FetchMonsterListWithFetcher fetches the index of monsters with one of the challenge ratings
+// using a custom fetcher (for testing)
+func FetchMonsterListWithFetcher(fetcher core.Fetcher, crs []float64, list *MonsterList) error {
+	ratings := make([]string, len(crs))
+	for i, cr := range crs {
+		ratings[i] = strconv.FormatFloat(cr, 'f', -1, 64)
+	}
+	return fetcher.FetchJSON(list, "?challenge_rating="+strings.Join(ratings, ","))
+}
+
+// FetchMonsterList fetches the index of monsters with one of the challenge ratings using the default fetcher
+func FetchMonsterList(crs []float64, list *MonsterList) error {
+	return FetchMonsterListWithFetcher(core.DefaultFetcher, crs, list)
+}
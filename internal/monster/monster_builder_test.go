@@ -0,0 +1,86 @@
+package monster_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockFetcherWithFixtures struct {
+	mock.Mock
+	t *testing.T
+}
+
+func (m *MockFetcherWithFixtures) FetchJSON(property reference.Fetchable, input string) error {
+	args := m.Called(property, input)
+
+	if args.Error(0) == nil && input != "" {
+		core.LoadFixtureInto(m.t, input+".json", property)
+	}
+
+	return args.Error(0)
+}
+
+func TestFetchMonsterWithFetcher(t *testing.T) {
+	t.Run("loads the stat block", func(t *testing.T) {
+		fetcher := &MockFetcherWithFixtures{t: t}
+		fetcher.On("FetchJSON", mock.AnythingOfType("*monster.Monster"), "goblin").Return(nil)
+
+		var goblin monster.Monster
+		err := monster.FetchMonsterWithFetcher(fetcher, "goblin", &goblin)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Goblin", goblin.Name)
+		assert.Equal(t, 15, goblin.AC())
+		assert.Equal(t, 7, goblin.HitPoints)
+		assert.Equal(t, 14, goblin.Dexterity)
+		assert.Equal(t, 0.25, goblin.ChallengeRating)
+		assert.Equal(t, 50, goblin.XP)
+		require.Len(t, goblin.Actions, 2)
+		assert.Equal(t, "1d6+2", goblin.Actions[0].Damage[0].DamageDice)
+		fetcher.AssertExpectations(t)
+	})
+
+	t.Run("loads actions with a save and usage", func(t *testing.T) {
+		fetcher := &MockFetcherWithFixtures{t: t}
+		fetcher.On("FetchJSON", mock.AnythingOfType("*monster.Monster"), "young-red-dragon").Return(nil)
+
+		var dragon monster.Monster
+		err := monster.FetchMonsterWithFetcher(fetcher, "young-red-dragon", &dragon)
+
+		require.NoError(t, err)
+		breath := dragon.Actions[2]
+		require.NotNil(t, breath.DC)
+		assert.Equal(t, 17, breath.DC.DCValue)
+		assert.Equal(t, 5, breath.Usage.MinValue)
+		assert.Equal(t, []string{"fire"}, dragon.DamageImmunities)
+	})
+
+	t.Run("returns fetch errors", func(t *testing.T) {
+		fetcher := &MockFetcherWithFixtures{t: t}
+		fetcher.On("FetchJSON", mock.Anything, "tarrasque-jr").Return(errors.New("404 not found"))
+
+		var m monster.Monster
+		err := monster.FetchMonsterWithFetcher(fetcher, "tarrasque-jr", &m)
+
+		assert.EqualError(t, err, "404 not found")
+	})
+}
+
+func TestFetchMonsterListWithFetcher(t *testing.T) {
+	fetcher := &MockFetcherWithFixtures{t: t}
+	fetcher.On("FetchJSON", mock.AnythingOfType("*monster.MonsterList"), "?challenge_rating=0.25,0.5,2").
+		Return(errors.New("offline"))
+
+	var list monster.MonsterList
+	err := monster.FetchMonsterListWithFetcher(fetcher, []float64{0.25, 0.5, 2}, &list)
+
+	assert.EqualError(t, err, "offline")
+	fetcher.AssertExpectations(t)
+}
@@ -0,0 +1,339 @@
+package monster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+)
+
+// Monster is an SRD creature's stat block, e.g. a Goblin or a Brown Bear
+type Monster struct {
+	Index     string `json:"index"`
+	Name      string `json:"name"`
+	Size      string `json:"size"`
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype,omitempty"`
+	Alignment string `json:"alignment"`
+
+	ArmorClass    []ArmorClass `json:"armor_class"`
+	HitPoints     int          `json:"hit_points"`
+	HitDice       string       `json:"hit_dice"`
+	HitPointsRoll string       `json:"hit_points_roll"` // Hit dice plus the Constitution bonus, e.g. "2d8+2"
+	Speed         Speed        `json:"speed"`
+
+	abilities.AbilityScores
+
+	Proficiencies         []Proficiency         `json:"proficiencies"`
+	DamageVulnerabilities []string              `json:"damage_vulnerabilities"`
+	DamageResistances     []string              `json:"damage_resistances"`
+	DamageImmunities      []string              `json:"damage_immunities"`
+	ConditionImmunities   []reference.Reference `json:"condition_immunities"`
+	Senses                Senses                `json:"senses"`
+	Languages             string                `json:"languages"`
+
+	ChallengeRating  float64 `json:"challenge_rating"`
+	ProficiencyBonus int     `json:"proficiency_bonus"`
+	XP               int     `json:"xp"`
+
+	SpecialAbilities []Action `json:"special_abilities"`
+	Actions          []Action `json:"actions"`
+	Reactions        []Action `json:"reactions,omitempty"`
+	LegendaryActions []Action `json:"legendary_actions"`
+
+	URL string `json:"url"`
+}
+
+// ArmorClass is one of a monster's armor class entries, e.g. 15 from leather armor and a shield
+type ArmorClass struct {
+	Type  string                `json:"type"`
+	Value int                   `json:"value"`
+	Armor []reference.Reference `json:"armor,omitempty"`
+	Desc  string                `json:"desc,omitempty"`
+}
+
+// Speed lists a monster's movement speeds, e.g. "30 ft."
+type Speed struct {
+	Walk   string `json:"walk,omitempty"`
+	Burrow string `json:"burrow,omitempty"`
+	Climb  string `json:"climb,omitempty"`
+	Fly    string `json:"fly,omitempty"`
+	Swim   string `json:"swim,omitempty"`
+	Hover  bool   `json:"hover,omitempty"`
+}
+
+// Senses lists a monster's special senses and passive Perception
+type Senses struct {
+	Blindsight        string `json:"blindsight,omitempty"`
+	Darkvision        string `json:"darkvision,omitempty"`
+	Tremorsense       string `json:"tremorsense,omitempty"`
+	Truesight         string `json:"truesight,omitempty"`
+	PassivePerception int    `json:"passive_perception"`
+}
+
+// Proficiency is a saving throw or skill bonus, e.g. "Skill: Stealth" +6
+type Proficiency struct {
+	Value       int                 `json:"value"`
+	Proficiency reference.Reference `json:"proficiency"`
+}
+
+// Action is a special ability, action, reaction or legendary action
+type Action struct {
+	Name        string         `json:"name"`
+	Desc        string         `json:"desc"`
+	AttackBonus int            `json:"attack_bonus,omitempty"`
+	Damage      []ActionDamage `json:"damage,omitempty"`
+	DC          *ActionDC      `json:"dc,omitempty"`
+	Usage       *Usage         `json:"usage,omitempty"`
+}
+
+// ActionDamage is the damage an action deals, e.g. 1d6+2 slashing
+type ActionDamage struct {
+	DamageType reference.Reference `json:"damage_type"`
+	DamageDice string              `json:"damage_dice"`
+}
+
+// ActionDC is the saving throw an action forces
+type ActionDC struct {
+	DCType      reference.Reference `json:"dc_type"`
+	DCValue     int                 `json:"dc_value"`
+	SuccessType string              `json:"success_type"`
+}
+
+// Usage limits how often an action can be used, e.g. "recharge on roll" 5 or "per day" 3 times
+type Usage struct {
+	Type     string `json:"type"`
+	Times    int    `json:"times,omitempty"`
+	Dice     string `json:"dice,omitempty"`
+	MinValue int    `json:"min_value,omitempty"`
+}
+
+func (m *Monster) GetEndpoint() string {
+	return "monsters/"
+}
+
+// MonsterList is the API's index of monsters, e.g. every monster of a challenge rating
+type MonsterList struct {
+	Count   int                   `json:"count"`
+	Results []reference.Reference `json:"results"`
+}
+
+func (l *MonsterList) GetEndpoint() string {
+	return "monsters"
+}
+
+// ChallengeRatings are every challenge rating a monster can have, in order
+var ChallengeRatings = []float64{0, 0.125, 0.25, 0.5, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30}
+
+// ParseCR reads a challenge rating such as "1/4", "0.25" or "3"
+func ParseCR(cr string) (float64, error) {
+	cr = strings.TrimSpace(cr)
+	for _, known := range ChallengeRatings {
+		if cr == FormatCR(known) || cr == fmt.Sprintf("%g", known) {
+			return known, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown challenge rating %q", cr)
+}
+
+// AC returns the monster's armor class, the first entry when there are several
+func (m *Monster) AC() int {
+	if len(m.ArmorClass) == 0 {
+		return 10 + m.Modifier(core.Dexterity)
+	}
+	return m.ArmorClass[0].Value
+}
+
+// FormatCR renders the challenge rating the way stat blocks do, e.g. "1/4"
+func FormatCR(cr float64) string {
+	switch cr {
+	case 0.125:
+		return "1/8"
+	case 0.25:
+		return "1/4"
+	case 0.5:
+		return "1/2"
+	}
+	return fmt.Sprintf("%g", cr)
+}
+
+// WalkSpeed returns the walking speed in feet
+func (m *Monster) WalkSpeed() int {
+	return feet(m.Speed.Walk)
+}
+
+// feet parses a distance such as "30 ft." into feet, 0 when it can't be read
+func feet(distance string) int {
+	var ft int
+	fmt.Sscanf(distance, "%d", &ft)
+	return ft
+}
+
+// String renders the speeds like a stat block, e.g. "30 ft., climb 30 ft."
+func (s Speed) String() string {
+	var speeds []string
+	if s.Walk != "" {
+		speeds = append(speeds, s.Walk)
+	}
+	for _, speed := range []struct{ kind, value string }{
+		{"burrow", s.Burrow}, {"climb", s.Climb}, {"fly", s.Fly}, {"swim", s.Swim},
+	} {
+		if speed.value == "" {
+			continue
+		}
+		if speed.kind == "fly" && s.Hover {
+			speed.value += " (hover)"
+		}
+		speeds = append(speeds, speed.kind+" "+speed.value)
+	}
+	return strings.Join(speeds, ", ")
+}
+
+// String renders the senses like a stat block, e.g. "darkvision 60 ft., passive Perception 9"
+func (s Senses) String() string {
+	var senses []string
+	for _, sense := range []struct{ kind, value string }{
+		{"blindsight", s.Blindsight}, {"darkvision", s.Darkvision}, {"tremorsense", s.Tremorsense}, {"truesight", s.Truesight},
+	} {
+		if sense.value != "" {
+			senses = append(senses, sense.kind+" "+sense.value)
+		}
+	}
+	return strings.Join(append(senses, fmt.Sprintf("passive Perception %d", s.PassivePerception)), ", ")
+}
+
+// proficiencies lists the saving throw or skill bonuses whose name starts with prefix, e.g. "Skill: Stealth +6"
+func (m *Monster) proficiencies(prefix string) string {
+	var bonuses []string
+	for _, p := range m.Proficiencies {
+		if name, ok := strings.CutPrefix(p.Proficiency.Name, prefix); ok {
+			bonuses = append(bonuses, fmt.Sprintf("%s %+d", name, p.Value))
+		}
+	}
+	return strings.Join(bonuses, ", ")
+}
+
+// ProficiencyBonusFor returns the monster's bonus to a saving throw or skill, e.g. "Skill: Stealth"
+func (m *Monster) ProficiencyBonusFor(name string) (int, bool) {
+	for _, p := range m.Proficiencies {
+		if strings.EqualFold(p.Proficiency.Name, name) {
+			return p.Value, true
+		}
+	}
+	return 0, false
+}
+
+// armorDesc renders what the armor class comes from, e.g. "leather armor, shield"
+func (ac ArmorClass) armorDesc() string {
+	if len(ac.Armor) == 0 {
+		if ac.Type == "natural" {
+			return "natural armor"
+		}
+		return ac.Desc
+	}
+	names := make([]string, len(ac.Armor))
+	for i, armor := range ac.Armor {
+		names[i] = strings.ToLower(armor.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// String renders the action with its usage, e.g. "Fire Breath (Recharge 5-6). Exhales fire..."
+func (a Action) String() string {
+	name := a.Name
+	// SRD names often carry the usage already, e.g. "Fire Breath (Recharge 5-6)"
+	if a.Usage != nil && !strings.Contains(name, "(") {
+		switch a.Usage.Type {
+		case "recharge on roll":
+			name += fmt.Sprintf(" (Recharge %d-6)", a.Usage.MinValue)
+		case "per day":
+			name += fmt.Sprintf(" (%d/Day)", a.Usage.Times)
+		case "recharge after rest":
+			name += " (Recharges after a Rest)"
+		}
+	}
+	return fmt.Sprintf("%s. %s", name, a.Desc)
+}
+
+// String renders the monster as a stat block
+func (m *Monster) String() string {
+	var sb strings.Builder
+
+	kind := m.Type
+	if m.Subtype != "" {
+		kind += fmt.Sprintf(" (%s)", m.Subtype)
+	}
+	fmt.Fprintf(&sb, "%s\n%s %s, %s\n", m.Name, m.Size, kind, m.Alignment)
+	sb.WriteString(strings.Repeat("-", 40) + "\n")
+
+	ac := fmt.Sprintf("%d", m.AC())
+	if len(m.ArmorClass) > 0 {
+		if desc := m.ArmorClass[0].armorDesc(); desc != "" {
+			ac += fmt.Sprintf(" (%s)", desc)
+		}
+	}
+	fmt.Fprintf(&sb, "Armor Class %s\n", ac)
+	fmt.Fprintf(&sb, "Hit Points %d (%s)\n", m.HitPoints, m.HitPointsRoll)
+	fmt.Fprintf(&sb, "Speed %s\n", m.Speed)
+	sb.WriteString(strings.Repeat("-", 40) + "\n")
+
+	for _, ability := range []core.Ability{core.Strength, core.Dexterity, core.Constitution, core.Intelligence, core.Wisdom, core.Charisma} {
+		fmt.Fprintf(&sb, "%s %d (%+d)  ", strings.ToUpper(ability.String()[:3]), m.Score(ability), m.Modifier(ability))
+	}
+	sb.WriteString("\n" + strings.Repeat("-", 40) + "\n")
+
+	for _, line := range []struct{ label, value string }{
+		{"Saving Throws", m.proficiencies("Saving Throw: ")},
+		{"Skills", m.proficiencies("Skill: ")},
+		{"Damage Vulnerabilities", strings.Join(m.DamageVulnerabilities, ", ")},
+		{"Damage Resistances", strings.Join(m.DamageResistances, ", ")},
+		{"Damage Immunities", strings.Join(m.DamageImmunities, ", ")},
+		{"Condition Immunities", conditionNames(m.ConditionImmunities)},
+		{"Senses", m.Senses.String()},
+		{"Languages", m.Languages},
+	} {
+		if line.value != "" {
+			fmt.Fprintf(&sb, "%s %s\n", line.label, line.value)
+		}
+	}
+	if m.Languages == "" {
+		sb.WriteString("Languages —\n")
+	}
+	fmt.Fprintf(&sb, "Challenge %s (%d XP)  Proficiency Bonus %+d\n", FormatCR(m.ChallengeRating), m.XP, m.ProficiencyBonus)
+
+	writeActions(&sb, "", m.SpecialAbilities)
+	writeActions(&sb, "Actions", m.Actions)
+	writeActions(&sb, "Reactions", m.Reactions)
+	writeActions(&sb, "Legendary Actions", m.LegendaryActions)
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// conditionNames joins condition references into a lowercase list
+func conditionNames(refs []reference.Reference) string {
+	names := make([]string, len(refs))
+	for i, ref := range refs {
+		names[i] = strings.ToLower(ref.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeActions writes a stat block section, without a heading for special abilities
+func writeActions(sb *strings.Builder, heading string, actions []Action) {
+	if len(actions) == 0 {
+		return
+	}
+	sb.WriteString(strings.Repeat("-", 40) + "\n")
+	if heading != "" {
+		sb.WriteString(heading + "\n")
+	}
+	for _, action := range actions {
+		sb.WriteString(action.String() + "\n\n")
+	}
+}
+
+func (m *Monster) Print() {
+	fmt.Println(m)
+}
@@ -0,0 +1,110 @@
+package monster_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadMonster(t *testing.T, name string) *monster.Monster {
+	var m monster.Monster
+	core.LoadFixtureInto(t, name+".json", &m)
+	return &m
+}
+
+func TestFormatCR(t *testing.T) {
+	tests := map[float64]string{0: "0", 0.125: "1/8", 0.25: "1/4", 0.5: "1/2", 1: "1", 17: "17"}
+	for cr, expected := range tests {
+		assert.Equal(t, expected, monster.FormatCR(cr))
+	}
+}
+
+func TestParseCR(t *testing.T) {
+	for input, expected := range map[string]float64{"1/8": 0.125, "1/4": 0.25, "0.5": 0.5, "0": 0, "10": 10, " 2 ": 2} {
+		cr, err := monster.ParseCR(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, cr, input)
+	}
+
+	_, err := monster.ParseCR("31")
+	assert.EqualError(t, err, `unknown challenge rating "31"`)
+}
+
+func TestSpeedAndSenses(t *testing.T) {
+	dragon := loadMonster(t, "young-red-dragon")
+
+	assert.Equal(t, "40 ft., climb 40 ft., fly 80 ft.", dragon.Speed.String())
+	assert.Equal(t, 40, dragon.WalkSpeed())
+	assert.Equal(t, "blindsight 30 ft., darkvision 120 ft., passive Perception 18", dragon.Senses.String())
+	assert.Equal(t, "fly 60 ft. (hover)", monster.Speed{Fly: "60 ft.", Hover: true}.String())
+}
+
+func TestProficiencyBonusFor(t *testing.T) {
+	dragon := loadMonster(t, "young-red-dragon")
+
+	bonus, ok := dragon.ProficiencyBonusFor("Saving Throw: CON")
+	assert.True(t, ok)
+	assert.Equal(t, 9, bonus)
+
+	_, ok = dragon.ProficiencyBonusFor("Skill: Arcana")
+	assert.False(t, ok)
+}
+
+func TestAC_WithoutArmorClassEntries(t *testing.T) {
+	m := monster.Monster{AbilityScores: abilities.AbilityScores{Dexterity: 14}}
+	assert.Equal(t, 12, m.AC())
+}
+
+func TestActionString(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   monster.Action
+		expected string
+	}{
+		{"plain", monster.Action{Name: "Bite", Desc: "Melee."}, "Bite. Melee."},
+		{"recharge", monster.Action{Name: "Fire Breath", Desc: "Fire.", Usage: &monster.Usage{Type: "recharge on roll", MinValue: 5}}, "Fire Breath (Recharge 5-6). Fire."},
+		{"per day", monster.Action{Name: "Web", Desc: "Sticky.", Usage: &monster.Usage{Type: "per day", Times: 3}}, "Web (3/Day). Sticky."},
+		{"usage already named", monster.Action{Name: "Web (Recharge 5-6)", Desc: "Sticky.", Usage: &monster.Usage{Type: "recharge on roll", MinValue: 5}}, "Web (Recharge 5-6). Sticky."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.action.String())
+		})
+	}
+}
+
+func TestString_StatBlock(t *testing.T) {
+	goblin := loadMonster(t, "goblin")
+
+	block := goblin.String()
+
+	for _, line := range []string{
+		"Goblin\nSmall humanoid (goblinoid), neutral evil",
+		"Armor Class 15 (leather armor, shield)",
+		"Hit Points 7 (2d6)",
+		"Speed 30 ft.",
+		"STR 8 (-1)  DEX 14 (+2)  CON 10 (+0)",
+		"Skills Stealth +6",
+		"Senses darkvision 60 ft., passive Perception 9",
+		"Languages Common, Goblin",
+		"Challenge 1/4 (50 XP)  Proficiency Bonus +2",
+		"Nimble Escape. The goblin can take",
+		"Actions\nScimitar. Melee Weapon Attack",
+	} {
+		assert.Contains(t, block, line)
+	}
+	assert.NotContains(t, block, "Legendary Actions")
+	assert.NotContains(t, block, "Saving Throws")
+
+	dragon := loadMonster(t, "young-red-dragon")
+	dragon.LegendaryActions = []monster.Action{{Name: "Detect", Desc: "The dragon makes a Wisdom (Perception) check."}}
+	block = dragon.String()
+	require.Contains(t, block, "Armor Class 18 (natural armor)")
+	assert.Contains(t, block, "Saving Throws DEX +4, CON +9, WIS +4, CHA +8")
+	assert.Contains(t, block, "Damage Immunities fire")
+	assert.Contains(t, block, "Legendary Actions\nDetect.")
+}
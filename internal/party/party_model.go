@@ -0,0 +1,79 @@
+package party
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/play"
+)
+
+// Party is a named group of saved characters, kept as the paths of their JSON files
+type Party struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// Member is a party member's character with their play state
+type Member struct {
+	Character *character.Character
+	State     *play.State
+}
+
+// Add adds a character JSON to the party
+func (p *Party) Add(path string) (string, error) {
+	if slices.Contains(p.Members, path) {
+		return "", fmt.Errorf("%s is already in %s", path, p.Name)
+	}
+	p.Members = append(p.Members, path)
+	return fmt.Sprintf("added %s", path), nil
+}
+
+// Remove takes a character JSON out of the party
+func (p *Party) Remove(path string) (string, error) {
+	if !slices.Contains(p.Members, path) {
+		return "", fmt.Errorf("%s isn't in %s", path, p.Name)
+	}
+	p.Members = slices.DeleteFunc(p.Members, func(member string) bool { return member == path })
+	return fmt.Sprintf("removed %s", path), nil
+}
+
+// LongRest gives every member a long rest, describing how each went.
+// A member who can't rest, e.g. because they're dead, doesn't stop the others.
+func LongRest(members []Member) []string {
+	results := make([]string, len(members))
+	for i, m := range members {
+		summary, err := m.State.LongRest()
+		if err != nil {
+			results[i] = fmt.Sprintf("✗ %s: %v", m.State.Character, err)
+			continue
+		}
+		results[i] = fmt.Sprintf("✓ %s %s", m.State.Character, summary)
+	}
+	return results
+}
+
+// canHeal reports whether the character can restore hit points: a healing spell,
+// Lay on Hands, the Healer feat or a healer's kit
+func canHeal(c *character.Character) bool {
+	if strings.EqualFold(c.Class.Name, "paladin") || slices.ContainsFunc(c.Feats, func(feat string) bool {
+		return strings.EqualFold(feat, "healer")
+	}) {
+		return true
+	}
+	for _, item := range c.Inventory.Items {
+		if strings.EqualFold(item.Index, "healers-kit") {
+			return true
+		}
+	}
+	for _, level := range c.Spells {
+		for _, spell := range level {
+			desc := strings.ToLower(strings.Join(spell.Desc, " "))
+			if strings.Contains(desc, "regain") && strings.Contains(desc, "hit points") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package party_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/party"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/race"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// member makes a party member at full HP from a level 5 character of a class
+func member(name, className string, hp int) party.Member {
+	c := &character.Character{
+		Name:  name,
+		Level: 5,
+		Race:  race.Race{Speed: 30, Languages: []reference.Reference{{Name: "Common"}}},
+		Class: class.Class{Name: className, HitDie: 8},
+		Stats: stats.Stats{HP: hp, AC: 10, Speed: 30},
+	}
+	return party.Member{Character: c, State: play.NewState(c)}
+}
+
+func TestParty_AddAndRemove(t *testing.T) {
+	p := &party.Party{Name: "tuesday"}
+
+	summary, err := p.Add("characters/leki.json")
+	require.NoError(t, err)
+	assert.Equal(t, "added characters/leki.json", summary)
+
+	_, err = p.Add("characters/leki.json")
+	assert.ErrorContains(t, err, "already in tuesday")
+
+	summary, err = p.Remove("characters/leki.json")
+	require.NoError(t, err)
+	assert.Equal(t, "removed characters/leki.json", summary)
+	assert.Empty(t, p.Members)
+
+	_, err = p.Remove("characters/leki.json")
+	assert.ErrorContains(t, err, "isn't in tuesday")
+}
+
+func TestLongRest(t *testing.T) {
+	leki, bob := member("Leki", "Cleric", 30), member("Bob", "Fighter", 40)
+	_, err := leki.State.Damage(12)
+	require.NoError(t, err)
+	bob.State.HP, bob.State.DeathSaves.Failures = 0, 3
+
+	results := party.LongRest([]party.Member{leki, bob})
+
+	assert.Equal(t, []string{"✓ Leki took a long rest", "✗ Bob: Bob is dead"}, results)
+	assert.Equal(t, 30, leki.State.HP)
+}
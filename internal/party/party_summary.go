@@ -0,0 +1,111 @@
+package party
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Row is a party member's line of the summary table
+type Row struct {
+	Name              string
+	Class             string // Class and level, e.g. "Cleric 5"
+	AC                int
+	HP, MaxHP         int
+	PassivePerception int
+	Speed             int
+	SpellSaveDC       int // 0 for characters who don't cast spells
+	Languages         []string
+}
+
+// NewRow summarizes a member from their character and current play state
+func NewRow(m Member) Row {
+	c := m.Character
+	row := Row{
+		Name:              c.Name,
+		Class:             fmt.Sprintf("%s %d", c.Class.Name, c.Level),
+		AC:                c.ArmorClass(),
+		HP:                m.State.HP,
+		MaxHP:             m.State.EffectiveMaxHP(),
+		PassivePerception: c.PassivePerception(),
+		Speed:             m.State.CurrentSpeed(),
+		Languages:         c.Languages(),
+	}
+	if dc, err := c.SpellSaveDC(); err == nil {
+		row.SpellSaveDC = dc
+	}
+	return row
+}
+
+// Gaps lists what no one in the party covers, e.g. Thieves' Tools or healing
+func Gaps(members []Member) []string {
+	var thief, healer, perceptive bool
+	var shared []string
+	for i, m := range members {
+		c := m.Character
+		thief = thief || c.HasProficiency("Thieves' Tools")
+		healer = healer || canHeal(c)
+		perceptive = perceptive || c.Skills.Perception.Proficient
+
+		if i == 0 {
+			shared = c.Languages()
+			continue
+		}
+		shared = slices.DeleteFunc(shared, func(language string) bool {
+			return !slices.Contains(c.Languages(), language)
+		})
+	}
+
+	var gaps []string
+	if !thief {
+		gaps = append(gaps, "No one is proficient with Thieves' Tools")
+	}
+	if !healer {
+		gaps = append(gaps, "No healer: no healing spells, Lay on Hands, Healer feat or healer's kit")
+	}
+	if !perceptive {
+		gaps = append(gaps, "No one is proficient in Perception")
+	}
+	if len(members) > 1 && len(shared) == 0 {
+		gaps = append(gaps, "No language everyone speaks")
+	}
+	return gaps
+}
+
+// Summary renders the party as a table of its members followed by its coverage gaps
+func Summary(name string, members []Member) string {
+	var sb strings.Builder
+	plural := "s"
+	if len(members) == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(&sb, "%s (%d member%s)\n", name, len(members), plural)
+
+	rows := make([]Row, len(members))
+	nameWidth, classWidth := len("Name"), len("Class")
+	for i, m := range members {
+		rows[i] = NewRow(m)
+		nameWidth = max(nameWidth, len(rows[i].Name))
+		classWidth = max(classWidth, len(rows[i].Class))
+	}
+
+	line := "%-*s  %-*s  %3s  %7s  %3s  %5s  %7s  %s\n"
+	fmt.Fprintf(&sb, line, nameWidth, "Name", classWidth, "Class", "AC", "HP", "PP", "Speed", "Save DC", "Languages")
+	for _, row := range rows {
+		dc := "—"
+		if row.SpellSaveDC > 0 {
+			dc = fmt.Sprintf("%d", row.SpellSaveDC)
+		}
+		fmt.Fprintf(&sb, line, nameWidth, row.Name, classWidth, row.Class,
+			fmt.Sprintf("%d", row.AC), fmt.Sprintf("%d/%d", row.HP, row.MaxHP), fmt.Sprintf("%d", row.PassivePerception),
+			fmt.Sprintf("%d", row.Speed), dc, strings.Join(row.Languages, ", "))
+	}
+
+	if gaps := Gaps(members); len(gaps) > 0 {
+		sb.WriteString("\nGaps:\n")
+		for _, gap := range gaps {
+			fmt.Fprintf(&sb, "  - %s\n", gap)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,71 @@
+package party_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/party"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRow(t *testing.T) {
+	leki := member("Leki", "Cleric", 30)
+	leki.Character.AbilityScores.Dexterity = 14
+	leki.Character.AbilityScores.Wisdom = 16
+	leki.Character.Skills.Perception.Bonus = 6
+	leki.Character.Class.Spellcasting.SpellcastingAbility = reference.Reference{Index: "wis", Name: "WIS"}
+	_, err := leki.State.Damage(5)
+	require.NoError(t, err)
+
+	row := party.NewRow(leki)
+
+	assert.Equal(t, party.Row{
+		Name: "Leki", Class: "Cleric 5", AC: 12, HP: 25, MaxHP: 30, PassivePerception: 16,
+		Speed: 30, SpellSaveDC: 14, Languages: []string{"Common"},
+	}, row)
+}
+
+func TestGaps(t *testing.T) {
+	fighter := member("Bob", "Fighter", 40)
+	elf := member("Ana", "Wizard", 20)
+	elf.Character.Race.Languages = []reference.Reference{{Name: "Elvish"}}
+
+	t.Run("flags everything no one covers", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"No one is proficient with Thieves' Tools",
+			"No healer: no healing spells, Lay on Hands, Healer feat or healer's kit",
+			"No one is proficient in Perception",
+			"No language everyone speaks",
+		}, party.Gaps([]party.Member{fighter, elf}))
+	})
+
+	t.Run("a rogue, a healing spell and a perceptive member close the gaps", func(t *testing.T) {
+		rogue := member("Vex", "Rogue", 30)
+		rogue.Character.Class.Proficiencies = []reference.Reference{{Index: "thieves-tools", Name: "Thieves' Tools"}}
+		rogue.Character.Skills.Perception.Proficient = true
+		cleric := member("Leki", "Cleric", 30)
+		cleric.Character.Spells = [][]spells.Spell{nil, {{Name: "Cure Wounds", Desc: []string{
+			"A creature you touch regains a number of hit points equal to 1d8 + your spellcasting ability modifier.",
+		}}}}
+
+		assert.Empty(t, party.Gaps([]party.Member{fighter, rogue, cleric}))
+	})
+
+	t.Run("a paladin counts as a healer", func(t *testing.T) {
+		gaps := party.Gaps([]party.Member{member("Sir Roe", "Paladin", 44)})
+		assert.NotContains(t, gaps, "No healer: no healing spells, Lay on Hands, Healer feat or healer's kit")
+	})
+}
+
+func TestSummary(t *testing.T) {
+	leki, bob := member("Leki", "Cleric", 30), member("Bob", "Fighter", 40)
+
+	summary := party.Summary("tuesday", []party.Member{leki, bob})
+
+	assert.Contains(t, summary, "tuesday (2 members)")
+	assert.Contains(t, summary, "Name  Class       AC       HP   PP  Speed  Save DC  Languages")
+	assert.Contains(t, summary, "Leki  Cleric 5     5    30/30   10     30        —  Common")
+	assert.Contains(t, summary, "Gaps:\n  - No one is proficient with Thieves' Tools")
+}
@@ -0,0 +1,80 @@
+package play
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+)
+
+// CompanionPool tracks the hit points of one of the character's companions
+type CompanionPool struct {
+	Name  string `json:"name"`
+	HP    int    `json:"hp"`
+	MaxHP int    `json:"max_hp"`
+}
+
+// syncCompanions rebuilds the companion pools from the character.
+// Current HP moves with max HP, e.g. when a ranger's level raises their companion's maximum.
+func (s *State) syncCompanions(c *character.Character) {
+	var companions []CompanionPool
+	for i := range c.Companions {
+		pool := CompanionPool{Name: c.Companions[i].Name, MaxHP: c.Companions[i].MaxHP(c)}
+		pool.HP = pool.MaxHP
+		if old, ok := s.companion(pool.Name); ok {
+			pool.HP = max(0, min(pool.MaxHP, old.HP+pool.MaxHP-old.MaxHP))
+		}
+		companions = append(companions, pool)
+	}
+	s.Companions = companions
+}
+
+// companion returns the pool for a companion by name, ignoring case
+func (s *State) companion(name string) (*CompanionPool, bool) {
+	for i := range s.Companions {
+		if strings.EqualFold(s.Companions[i].Name, strings.TrimSpace(name)) {
+			return &s.Companions[i], true
+		}
+	}
+	return nil, false
+}
+
+// DamageCompanion removes hit points from a companion
+func (s *State) DamageCompanion(name string, amount int) (string, error) {
+	if amount < 0 {
+		return "", fmt.Errorf("damage can't be negative")
+	}
+	pool, ok := s.companion(name)
+	if !ok {
+		return "", fmt.Errorf("%s has no companion named %q", s.Character, name)
+	}
+	before := s.Tracker.clone()
+
+	pool.HP = max(0, pool.HP-amount)
+
+	summary := fmt.Sprintf("— %s took %d damage (%d/%d HP)", pool.Name, amount, pool.HP, pool.MaxHP)
+	if pool.HP == 0 {
+		summary += fmt.Sprintf(", %s is down", pool.Name)
+	}
+	s.record("companion", summary, before)
+	return summary, nil
+}
+
+// HealCompanion restores a companion's hit points up to its maximum
+func (s *State) HealCompanion(name string, amount int) (string, error) {
+	if amount < 0 {
+		return "", fmt.Errorf("healing can't be negative")
+	}
+	pool, ok := s.companion(name)
+	if !ok {
+		return "", fmt.Errorf("%s has no companion named %q", s.Character, name)
+	}
+	before := s.Tracker.clone()
+
+	healed := min(amount, pool.MaxHP-pool.HP)
+	pool.HP += healed
+
+	summary := fmt.Sprintf("— %s healed %d HP (%d/%d HP)", pool.Name, healed, pool.HP, pool.MaxHP)
+	s.record("companion", summary, before)
+	return summary, nil
+}
@@ -0,0 +1,66 @@
+package play_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// beastMaster makes the suite's character a level 5 Beast Master ranger with a wolf companion
+func (suite *StateTestSuite) beastMaster() {
+	suite.char.Class = class.Class{Name: "Ranger", HitDie: 10}
+	suite.char.Subclass = "Beast Master"
+	comp, err := character.NewCompanion(suite.char, *wolf(), "Trinket", character.CompanionBeast)
+	require.NoError(suite.T(), err)
+	suite.char.Companions = []character.Companion{
+		comp,
+		{Name: "Owl", Kind: character.CompanionSummon, Monster: monster.Monster{HitPoints: 1}},
+	}
+	suite.state.Sync(suite.char)
+}
+
+func (suite *StateTestSuite) TestCompanions_DamageAndHeal() {
+	suite.beastMaster()
+	assert.Contains(suite.T(), suite.state.String(), "Companions: Trinket 20/20 HP, Owl 1/1 HP")
+
+	summary, err := suite.state.DamageCompanion("trinket", 8)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "— Trinket took 8 damage (12/20 HP)", summary)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+
+	summary, err = suite.state.HealCompanion("Trinket", 10)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "— Trinket healed 8 HP (20/20 HP)", summary)
+
+	summary, err = suite.state.DamageCompanion("owl", 3)
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), summary, "Owl is down")
+
+	_, err = suite.state.DamageCompanion("Sparky", 1)
+	assert.Error(suite.T(), err)
+	_, err = suite.state.HealCompanion("Trinket", -1)
+	assert.Error(suite.T(), err)
+
+	_, err = suite.state.LongRest()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, suite.state.Companions[1].HP)
+}
+
+func (suite *StateTestSuite) TestCompanions_SyncFollowsOwnerLevel() {
+	suite.beastMaster()
+	_, _ = suite.state.DamageCompanion("Trinket", 5)
+
+	// At level 6 the companion's maximum is 24 and current HP rises with it
+	suite.char.Level = 6
+	suite.state.Sync(suite.char)
+
+	assert.Equal(suite.T(), 24, suite.state.Companions[0].MaxHP)
+	assert.Equal(suite.T(), 19, suite.state.Companions[0].HP)
+
+	// Dismissed companions are no longer tracked
+	suite.char.Companions = suite.char.Companions[:1]
+	suite.state.Sync(suite.char)
+	assert.Len(suite.T(), suite.state.Companions, 1)
+}
@@ -0,0 +1,192 @@
+package play
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+)
+
+// ConcentrationDC is the DC of the Constitution save to keep concentrating after taking damage:
+// 10 or half the damage, whichever is higher
+func ConcentrationDC(damage int) int {
+	return max(10, damage/2)
+}
+
+// Concentrate starts concentrating on a spell, ending concentration on any other spell
+func (s *State) Concentrate(spell string) (string, error) {
+	if spell == "" {
+		return "", fmt.Errorf("name the spell %s is concentrating on", s.Character)
+	}
+	if s.Effects().Incapacitated {
+		return "", fmt.Errorf("%s is incapacitated and can't concentrate", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	summary := fmt.Sprintf("is concentrating on %s", spell)
+	if s.Concentration != "" {
+		summary = fmt.Sprintf("stopped concentrating on %s and %s", s.Concentration, summary)
+	}
+	s.Concentration = spell
+	s.ConcentrationSaves = nil
+	s.record("concentration", summary, before)
+	return summary, nil
+}
+
+// EndConcentration ends concentration on the current spell
+func (s *State) EndConcentration() (string, error) {
+	if s.Concentration == "" {
+		return "", fmt.Errorf("%s isn't concentrating on a spell", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	summary := fmt.Sprintf("stopped concentrating on %s", s.Concentration)
+	s.loseConcentration()
+	s.record("concentration", summary, before)
+	return summary, nil
+}
+
+// loseConcentration ends concentration along with any saves still owed for it
+func (s *State) loseConcentration() {
+	s.Concentration = ""
+	s.ConcentrationSaves = nil
+}
+
+// concentrationAfterDamage ends concentration when the damage drops the character to 0 HP,
+// or owes a Constitution save against it, describing which
+func (s *State) concentrationAfterDamage(amount int) string {
+	spell := s.Concentration
+	switch {
+	case spell == "" || amount == 0:
+		return ""
+	case s.HP == 0:
+		s.loseConcentration()
+		return fmt.Sprintf(", lost concentration on %s", spell)
+	}
+	dc := ConcentrationDC(amount)
+	s.ConcentrationSaves = append(s.ConcentrationSaves, dc)
+	return fmt.Sprintf(", DC %d Constitution save to keep concentrating on %s", dc, spell)
+}
+
+// concentrationAfterCondition ends concentration when a condition incapacitates the character
+func (s *State) concentrationAfterCondition() string {
+	spell := s.Concentration
+	if spell == "" || !s.Effects().Incapacitated {
+		return ""
+	}
+	s.loseConcentration()
+	return fmt.Sprintf(" and lost concentration on %s", spell)
+}
+
+// Cast casts a known or prepared spell, spending a slot of the spell's level unless slot is higher.
+// A concentration spell replaces whatever the character was concentrating on.
+func (s *State) Cast(c *character.Character, name string, slot int) (string, error) {
+	return s.cast(c, name, slot, false)
+}
+
+// CastRitual casts a ritual spell without spending a slot, if the character's class can cast it as a ritual
+func (s *State) CastRitual(c *character.Character, name string) (string, error) {
+	return s.cast(c, name, 0, true)
+}
+
+// cast checks the character can cast the spell now, then spends the slot and starts concentrating
+func (s *State) cast(c *character.Character, name string, slot int, ritual bool) (string, error) {
+	spell, err := c.FindSpell(name)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case s.Effects().Incapacitated:
+		return "", fmt.Errorf("%s is incapacitated and can't cast spells", s.Character)
+	case s.WildShape != nil:
+		return "", fmt.Errorf("%s can't cast spells while in Wild Shape", s.Character)
+	}
+	c = s.WithSpent(s.WithPrepared(c))
+	if ritual && !c.CanRitualCast(spell) {
+		return "", fmt.Errorf("%s can't cast %s as a ritual", s.Character, spell.Name)
+	}
+	if err := c.CanCast(spell); err != nil && !ritual {
+		return "", err
+	}
+	if err := c.MissingCostlyMaterial(spell); err != nil {
+		return "", err
+	}
+
+	before := s.Tracker.clone()
+	summary := fmt.Sprintf("cast %s", spell.Name)
+
+	switch {
+	case ritual:
+		summary += " as a ritual"
+	case spell.Level > 0:
+		if slot == 0 {
+			slot = spell.Level
+		}
+		if slot < spell.Level {
+			return "", fmt.Errorf("%s is level %d and can't be cast with a level %d slot", spell.Name, spell.Level, slot)
+		}
+		pool, ok := s.slot(slot)
+		if !ok || pool.Used >= pool.Max {
+			return "", fmt.Errorf("%s has no level %d spell slots left", s.Character, slot)
+		}
+		pool.Used++
+		summary += fmt.Sprintf(" with a level %d slot (%d/%d left)", slot, pool.Max-pool.Used, pool.Max)
+	}
+
+	// Consumed components are gone once the spell is cast, e.g. Revivify's diamond
+	if consumed := c.ConsumedMaterials(spell); len(consumed) > 0 {
+		names := make([]string, len(consumed))
+		for i, valuable := range consumed {
+			names[i] = valuable.String()
+		}
+		s.Spent = append(s.Spent, consumed...)
+		summary += ", consuming " + strings.Join(names, ", ")
+	}
+
+	if spell.Concentration {
+		if s.Concentration != "" {
+			summary += fmt.Sprintf(", ending concentration on %s", s.Concentration)
+		}
+		s.Concentration = spell.Name
+		s.ConcentrationSaves = nil
+		summary += fmt.Sprintf(", concentrating on %s", spell.Name)
+	}
+
+	s.record("cast", summary, before)
+	return summary, nil
+}
+
+// ConcentrationSave rolls the oldest Constitution save owed to keep concentrating.
+// A failure ends concentration and the saves owed for it. The War Caster feat grants advantage.
+func (s *State) ConcentrationSave(c *character.Character, roller *dice.Roller) (string, error) {
+	if len(s.ConcentrationSaves) == 0 {
+		return "", fmt.Errorf("%s doesn't owe a concentration save", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	check := c.SavingThrow(core.Constitution)
+	if slices.ContainsFunc(c.Feats, func(feat string) bool { return strings.EqualFold(feat, "war caster") }) {
+		check.Advantage = true
+	}
+	s.ApplyEffects(&check)
+	result, err := c.RollCheck(check, roller)
+	if err != nil {
+		return "", err
+	}
+
+	dc, spell := s.ConcentrationSaves[0], s.Concentration
+	s.ConcentrationSaves = s.ConcentrationSaves[1:]
+
+	summary := fmt.Sprintf("rolled %d on a DC %d Constitution save", result.Roll.Total, dc)
+	if result.Roll.Total >= dc {
+		summary += fmt.Sprintf(" and kept concentrating on %s", spell)
+	} else {
+		s.loseConcentration()
+		summary += fmt.Sprintf(" and lost concentration on %s", spell)
+	}
+	s.record("concentration", summary, before)
+	return summary, nil
+}
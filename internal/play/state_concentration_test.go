@@ -0,0 +1,165 @@
+package play_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// knowSpells gives the suite's cleric a cantrip and two concentration spells
+func (suite *StateTestSuite) knowSpells() {
+	suite.char.Spells = [][]spells.Spell{
+		{{Index: "sacred-flame", Name: "Sacred Flame"}},
+		{{Index: "bless", Name: "Bless", Level: 1, Concentration: true}},
+		nil,
+		{{Index: "spirit-guardians", Name: "Spirit Guardians", Level: 3, Concentration: true}},
+	}
+}
+
+func TestConcentrationDC(t *testing.T) {
+	assert.Equal(t, 10, play.ConcentrationDC(7))
+	assert.Equal(t, 10, play.ConcentrationDC(21))
+	assert.Equal(t, 11, play.ConcentrationDC(22))
+}
+
+func (suite *StateTestSuite) TestConcentration() {
+	_, err := suite.state.EndConcentration()
+	assert.EqualError(suite.T(), err, "Leki isn't concentrating on a spell")
+
+	summary, err := suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "is concentrating on Bless", summary)
+	assert.Contains(suite.T(), suite.state.String(), "Concentrating on: Bless")
+
+	summary, err = suite.state.Concentrate("Spirit Guardians")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "stopped concentrating on Bless and is concentrating on Spirit Guardians", summary)
+
+	summary, err = suite.state.EndConcentration()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "stopped concentrating on Spirit Guardians", summary)
+	assert.Empty(suite.T(), suite.state.Concentration)
+
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Spirit Guardians", suite.state.Concentration)
+}
+
+func (suite *StateTestSuite) TestCast() {
+	suite.knowSpells()
+
+	summary, err := suite.state.Cast(suite.char, "sacred flame", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Sacred Flame", summary)
+
+	summary, err = suite.state.Cast(suite.char, "bless", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Bless with a level 1 slot (3/4 left), concentrating on Bless", summary)
+
+	summary, err = suite.state.Cast(suite.char, "Spirit Guardians", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Spirit Guardians with a level 3 slot (1/2 left), ending concentration on Bless, concentrating on Spirit Guardians", summary)
+	assert.Equal(suite.T(), "Spirit Guardians", suite.state.Concentration)
+
+	_, err = suite.state.Cast(suite.char, "spirit guardians", 2)
+	assert.EqualError(suite.T(), err, "Spirit Guardians is level 3 and can't be cast with a level 2 slot")
+	_, err = suite.state.Cast(suite.char, "bless", 4)
+	assert.EqualError(suite.T(), err, "Leki has no level 4 spell slots left")
+	_, err = suite.state.Cast(suite.char, "wish", 0)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestCast_Incapacitated() {
+	suite.knowSpells()
+	suite.state.Conditions = []string{"Stunned"}
+
+	_, err := suite.state.Cast(suite.char, "bless", 0)
+
+	assert.EqualError(suite.T(), err, "Leki is incapacitated and can't cast spells")
+}
+
+func (suite *StateTestSuite) TestDamage_OwesConcentrationSave() {
+	_, err := suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+
+	summary, err := suite.state.Damage(24)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "took 24 damage, DC 12 Constitution save to keep concentrating on Bless", summary)
+
+	summary, err = suite.state.Damage(3)
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), summary, "DC 10 Constitution save")
+	assert.Equal(suite.T(), []int{12, 10}, suite.state.ConcentrationSaves)
+	assert.Contains(suite.T(), suite.state.String(), "Concentrating on: Bless (DC 12 save owed) (DC 10 save owed)")
+}
+
+func (suite *StateTestSuite) TestDamage_DroppingToZeroEndsConcentration() {
+	_, err := suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+
+	summary, err := suite.state.Damage(40)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "took 40 damage, down to 0 HP, lost concentration on Bless", summary)
+	assert.Empty(suite.T(), suite.state.Concentration)
+	assert.Empty(suite.T(), suite.state.ConcentrationSaves)
+}
+
+func (suite *StateTestSuite) TestAddCondition_IncapacitationEndsConcentration() {
+	_, err := suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+
+	summary, err := suite.state.AddCondition(conditions.Condition{Name: "Poisoned"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "is now poisoned", summary)
+
+	summary, err = suite.state.AddCondition(conditions.Condition{Name: "Paralyzed"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "is now paralyzed and lost concentration on Bless", summary)
+	assert.Empty(suite.T(), suite.state.Concentration)
+
+	_, err = suite.state.Concentrate("Bless")
+	assert.EqualError(suite.T(), err, "Leki is incapacitated and can't concentrate")
+}
+
+func (suite *StateTestSuite) TestConcentrationSave() {
+	_, err := suite.state.ConcentrationSave(suite.char, dice.NewRoller(1))
+	assert.EqualError(suite.T(), err, "Leki doesn't owe a concentration save")
+
+	_, err = suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+	_, err = suite.state.Damage(5)
+	require.NoError(suite.T(), err)
+	_, err = suite.state.Damage(5)
+	require.NoError(suite.T(), err)
+
+	// Seed 4 rolls a 15 and seed 2 a 4 on the d20
+	summary, err := suite.state.ConcentrationSave(suite.char, dice.NewRoller(4))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "rolled 15 on a DC 10 Constitution save and kept concentrating on Bless", summary)
+	assert.Equal(suite.T(), []int{10}, suite.state.ConcentrationSaves)
+
+	summary, err = suite.state.ConcentrationSave(suite.char, dice.NewRoller(2))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "rolled 4 on a DC 10 Constitution save and lost concentration on Bless", summary)
+	assert.Empty(suite.T(), suite.state.Concentration)
+}
+
+func (suite *StateTestSuite) TestConcentrationSave_WarCasterAdvantage() {
+	suite.char.Feats = []string{"War Caster"}
+	_, err := suite.state.Concentrate("Bless")
+	require.NoError(suite.T(), err)
+	_, err = suite.state.Damage(5)
+	require.NoError(suite.T(), err)
+
+	// Seed 2 rolls a 4 first, advantage keeps the second die
+	summary, err := suite.state.ConcentrationSave(suite.char, dice.NewRoller(2))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "rolled 20 on a DC 10 Constitution save and kept concentrating on Bless", summary)
+}
@@ -0,0 +1,261 @@
+package play
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+)
+
+// DeathSaves tracks death saving throws while the character is at 0 HP
+type DeathSaves struct {
+	Successes int  `json:"successes"`
+	Failures  int  `json:"failures"`
+	Stable    bool `json:"stable,omitempty"`
+}
+
+// Dead reports whether the character failed three death saves or reached exhaustion 6
+func (s *State) Dead() bool {
+	return s.DeathSaves.Failures >= 3 || s.Exhaustion >= 6
+}
+
+// source is a named cause of effects: a condition or the current exhaustion level
+type source struct {
+	Name    string
+	Effects conditions.Effects
+}
+
+// sources lists everything currently affecting the character
+func (s *State) sources() []source {
+	var sources []source
+	for _, name := range s.Conditions {
+		sources = append(sources, source{Name: name, Effects: conditions.EffectsOf(name)})
+	}
+	if s.Exhaustion > 0 {
+		sources = append(sources, source{Name: fmt.Sprintf("Exhaustion %d", s.Exhaustion), Effects: conditions.ExhaustionEffects(s.Exhaustion)})
+	}
+	return sources
+}
+
+// Effects combines the effects of every condition and the exhaustion level
+func (s *State) Effects() conditions.Effects {
+	var effects conditions.Effects
+	for _, src := range s.sources() {
+		effects = effects.Combine(src.Effects)
+	}
+	return effects
+}
+
+// EffectiveMaxHP is max HP after exhaustion, which halves it from level 4
+func (s *State) EffectiveMaxHP() int {
+	if s.Effects().HPMaxHalved {
+		return s.MaxHP / 2
+	}
+	return s.MaxHP
+}
+
+// BaseSpeed is the walking speed before conditions, the beast's while in Wild Shape
+func (s *State) BaseSpeed() int {
+	if s.WildShape != nil {
+		return s.WildShape.Speed
+	}
+	return s.Speed
+}
+
+// CurrentSpeed is the walking speed after conditions and exhaustion
+func (s *State) CurrentSpeed() int {
+	effects := s.Effects()
+	switch {
+	case effects.SpeedZero:
+		return 0
+	case effects.SpeedHalved:
+		return s.BaseSpeed() / 2
+	default:
+		return s.BaseSpeed()
+	}
+}
+
+// hasCondition returns the index of a condition, ignoring case
+func (s *State) hasCondition(name string) int {
+	return slices.IndexFunc(s.Conditions, func(c string) bool { return strings.EqualFold(c, name) })
+}
+
+// AddCondition applies a fetched condition
+func (s *State) AddCondition(condition conditions.Condition) (string, error) {
+	if s.hasCondition(condition.Name) >= 0 {
+		return "", fmt.Errorf("%s is already %s", s.Character, strings.ToLower(condition.Name))
+	}
+	before := s.Tracker.clone()
+
+	s.Conditions = append(s.Conditions, condition.Name)
+
+	summary := fmt.Sprintf("is now %s", strings.ToLower(condition.Name)) + s.concentrationAfterCondition()
+	s.record("condition", summary, before)
+	return summary, nil
+}
+
+// RemoveCondition removes a condition by name
+func (s *State) RemoveCondition(name string) (string, error) {
+	i := s.hasCondition(name)
+	if i < 0 {
+		return "", fmt.Errorf("%s is not %s", s.Character, strings.ToLower(name))
+	}
+	before := s.Tracker.clone()
+
+	removed := s.Conditions[i]
+	s.Conditions = slices.Delete(s.Conditions, i, i+1)
+
+	summary := fmt.Sprintf("is no longer %s", strings.ToLower(removed))
+	s.record("condition", summary, before)
+	return summary, nil
+}
+
+// SetExhaustion sets the exhaustion level (0-6), capping HP when max HP is halved
+func (s *State) SetExhaustion(level int) (string, error) {
+	if level < 0 || level > 6 {
+		return "", fmt.Errorf("exhaustion must be between 0 and 6, got %d", level)
+	}
+	before := s.Tracker.clone()
+
+	s.Exhaustion = level
+	s.HP = min(s.HP, s.EffectiveMaxHP())
+
+	summary := fmt.Sprintf("is at exhaustion level %d", level)
+	if s.Dead() {
+		s.loseConcentration()
+		summary += " and dies"
+	}
+	s.record("exhaustion", summary, before)
+	return summary, nil
+}
+
+// DeathSave rolls a death saving throw: 10 or higher succeeds, a natural 1 counts as two failures
+// and a natural 20 brings the character back with 1 HP. Three successes stabilize the character.
+func (s *State) DeathSave(roller *dice.Roller) (string, error) {
+	switch {
+	case s.Dead():
+		return "", fmt.Errorf("%s is dead", s.Character)
+	case s.HP > 0:
+		return "", fmt.Errorf("%s isn't dying", s.Character)
+	case s.DeathSaves.Stable:
+		return "", fmt.Errorf("%s is stable", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	roll := roller.Roll(20, "death save")
+	summary := fmt.Sprintf("rolled a %d on a death save", roll)
+
+	switch {
+	case roll == 20:
+		s.HP = 1
+		s.DeathSaves = DeathSaves{}
+		summary += ", regaining 1 HP"
+	case roll == 1:
+		s.DeathSaves.Failures = min(3, s.DeathSaves.Failures+2)
+		summary += fmt.Sprintf(", two failures (%d/3)", s.DeathSaves.Failures)
+	case roll >= 10:
+		s.DeathSaves.Successes++
+		summary += fmt.Sprintf(", a success (%d/3)", s.DeathSaves.Successes)
+	default:
+		s.DeathSaves.Failures++
+		summary += fmt.Sprintf(", a failure (%d/3)", s.DeathSaves.Failures)
+	}
+
+	if s.DeathSaves.Successes >= 3 {
+		s.DeathSaves.Stable = true
+		summary += " and is stable"
+	}
+	if s.Dead() {
+		summary += " and dies"
+	}
+
+	s.record("death-save", summary, before)
+	return summary, nil
+}
+
+// Stabilize makes a dying character stable, e.g. from a Medicine check or Spare the Dying
+func (s *State) Stabilize() (string, error) {
+	if s.Dead() {
+		return "", fmt.Errorf("%s is dead", s.Character)
+	}
+	if s.HP > 0 {
+		return "", fmt.Errorf("%s isn't dying", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	s.DeathSaves = DeathSaves{Stable: true}
+
+	summary := "is stable"
+	s.record("stabilize", summary, before)
+	return summary, nil
+}
+
+// ApplyEffects adds disadvantage, advantage, automatic failures and notes from conditions and exhaustion to a check
+func (s *State) ApplyEffects(check *character.Check) {
+	if !check.HasD20 {
+		return
+	}
+
+	ability, err := core.ParseAbility(check.Name)
+	isStrDexSave := check.Kind == "save" && err == nil && (ability == core.Strength || ability == core.Dexterity)
+
+	for _, src := range s.sources() {
+		e := src.Effects
+		switch check.Kind {
+		case "skill":
+			if e.CheckDisadvantage {
+				check.Disadvantage = true
+				check.Notes = append(check.Notes, src.Name+" imposes disadvantage on ability checks")
+			}
+		case "save":
+			if e.AutoFailStrDexSaves && isStrDexSave {
+				check.AutoFail = true
+				check.Notes = append(check.Notes, src.Name+": automatically fails Strength and Dexterity saves")
+			}
+			if e.SaveDisadvantage || (e.DexSaveDisadvantage && err == nil && ability == core.Dexterity) {
+				check.Disadvantage = true
+				check.Notes = append(check.Notes, src.Name+" imposes disadvantage on this save")
+			}
+		case "attack", "cast":
+			if e.AttackDisadvantage {
+				check.Disadvantage = true
+				check.Notes = append(check.Notes, src.Name+" imposes disadvantage on attack rolls")
+			}
+			if e.AttackAdvantage {
+				check.Advantage = true
+				check.Notes = append(check.Notes, src.Name+" grants advantage on attack rolls")
+			}
+		}
+		if e.Incapacitated && check.Kind != "save" {
+			check.Notes = append(check.Notes, src.Name+": incapacitated, can't take actions")
+		}
+	}
+}
+
+// writeConditions adds conditions, exhaustion, speed and death saves to the state's summary
+func (s *State) writeConditions(sb *strings.Builder) {
+	if s.Dead() {
+		sb.WriteString("\nDEAD")
+		return
+	}
+	if s.HP == 0 {
+		if s.DeathSaves.Stable {
+			sb.WriteString("\nUnconscious and stable")
+		} else {
+			fmt.Fprintf(sb, "\nDying: death saves %d successes, %d failures", s.DeathSaves.Successes, s.DeathSaves.Failures)
+		}
+	}
+	if len(s.Conditions) > 0 {
+		fmt.Fprintf(sb, "\nConditions: %s", strings.Join(s.Conditions, ", "))
+	}
+	if s.Exhaustion > 0 {
+		fmt.Fprintf(sb, "\nExhaustion: %d", s.Exhaustion)
+	}
+	if speed := s.CurrentSpeed(); speed != s.BaseSpeed() {
+		fmt.Fprintf(sb, "\nSpeed: %d (base %d)", speed, s.BaseSpeed())
+	}
+}
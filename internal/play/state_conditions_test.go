@@ -0,0 +1,226 @@
+package play_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/conditions"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *StateTestSuite) TestConditions_AddAndRemove() {
+	_, err := suite.state.AddCondition(conditions.Condition{Index: "poisoned", Name: "Poisoned"})
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.AddCondition(conditions.Condition{Name: "Poisoned"})
+	assert.ErrorContains(suite.T(), err, "already poisoned")
+	assert.Contains(suite.T(), suite.state.String(), "Conditions: Poisoned")
+
+	summary, err := suite.state.RemoveCondition("poisoned")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "is no longer poisoned", summary)
+	assert.Empty(suite.T(), suite.state.Conditions)
+
+	_, err = suite.state.RemoveCondition("prone")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestConditions_Undo() {
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Prone"})
+
+	_, err := suite.state.Undo()
+
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.state.Conditions)
+}
+
+func (suite *StateTestSuite) TestCurrentSpeed() {
+	suite.state.Speed = 30
+	assert.Equal(suite.T(), 30, suite.state.CurrentSpeed())
+
+	_, _ = suite.state.SetExhaustion(2)
+	assert.Equal(suite.T(), 15, suite.state.CurrentSpeed())
+	assert.Contains(suite.T(), suite.state.String(), "Speed: 15 (base 30)")
+
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Grappled"})
+	assert.Equal(suite.T(), 0, suite.state.CurrentSpeed())
+}
+
+func (suite *StateTestSuite) TestExhaustion_HalvesMaxHP() {
+	_, err := suite.state.SetExhaustion(4)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 19, suite.state.EffectiveMaxHP())
+	assert.Equal(suite.T(), 19, suite.state.HP)
+
+	_, _ = suite.state.Heal(10)
+	assert.Equal(suite.T(), 19, suite.state.HP)
+
+	// A long rest removes one level and restores the full max HP
+	_, err = suite.state.LongRest()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, suite.state.Exhaustion)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+
+	_, err = suite.state.SetExhaustion(7)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestExhaustion_SixIsDeath() {
+	summary, err := suite.state.SetExhaustion(6)
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.state.Dead())
+	assert.Contains(suite.T(), summary, "dies")
+
+	_, err = suite.state.Heal(5)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestDeathSaves() {
+	_, err := suite.state.DeathSave(dice.NewRoller(0))
+	assert.ErrorContains(suite.T(), err, "isn't dying")
+
+	_, _ = suite.state.Damage(38)
+	roller := dice.NewRoller(0)
+
+	// Seed 0 rolls 5, 14 and 7
+	summary, err := suite.state.DeathSave(roller)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "rolled a 5 on a death save, a failure (1/3)", summary)
+	_, _ = suite.state.DeathSave(roller)
+	_, _ = suite.state.DeathSave(roller)
+	assert.Equal(suite.T(), 1, suite.state.DeathSaves.Successes)
+	assert.Equal(suite.T(), 2, suite.state.DeathSaves.Failures)
+
+	// Damage while down is another failure
+	summary, _ = suite.state.Damage(1)
+	assert.Contains(suite.T(), summary, "death save failure")
+	assert.True(suite.T(), suite.state.Dead())
+	assert.Contains(suite.T(), suite.state.String(), "DEAD")
+}
+
+func (suite *StateTestSuite) TestDeathSaves_ThreeSuccessesStabilize() {
+	_, _ = suite.state.Damage(38)
+	roller := dice.NewRoller(9)
+
+	// Seed 9 rolls 18, 16 and 15
+	for range 3 {
+		_, err := suite.state.DeathSave(roller)
+		require.NoError(suite.T(), err)
+	}
+
+	assert.True(suite.T(), suite.state.DeathSaves.Stable)
+	assert.Contains(suite.T(), suite.state.String(), "Unconscious and stable")
+
+	_, err := suite.state.DeathSave(roller)
+	assert.ErrorContains(suite.T(), err, "is stable")
+
+	// Healing resets the death saves
+	_, _ = suite.state.Heal(3)
+	assert.Equal(suite.T(), 0, suite.state.DeathSaves.Successes)
+}
+
+func (suite *StateTestSuite) TestDeathSaves_Natural20And1() {
+	_, _ = suite.state.Damage(38)
+	summary, _ := suite.state.DeathSave(dice.NewRoller(1))
+	assert.Contains(suite.T(), summary, "regaining 1 HP")
+	assert.Equal(suite.T(), 1, suite.state.HP)
+
+	_, _ = suite.state.Damage(1)
+	summary, _ = suite.state.DeathSave(dice.NewRoller(8))
+	assert.Contains(suite.T(), summary, "two failures (2/3)")
+}
+
+func (suite *StateTestSuite) TestStabilize() {
+	_, err := suite.state.Stabilize()
+	assert.Error(suite.T(), err)
+
+	_, _ = suite.state.Damage(38)
+	_, err = suite.state.Stabilize()
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.state.DeathSaves.Stable)
+}
+
+func (suite *StateTestSuite) TestMassiveDamage() {
+	summary, _ := suite.state.Damage(76)
+
+	assert.True(suite.T(), suite.state.Dead())
+	assert.Contains(suite.T(), summary, "massive damage")
+}
+
+func (suite *StateTestSuite) TestApplyEffects() {
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Poisoned"})
+
+	skill := character.Check{Kind: "skill", Name: "Insight", HasD20: true}
+	suite.state.ApplyEffects(&skill)
+	assert.True(suite.T(), skill.Disadvantage)
+	assert.Contains(suite.T(), skill.Notes[0], "Poisoned")
+
+	save := character.Check{Kind: "save", Name: core.Wisdom.String(), HasD20: true}
+	suite.state.ApplyEffects(&save)
+	assert.False(suite.T(), save.Disadvantage)
+
+	// Invisible's advantage cancels Poisoned's disadvantage
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Invisible"})
+	attack := character.Check{Kind: "attack", Name: "Mace", HasD20: true}
+	suite.state.ApplyEffects(&attack)
+	assert.True(suite.T(), attack.Advantage && attack.Disadvantage)
+	assert.Len(suite.T(), attack.Notes, 2)
+
+	// However many sources there are, the roll is normal while any disadvantage remains
+	attack.Advantage = true
+	result, err := attack.Roll(dice.NewRoller(1))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dice.Normal, result.Check.Mode)
+}
+
+func (suite *StateTestSuite) TestApplyEffects_Saves() {
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Restrained"})
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Stunned"})
+
+	dex := character.Check{Kind: "save", Name: core.Dexterity.String(), HasD20: true}
+	suite.state.ApplyEffects(&dex)
+	assert.True(suite.T(), dex.Disadvantage)
+	assert.True(suite.T(), dex.AutoFail)
+	assert.Contains(suite.T(), dex.Notes[1], "automatically fails")
+
+	result, err := dex.Roll(dice.NewRoller(1))
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.Roll)
+	assert.Contains(suite.T(), result.String(), "Roll:   automatic failure")
+
+	wis := character.Check{Kind: "save", Name: core.Wisdom.String(), HasD20: true}
+	suite.state.ApplyEffects(&wis)
+	assert.False(suite.T(), wis.AutoFail)
+
+	// Saves with no d20 aren't touched
+	fireball := character.Check{Kind: "cast", Name: "Fireball"}
+	suite.state.ApplyEffects(&fireball)
+	assert.Empty(suite.T(), fireball.Notes)
+}
+
+func (suite *StateTestSuite) TestTypedDamage() {
+	defenses := damage.Defenses{{DamageType: damage.TypeReference("fire"), Kind: damage.Resistance, Source: "Hellish Resistance"}}
+
+	summary, err := suite.state.TypedDamage(9, "Fire", defenses)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 34, suite.state.HP)
+	assert.Equal(suite.T(), "took 4 fire damage (9, halved by fire resistance from Hellish Resistance)", summary)
+
+	_, _ = suite.state.TypedDamage(9, "cold", defenses)
+	assert.Equal(suite.T(), 25, suite.state.HP)
+}
+
+func (suite *StateTestSuite) TestTypedDamage_PetrifiedResistsEverything() {
+	_, _ = suite.state.AddCondition(conditions.Condition{Name: "Petrified"})
+
+	summary, err := suite.state.TypedDamage(10, "slashing", nil)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 33, suite.state.HP)
+	assert.Contains(suite.T(), summary, "from Petrified")
+}
@@ -0,0 +1,511 @@
+package play
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/damage"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+)
+
+// SlotPool tracks the spell slots of a single slot level
+type SlotPool struct {
+	Level     int  `json:"level"`
+	Max       int  `json:"max"`
+	Used      int  `json:"used"`
+	ShortRest bool `json:"short_rest,omitempty"` // Pact Magic slots come back on a short rest
+}
+
+// ResourcePool tracks the uses of a limited class feature
+type ResourcePool struct {
+	Name      string `json:"name"`
+	Max       int    `json:"max"`
+	Used      int    `json:"used"`
+	ShortRest bool   `json:"short_rest,omitempty"`
+}
+
+// Tracker holds everything that changes during a session
+type Tracker struct {
+	HP         int                  `json:"hp"`
+	TempHP     int                  `json:"temp_hp"`
+	HitDice    []stats.HitDicePool  `json:"hit_dice"`
+	Slots      []SlotPool           `json:"slots,omitempty"`
+	Resources  []ResourcePool       `json:"resources,omitempty"`
+	Supplies   []SupplyPool         `json:"supplies,omitempty"`
+	Companions []CompanionPool      `json:"companions,omitempty"`
+	Conditions []string             `json:"conditions,omitempty"`
+	Exhaustion int                  `json:"exhaustion,omitempty"`
+	DeathSaves DeathSaves           `json:"death_saves"`
+	WildShape  *WildShapeForm       `json:"wild_shape,omitempty"`
+	Prepared   []string             `json:"prepared,omitempty"` // Spells prepared at the last long rest, the character's own when empty
+	Spent      []inventory.Valuable `json:"spent,omitempty"`    // Valuables used up as material components
+
+	Concentration      string `json:"concentration,omitempty"`       // Spell being concentrated on
+	ConcentrationSaves []int  `json:"concentration_saves,omitempty"` // DCs of the Constitution saves owed after taking damage
+}
+
+// clone deep copies the tracker so it can be kept in the undo log
+func (t Tracker) clone() Tracker {
+	t.HitDice = slices.Clone(t.HitDice)
+	t.Slots = slices.Clone(t.Slots)
+	t.Resources = slices.Clone(t.Resources)
+	t.Supplies = slices.Clone(t.Supplies)
+	t.Companions = slices.Clone(t.Companions)
+	t.Conditions = slices.Clone(t.Conditions)
+	t.ConcentrationSaves = slices.Clone(t.ConcentrationSaves)
+	t.Prepared = slices.Clone(t.Prepared)
+	t.Spent = slices.Clone(t.Spent)
+	if t.WildShape != nil {
+		form := *t.WildShape
+		t.WildShape = &form
+	}
+	return t
+}
+
+// Change is an entry of the undo log: what happened and the state before it
+type Change struct {
+	Action  string  `json:"action"`
+	Summary string  `json:"summary"`
+	Before  Tracker `json:"before"`
+}
+
+// State is a character's play state for a session, saved next to the character JSON
+type State struct {
+	Character string `json:"character"`
+	MaxHP     int    `json:"max_hp"`
+	Speed     int    `json:"speed"` // Walking speed after encumbrance, before conditions
+	Tracker
+	History []Change `json:"history,omitempty"`
+}
+
+// NewState starts a fresh play state at full HP with every slot and resource available
+func NewState(c *character.Character) *State {
+	s := &State{Character: c.Name}
+	s.Sync(c)
+	s.HP = s.MaxHP
+	s.TempHP = c.Stats.TempHP
+	return s
+}
+
+// NewMonsterState starts a play state for a monster in combat, e.g. "Goblin 2", at its stat block's HP
+func NewMonsterState(name string, m *monster.Monster) *State {
+	return &State{
+		Character: name,
+		MaxHP:     m.HitPoints,
+		Speed:     m.WalkSpeed(),
+		Tracker:   Tracker{HP: m.HitPoints},
+	}
+}
+
+// Sync updates the maximums from the character, e.g. after a level up.
+// Current HP moves with max HP and used slots, dice and resources are kept.
+func (s *State) Sync(c *character.Character) {
+	s.HP += c.MaxHP() - s.MaxHP
+	s.MaxHP = c.MaxHP()
+	s.Speed = c.Encumbrance().Speed(c.Stats.Speed)
+
+	pools := slices.Clone(c.Stats.HitDice)
+	if len(pools) == 0 && c.Class.HitDie > 0 {
+		// Characters saved without a hit dice pool
+		pools = []stats.HitDicePool{{Die: c.Class.HitDie, Max: c.Level, Remaining: c.Level}}
+	}
+	for i := range pools {
+		pools[i].Remaining = pools[i].Max
+		for _, old := range s.HitDice {
+			if old.Die == pools[i].Die {
+				pools[i].Remaining = min(pools[i].Max, old.Remaining+pools[i].Max-old.Max)
+			}
+		}
+	}
+	s.HitDice = pools
+
+	var slots []SlotPool
+	for i, count := range c.Class.SpellSlots(c.Level) {
+		if count == 0 {
+			continue
+		}
+		pool := SlotPool{Level: i + 1, Max: count, ShortRest: c.Class.PactMagic()}
+		if old, ok := s.slot(pool.Level); ok {
+			pool.Used = min(old.Used, count)
+		}
+		slots = append(slots, pool)
+	}
+	s.Slots = slots
+
+	var resources []ResourcePool
+	for _, r := range c.Class.Resources(c.Level) {
+		pool := ResourcePool{Name: r.Name, Max: r.Max, ShortRest: r.ShortRest}
+		if old, ok := s.resource(r.Name); ok {
+			pool.Used = min(old.Used, r.Max)
+		}
+		resources = append(resources, pool)
+	}
+	s.Resources = resources
+
+	s.syncSupplies(c)
+	s.syncCompanions(c)
+}
+
+// slot returns the pool for a slot level
+func (s *State) slot(level int) (*SlotPool, bool) {
+	for i := range s.Slots {
+		if s.Slots[i].Level == level {
+			return &s.Slots[i], true
+		}
+	}
+	return nil, false
+}
+
+// resource returns the pool for a named resource, ignoring case
+func (s *State) resource(name string) (*ResourcePool, bool) {
+	for i := range s.Resources {
+		if strings.EqualFold(s.Resources[i].Name, name) {
+			return &s.Resources[i], true
+		}
+	}
+	return nil, false
+}
+
+// record adds an entry to the undo log holding the state from before the change
+func (s *State) record(action, summary string, before Tracker) {
+	s.History = append(s.History, Change{Action: action, Summary: summary, Before: before})
+}
+
+// Damage removes hit points, taking them from temporary HP first
+func (s *State) Damage(amount int) (string, error) {
+	return s.TypedDamage(amount, "", nil)
+}
+
+// TypedDamage applies damage of a type, halved, doubled or prevented by the character's defenses.
+// Petrified creatures resist all damage.
+func (s *State) TypedDamage(amount int, damageType string, defenses damage.Defenses) (string, error) {
+	if amount < 0 {
+		return "", fmt.Errorf("damage can't be negative")
+	}
+	before := s.Tracker.clone()
+	wasDown := s.HP == 0
+
+	rolled := amount
+	if damageType != "" && s.hasCondition("petrified") >= 0 {
+		defenses = append(slices.Clone(defenses), damage.Defense{DamageType: damage.TypeReference(damageType), Kind: damage.Resistance, Source: "Petrified"})
+	}
+	amount, reasons := defenses.Apply(amount, damageType)
+
+	absorbed := min(s.TempHP, amount)
+	s.TempHP -= absorbed
+	remaining, wildShapeNote := s.absorbWildShape(amount - absorbed)
+	overflow := remaining - s.HP
+	s.HP = max(0, s.HP-remaining)
+
+	summary := fmt.Sprintf("took %d damage", amount)
+	if damageType != "" {
+		summary = fmt.Sprintf("took %d %s damage", amount, strings.ToLower(damageType))
+	}
+	if len(reasons) > 0 {
+		summary += fmt.Sprintf(" (%d, %s)", rolled, strings.Join(reasons, ", "))
+	}
+	if absorbed > 0 {
+		summary += fmt.Sprintf(" (%d absorbed by temp HP)", absorbed)
+	}
+	summary += wildShapeNote
+	switch {
+	case s.HP > 0:
+	case overflow >= s.MaxHP:
+		// Massive damage: what's left after dropping to 0 is at least max HP
+		s.DeathSaves.Failures = 3
+		summary += ", killed outright by massive damage"
+	case wasDown && remaining > 0:
+		s.DeathSaves.Stable = false
+		s.DeathSaves.Failures = min(3, s.DeathSaves.Failures+1)
+		summary += fmt.Sprintf(", a death save failure while down (%d/3)", s.DeathSaves.Failures)
+	default:
+		summary += ", down to 0 HP"
+	}
+	summary += s.concentrationAfterDamage(amount)
+	s.record("damage", summary, before)
+	return summary, nil
+}
+
+// Heal restores hit points up to max HP
+func (s *State) Heal(amount int) (string, error) {
+	if amount < 0 {
+		return "", fmt.Errorf("healing can't be negative")
+	}
+	if s.Dead() {
+		return "", fmt.Errorf("%s is dead", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	// Healing in Wild Shape restores the beast's hit points
+	if form := s.WildShape; form != nil {
+		healed := min(amount, form.MaxHP-form.HP)
+		form.HP += healed
+
+		summary := fmt.Sprintf("healed %d HP (%s %d/%d HP)", healed, form.Beast, form.HP, form.MaxHP)
+		s.record("heal", summary, before)
+		return summary, nil
+	}
+
+	healed := max(0, min(amount, s.EffectiveMaxHP()-s.HP))
+	if healed > 0 && s.HP == 0 {
+		s.DeathSaves = DeathSaves{}
+	}
+	s.HP += healed
+
+	summary := fmt.Sprintf("healed %d HP", healed)
+	s.record("heal", summary, before)
+	return summary, nil
+}
+
+// SetTempHP grants temporary hit points. They don't stack, so the higher value is kept.
+func (s *State) SetTempHP(amount int) (string, error) {
+	if amount < 0 {
+		return "", fmt.Errorf("temporary HP can't be negative")
+	}
+	before := s.Tracker.clone()
+
+	summary := fmt.Sprintf("gained %d temp HP", amount)
+	if amount <= s.TempHP {
+		summary = fmt.Sprintf("kept %d temp HP (temporary HP doesn't stack)", s.TempHP)
+	} else {
+		s.TempHP = amount
+	}
+	s.record("temp", summary, before)
+	return summary, nil
+}
+
+// UseSlot expends a spell slot of the given level
+func (s *State) UseSlot(level int) (string, error) {
+	pool, ok := s.slot(level)
+	if !ok {
+		return "", fmt.Errorf("%s has no level %d spell slots", s.Character, level)
+	}
+	if pool.Used >= pool.Max {
+		return "", fmt.Errorf("%s has no level %d spell slots left", s.Character, level)
+	}
+	before := s.Tracker.clone()
+
+	pool.Used++
+
+	summary := fmt.Sprintf("used a level %d slot (%d/%d left)", level, pool.Max-pool.Used, pool.Max)
+	s.record("slot", summary, before)
+	return summary, nil
+}
+
+// UseResource expends one use of a class resource
+func (s *State) UseResource(name string) (string, error) {
+	pool, ok := s.resource(name)
+	if !ok {
+		return "", fmt.Errorf("%s has no resource named %q", s.Character, name)
+	}
+	if pool.Used >= pool.Max {
+		return "", fmt.Errorf("%s has no uses of %s left", s.Character, pool.Name)
+	}
+	before := s.Tracker.clone()
+
+	pool.Used++
+
+	summary := fmt.Sprintf("used %s (%d/%d left)", pool.Name, pool.Max-pool.Used, pool.Max)
+	s.record("resource", summary, before)
+	return summary, nil
+}
+
+// ShortRest spends up to count hit dice (largest first), each healing its roll plus the Constitution modifier.
+// Short rest resources and Pact Magic slots are recovered. A character at 0 HP can't rest.
+func (s *State) ShortRest(c *character.Character, count int, roller *dice.Roller) (string, error) {
+	if err := s.canRest(); err != nil {
+		return "", err
+	}
+	if count < 0 {
+		return "", fmt.Errorf("hit dice spent can't be negative")
+	}
+	available := 0
+	for _, pool := range s.HitDice {
+		available += pool.Remaining
+	}
+	if count > available {
+		return "", fmt.Errorf("%s only has %d hit dice left", s.Character, available)
+	}
+	before := s.Tracker.clone()
+
+	scores := c.Abilities()
+	conBonus := scores.Modifier(core.Constitution)
+	slices.SortFunc(s.HitDice, func(a, b stats.HitDicePool) int { return b.Die - a.Die })
+
+	var rolls []string
+	healed := 0
+	for i := range s.HitDice {
+		for ; count > 0 && s.HitDice[i].Remaining > 0; count-- {
+			roll := roller.Roll(s.HitDice[i].Die, "short rest")
+			s.HitDice[i].Remaining--
+			healed += max(0, roll+conBonus)
+			rolls = append(rolls, fmt.Sprintf("d%d %d", s.HitDice[i].Die, roll))
+		}
+	}
+	healed = max(0, min(healed, s.EffectiveMaxHP()-s.HP))
+	s.HP += healed
+
+	for i := range s.Slots {
+		if s.Slots[i].ShortRest {
+			s.Slots[i].Used = 0
+		}
+	}
+	for i := range s.Resources {
+		if s.Resources[i].ShortRest {
+			s.Resources[i].Used = 0
+		}
+	}
+
+	summary := "took a short rest"
+	if len(rolls) > 0 {
+		summary += fmt.Sprintf(", spent %d hit dice [%s] Con %+d each and healed %d HP", len(rolls), strings.Join(rolls, ", "), conBonus, healed)
+	}
+	s.record("short-rest", summary, before)
+	return summary, nil
+}
+
+// LongRest restores HP, every slot and resource, and half of the character's hit dice (at least one).
+// It also removes one level of exhaustion and ends Wild Shape and concentration.
+func (s *State) LongRest() (string, error) {
+	if err := s.canRest(); err != nil {
+		return "", err
+	}
+	before := s.Tracker.clone()
+
+	s.WildShape = nil
+	s.loseConcentration()
+
+	s.Exhaustion = max(0, s.Exhaustion-1)
+	s.DeathSaves = DeathSaves{}
+	s.HP = s.EffectiveMaxHP()
+	s.TempHP = 0
+
+	total := 0
+	for _, pool := range s.HitDice {
+		total += pool.Max
+	}
+	regain := max(1, total/2)
+	slices.SortFunc(s.HitDice, func(a, b stats.HitDicePool) int { return b.Die - a.Die })
+	for i := range s.HitDice {
+		restored := min(regain, s.HitDice[i].Max-s.HitDice[i].Remaining)
+		s.HitDice[i].Remaining += restored
+		regain -= restored
+	}
+
+	for i := range s.Slots {
+		s.Slots[i].Used = 0
+	}
+	for i := range s.Resources {
+		s.Resources[i].Used = 0
+	}
+	for i := range s.Companions {
+		s.Companions[i].HP = s.Companions[i].MaxHP
+	}
+
+	summary := "took a long rest"
+	s.record("long-rest", summary, before)
+	return summary, nil
+}
+
+// canRest reports why the character can't rest: they need at least 1 HP
+func (s *State) canRest() error {
+	switch {
+	case s.Dead():
+		return fmt.Errorf("%s is dead", s.Character)
+	case s.HP == 0 && s.DeathSaves.Stable:
+		return fmt.Errorf("%s is unconscious and needs at least 1 HP to rest", s.Character)
+	case s.HP == 0:
+		return fmt.Errorf("%s is dying and needs at least 1 HP to rest", s.Character)
+	}
+	return nil
+}
+
+// Undo reverts the last change and removes it from the log
+func (s *State) Undo() (string, error) {
+	if len(s.History) == 0 {
+		return "", fmt.Errorf("nothing to undo")
+	}
+
+	last := s.History[len(s.History)-1]
+	s.History = s.History[:len(s.History)-1]
+	s.Tracker = last.Before
+
+	return fmt.Sprintf("reverted %q", last.Summary), nil
+}
+
+// String renders the current state for the terminal
+func (s *State) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s: HP %d/%d", s.Character, s.HP, s.EffectiveMaxHP())
+	if s.TempHP > 0 {
+		fmt.Fprintf(&sb, " (+%d temp)", s.TempHP)
+	}
+	if s.EffectiveMaxHP() < s.MaxHP {
+		sb.WriteString(" (max HP halved by exhaustion)")
+	}
+	if s.Concentration != "" {
+		fmt.Fprintf(&sb, "\nConcentrating on: %s", s.Concentration)
+		for _, dc := range s.ConcentrationSaves {
+			fmt.Fprintf(&sb, " (DC %d save owed)", dc)
+		}
+	}
+	if len(s.Prepared) > 0 {
+		fmt.Fprintf(&sb, "\nPrepared: %s", strings.Join(s.Prepared, ", "))
+	}
+	if len(s.Spent) > 0 {
+		spent := make([]string, len(s.Spent))
+		for i, valuable := range s.Spent {
+			spent[i] = valuable.String()
+		}
+		fmt.Fprintf(&sb, "\nSpent on components: %s", strings.Join(spent, ", "))
+	}
+	if form := s.WildShape; form != nil {
+		fmt.Fprintf(&sb, "\nWild Shape: %s, HP %d/%d, AC %d", form.Beast, form.HP, form.MaxHP, form.AC)
+	}
+	s.writeConditions(&sb)
+
+	if len(s.HitDice) > 0 {
+		dice := make([]string, len(s.HitDice))
+		for i, pool := range s.HitDice {
+			dice[i] = fmt.Sprintf("%d/%dd%d", pool.Remaining, pool.Max, pool.Die)
+		}
+		fmt.Fprintf(&sb, "\nHit Dice: %s", strings.Join(dice, ", "))
+	}
+
+	if len(s.Slots) > 0 {
+		slots := make([]string, len(s.Slots))
+		for i, pool := range s.Slots {
+			slots[i] = fmt.Sprintf("L%d %d/%d", pool.Level, pool.Max-pool.Used, pool.Max)
+		}
+		fmt.Fprintf(&sb, "\nSpell Slots: %s", strings.Join(slots, ", "))
+	}
+
+	for _, pool := range s.Resources {
+		fmt.Fprintf(&sb, "\n%s: %d/%d", pool.Name, pool.Max-pool.Used, pool.Max)
+	}
+
+	if len(s.Supplies) > 0 {
+		supplies := make([]string, len(s.Supplies))
+		for i, pool := range s.Supplies {
+			supplies[i] = fmt.Sprintf("%s %d/%d", pool.Name, pool.Left(), pool.Max)
+		}
+		fmt.Fprintf(&sb, "\nSupplies: %s", strings.Join(supplies, ", "))
+	}
+
+	if len(s.Companions) > 0 {
+		companions := make([]string, len(s.Companions))
+		for i, pool := range s.Companions {
+			companions[i] = fmt.Sprintf("%s %d/%d HP", pool.Name, pool.HP, pool.MaxHP)
+		}
+		fmt.Fprintf(&sb, "\nCompanions: %s", strings.Join(companions, ", "))
+	}
+
+	return sb.String()
+}
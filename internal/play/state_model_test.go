@@ -0,0 +1,270 @@
+package play_test
+
+import (
+	"testing"
+
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/dice"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// StateTestSuite covers tracking HP, slots, resources and rests during a session
+type StateTestSuite struct {
+	suite.Suite
+	char  *character.Character
+	state *play.State
+}
+
+func (suite *StateTestSuite) SetupTest() {
+	suite.char = &character.Character{
+		Name:          "Leki",
+		Level:         5,
+		Class:         class.Class{Name: "Cleric", HitDie: 8},
+		AbilityScores: abilities.AbilityScores{Constitution: 14},
+		Stats: stats.Stats{
+			HP:      38,
+			HitDice: []stats.HitDicePool{{Die: 8, Max: 5, Remaining: 5}},
+		},
+	}
+	suite.state = play.NewState(suite.char)
+}
+
+func TestStateTestSuite(t *testing.T) {
+	suite.Run(t, new(StateTestSuite))
+}
+
+func (suite *StateTestSuite) TestNewState() {
+	assert.Equal(suite.T(), 38, suite.state.HP)
+	assert.Equal(suite.T(), 38, suite.state.MaxHP)
+	assert.Equal(suite.T(), []play.SlotPool{{Level: 1, Max: 4}, {Level: 2, Max: 3}, {Level: 3, Max: 2}}, suite.state.Slots)
+	require.Len(suite.T(), suite.state.Resources, 1)
+	assert.Equal(suite.T(), "Channel Divinity", suite.state.Resources[0].Name)
+}
+
+func (suite *StateTestSuite) TestDamage_TempHPAbsorbsFirst() {
+	_, err := suite.state.SetTempHP(5)
+	require.NoError(suite.T(), err)
+
+	summary, err := suite.state.Damage(8)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, suite.state.TempHP)
+	assert.Equal(suite.T(), 35, suite.state.HP)
+	assert.Contains(suite.T(), summary, "5 absorbed")
+
+	_, err = suite.state.Damage(100)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, suite.state.HP)
+
+	_, err = suite.state.Damage(-1)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestHeal_CappedAtMax() {
+	_, _ = suite.state.Damage(10)
+
+	summary, err := suite.state.Heal(20)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+	assert.Equal(suite.T(), "healed 10 HP", summary)
+}
+
+func (suite *StateTestSuite) TestTempHP_DoesNotStack() {
+	_, _ = suite.state.SetTempHP(8)
+	_, _ = suite.state.SetTempHP(5)
+
+	assert.Equal(suite.T(), 8, suite.state.TempHP)
+}
+
+func (suite *StateTestSuite) TestUseSlot() {
+	_, err := suite.state.UseSlot(3)
+	require.NoError(suite.T(), err)
+	_, err = suite.state.UseSlot(3)
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.UseSlot(3)
+	assert.ErrorContains(suite.T(), err, "no level 3 spell slots left")
+
+	_, err = suite.state.UseSlot(4)
+	assert.ErrorContains(suite.T(), err, "has no level 4")
+}
+
+func (suite *StateTestSuite) TestUseResource() {
+	_, err := suite.state.UseResource("channel divinity")
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.UseResource("Channel Divinity")
+	assert.Error(suite.T(), err)
+
+	_, err = suite.state.UseResource("Rage")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestShortRest() {
+	_, _ = suite.state.Damage(30)
+	_, _ = suite.state.UseSlot(1)
+	_, _ = suite.state.UseResource("Channel Divinity")
+
+	summary, err := suite.state.ShortRest(suite.char, 2, dice.NewRoller(42))
+
+	require.NoError(suite.T(), err)
+	// Seed 42 rolls 3 and 8 on a d8, each +2 Con
+	assert.Equal(suite.T(), 8+15, suite.state.HP)
+	assert.Equal(suite.T(), 3, suite.state.HitDice[0].Remaining)
+	assert.Contains(suite.T(), summary, "healed 15 HP")
+	// Slots only come back on a long rest, Channel Divinity on a short rest
+	assert.Equal(suite.T(), 1, suite.state.Slots[0].Used)
+	assert.Equal(suite.T(), 0, suite.state.Resources[0].Used)
+
+	_, err = suite.state.ShortRest(suite.char, 4, dice.NewRoller(1))
+	assert.ErrorContains(suite.T(), err, "only has 3 hit dice")
+}
+
+func (suite *StateTestSuite) TestAmuletOfHealth() {
+	suite.char.Inventory.MagicItems = []inventory.MagicItem{{Index: "amulet-of-health", Name: "Amulet of Health", Attuned: true}}
+	state := play.NewState(suite.char)
+
+	// Con 19 raises the modifier from +2 to +4 at each of the 5 levels
+	assert.Equal(suite.T(), 48, suite.char.MaxHP())
+	assert.Equal(suite.T(), 48, state.MaxHP)
+
+	// Hit dice heal with the higher modifier too: seed 42 rolls 3 and 8, each +4
+	_, _ = state.Damage(30)
+	summary, err := state.ShortRest(suite.char, 2, dice.NewRoller(42))
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), summary, "healed 19 HP")
+
+	// Taking it off lowers max HP again
+	suite.char.Inventory.MagicItems = nil
+	state.Sync(suite.char)
+	assert.Equal(suite.T(), 38, state.MaxHP)
+}
+
+func (suite *StateTestSuite) TestShortRest_PactMagic() {
+	warlock := &character.Character{Name: "Vex", Level: 3, Class: class.Class{Name: "Warlock", HitDie: 8}, Stats: stats.Stats{HP: 24}}
+	state := play.NewState(warlock)
+	_, err := state.UseSlot(2)
+	require.NoError(suite.T(), err)
+
+	_, err = state.ShortRest(warlock, 0, dice.NewRoller(1))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, state.Slots[0].Used)
+	// The hit dice pool is derived when the character doesn't have one
+	assert.Equal(suite.T(), 3, state.HitDice[0].Max)
+}
+
+func (suite *StateTestSuite) TestLongRest() {
+	_, _ = suite.state.Damage(30)
+	_, _ = suite.state.SetTempHP(4)
+	_, _ = suite.state.UseSlot(2)
+	_, _ = suite.state.ShortRest(suite.char, 5, dice.NewRoller(3))
+
+	_, err := suite.state.LongRest()
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+	assert.Equal(suite.T(), 0, suite.state.TempHP)
+	assert.Equal(suite.T(), 0, suite.state.Slots[1].Used)
+	// Half of 5 hit dice
+	assert.Equal(suite.T(), 2, suite.state.HitDice[0].Remaining)
+}
+
+func (suite *StateTestSuite) TestLongRest_EndsWildShapeAndConcentration() {
+	suite.druid()
+	_, _ = suite.state.Concentrate("Entangle")
+	_, _ = suite.state.Damage(4)
+	_, err := suite.state.StartWildShape(suite.char, wolf())
+	require.NoError(suite.T(), err)
+	require.NotEmpty(suite.T(), suite.state.ConcentrationSaves)
+
+	_, err = suite.state.LongRest()
+
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), suite.state.WildShape)
+	assert.Empty(suite.T(), suite.state.Concentration)
+	assert.Empty(suite.T(), suite.state.ConcentrationSaves)
+}
+
+func (suite *StateTestSuite) TestRest_NeedsHP() {
+	_, _ = suite.state.Damage(38)
+
+	_, err := suite.state.LongRest()
+	assert.EqualError(suite.T(), err, "Leki is dying and needs at least 1 HP to rest")
+	_, err = suite.state.ShortRest(suite.char, 1, dice.NewRoller(1))
+	assert.EqualError(suite.T(), err, "Leki is dying and needs at least 1 HP to rest")
+
+	_, _ = suite.state.Stabilize()
+	_, err = suite.state.LongRest()
+	assert.EqualError(suite.T(), err, "Leki is unconscious and needs at least 1 HP to rest")
+	assert.Equal(suite.T(), 0, suite.state.HP)
+}
+
+func (suite *StateTestSuite) TestUndo() {
+	_, _ = suite.state.SetTempHP(5)
+	_, _ = suite.state.Damage(12)
+	_, _ = suite.state.UseSlot(1)
+
+	summary, err := suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `reverted "used a level 1 slot (3/4 left)"`, summary)
+	assert.Equal(suite.T(), 0, suite.state.Slots[0].Used)
+
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+	assert.Equal(suite.T(), 5, suite.state.TempHP)
+	assert.Len(suite.T(), suite.state.History, 1)
+
+	_, _ = suite.state.Undo()
+	_, err = suite.state.Undo()
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestSync_AfterLevelUp() {
+	_, _ = suite.state.Damage(10)
+	_, _ = suite.state.UseSlot(1)
+
+	suite.char.Level = 6
+	suite.char.Stats.HP = 45
+	suite.char.Stats.HitDice[0].Max = 6
+	suite.state.Sync(suite.char)
+
+	assert.Equal(suite.T(), 35, suite.state.HP)
+	assert.Equal(suite.T(), 45, suite.state.MaxHP)
+	assert.Equal(suite.T(), 6, suite.state.HitDice[0].Remaining)
+	assert.Equal(suite.T(), 1, suite.state.Slots[0].Used)
+	assert.Equal(suite.T(), 2, suite.state.Resources[0].Max)
+}
+
+func (suite *StateTestSuite) TestString() {
+	_, _ = suite.state.SetTempHP(3)
+
+	out := suite.state.String()
+
+	assert.Contains(suite.T(), out, "Leki: HP 38/38 (+3 temp)")
+	assert.Contains(suite.T(), out, "Hit Dice: 5/5d8")
+	assert.Contains(suite.T(), out, "Spell Slots: L1 4/4, L2 3/3, L3 2/2")
+	assert.Contains(suite.T(), out, "Channel Divinity: 1/1")
+}
+
+func TestNewMonsterState(t *testing.T) {
+	state := play.NewMonsterState("Wolf 2", wolf())
+
+	assert.Equal(t, "Wolf 2", state.Character)
+	assert.Equal(t, 11, state.HP)
+	assert.Equal(t, 11, state.MaxHP)
+	assert.Equal(t, 40, state.Speed)
+
+	_, err := state.Damage(4)
+	require.NoError(t, err)
+	assert.Equal(t, "Wolf 2: HP 7/11", state.String())
+}
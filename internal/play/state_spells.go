@@ -0,0 +1,71 @@
+package play
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/class"
+)
+
+// WithPrepared returns the character with the spells prepared during play, or as built when none were swapped
+func (s *State) WithPrepared(c *character.Character) *character.Character {
+	if s.Prepared == nil {
+		return c
+	}
+	prepared := *c
+	prepared.Prepared = s.Prepared
+	return &prepared
+}
+
+// WithSpent returns the character without the valuables used up as material components during play
+func (s *State) WithSpent(c *character.Character) *character.Character {
+	if len(s.Spent) == 0 {
+		return c
+	}
+	spent := *c
+	spent.Inventory.Valuables = slices.Clone(c.Inventory.Valuables)
+	for _, valuable := range s.Spent {
+		spent.Inventory.SpendValuable(valuable)
+	}
+	return &spent
+}
+
+// Prepare swaps the character's prepared spells for a new list, which is only possible
+// when finishing a long rest. The list has to fit the class's preparation limit.
+func (s *State) Prepare(c *character.Character, names []string) (string, error) {
+	switch c.Class.Preparation() {
+	case class.NoSpellcasting:
+		return "", fmt.Errorf("%s doesn't prepare spells", c.Class.Name)
+	case class.KnownSpells:
+		return "", fmt.Errorf("%s knows their spells and doesn't prepare them", c.Class.Name)
+	}
+	if last := len(s.History) - 1; last < 0 || (s.History[last].Action != "long-rest" && s.History[last].Action != "prepare") {
+		return "", fmt.Errorf("%s can only change their prepared spells when finishing a long rest", s.Character)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no spells to prepare")
+	}
+
+	prepared := make([]string, len(names))
+	for i, name := range names {
+		spell, err := c.FindSpell(name)
+		if err != nil {
+			return "", err
+		}
+		prepared[i] = spell.Name
+	}
+	probe := *c
+	probe.Prepared = prepared
+	if err := probe.CheckSpells(); err != nil {
+		return "", err
+	}
+	before := s.Tracker.clone()
+
+	s.Prepared = prepared
+
+	summary := fmt.Sprintf("prepared %s (%d/%d)", strings.Join(prepared, ", "), len(prepared), probe.PreparedLimit())
+	s.record("prepare", summary, before)
+	return summary, nil
+}
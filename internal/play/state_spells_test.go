@@ -0,0 +1,117 @@
+package play_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/spells"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *StateTestSuite) TestPrepare_OnlyAfterLongRest() {
+	suite.knowSpells()
+
+	_, err := suite.state.Prepare(suite.char, []string{"bless"})
+	assert.EqualError(suite.T(), err, "Leki can only change their prepared spells when finishing a long rest")
+
+	_, err = suite.state.LongRest()
+	require.NoError(suite.T(), err)
+
+	summary, err := suite.state.Prepare(suite.char, []string{"bless"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "prepared Bless (1/5)", summary)
+	assert.Equal(suite.T(), []string{"Bless"}, suite.state.Prepared)
+	assert.Contains(suite.T(), suite.state.String(), "Prepared: Bless")
+
+	// Changing the list again straight away is fine
+	_, err = suite.state.Prepare(suite.char, []string{"spirit guardians"})
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Bless"}, suite.state.Prepared)
+}
+
+func (suite *StateTestSuite) TestPrepare_Invalid() {
+	suite.knowSpells()
+	_, err := suite.state.LongRest()
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.Prepare(suite.char, []string{"sacred flame"})
+	assert.EqualError(suite.T(), err, "Sacred Flame is a cantrip and doesn't need preparing")
+
+	_, err = suite.state.Prepare(suite.char, []string{"fireball"})
+	assert.EqualError(suite.T(), err, `Leki does not know the spell "fireball"`)
+
+	suite.char.Level = 1
+	_, err = suite.state.Prepare(suite.char, []string{"bless", "spirit guardians"})
+	assert.EqualError(suite.T(), err, "Leki has 2 spells prepared, but a level 1 cleric can prepare 1")
+
+	suite.char.Class = class.Class{Name: "Sorcerer"}
+	_, err = suite.state.Prepare(suite.char, []string{"bless"})
+	assert.EqualError(suite.T(), err, "Sorcerer knows their spells and doesn't prepare them")
+	assert.Empty(suite.T(), suite.state.Prepared)
+}
+
+func (suite *StateTestSuite) TestCast_OnlyPreparedSpells() {
+	suite.knowSpells()
+	_, err := suite.state.LongRest()
+	require.NoError(suite.T(), err)
+	_, err = suite.state.Prepare(suite.char, []string{"bless"})
+	require.NoError(suite.T(), err)
+
+	_, err = suite.state.Cast(suite.char, "spirit guardians", 0)
+	assert.EqualError(suite.T(), err, "Leki doesn't have Spirit Guardians prepared")
+
+	// Domain spells are always prepared
+	suite.char.AlwaysPrepared = []string{"Spirit Guardians"}
+	summary, err := suite.state.Cast(suite.char, "spirit guardians", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Spirit Guardians with a level 3 slot (1/2 left), concentrating on Spirit Guardians", summary)
+}
+
+func (suite *StateTestSuite) TestCastRitual() {
+	suite.char.Class = class.Class{Name: "Wizard"}
+	suite.char.Spells = [][]spells.Spell{nil, {
+		{Name: "Detect Magic", Level: 1, Ritual: true, Concentration: true},
+		{Name: "Shield", Level: 1},
+	}}
+	suite.char.Prepared = []string{"Shield"}
+
+	summary, err := suite.state.CastRitual(suite.char, "detect magic")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Detect Magic as a ritual, concentrating on Detect Magic", summary)
+	assert.Equal(suite.T(), 0, suite.state.Slots[0].Used, "rituals don't spend a slot")
+
+	_, err = suite.state.Cast(suite.char, "detect magic", 0)
+	assert.EqualError(suite.T(), err, "Leki doesn't have Detect Magic prepared")
+
+	_, err = suite.state.CastRitual(suite.char, "shield")
+	assert.EqualError(suite.T(), err, "Leki can't cast Shield as a ritual")
+}
+
+func (suite *StateTestSuite) TestCast_CostlyMaterial() {
+	suite.char.Spells = [][]spells.Spell{nil, nil, nil, {
+		{Name: "Revivify", Level: 3, Components: []string{"V", "S", "M"}, Material: "Diamonds worth 300 gp, which the spell consumes."},
+	}}
+
+	_, err := suite.state.Cast(suite.char, "revivify", 0)
+	assert.EqualError(suite.T(), err, "Leki needs diamonds worth 300 gp to cast Revivify")
+
+	suite.char.Inventory.Valuables = []inventory.Valuable{{Name: "Diamond", GP: 300}}
+	summary, err := suite.state.Cast(suite.char, "revivify", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cast Revivify with a level 3 slot (1/2 left), consuming Diamond (300 gp)", summary)
+	assert.Contains(suite.T(), suite.state.String(), "Spent on components: Diamond (300 gp)")
+
+	// The diamond is gone, so it can't pay for another cast
+	_, err = suite.state.Cast(suite.char, "revivify", 0)
+	assert.EqualError(suite.T(), err, "Leki needs diamonds worth 300 gp to cast Revivify")
+
+	// Undoing the cast gives it back
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.state.Spent)
+	_, err = suite.state.Cast(suite.char, "revivify", 0)
+	assert.NoError(suite.T(), err)
+}
@@ -0,0 +1,128 @@
+package play
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+)
+
+// SupplyPool tracks how much of a carried supply has been spent: ammunition, thrown weapons or consumable uses
+type SupplyPool struct {
+	Index string `json:"index"`
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Max   int    `json:"max"`
+	Used  int    `json:"used"`
+}
+
+// Left returns how much of the supply hasn't been spent
+func (p SupplyPool) Left() int {
+	return p.Max - p.Used
+}
+
+// syncSupplies rebuilds the supply pools from the inventory, keeping what was already spent.
+// Older states may hold several pools for one item, so everything spent from them is added up.
+func (s *State) syncSupplies(c *character.Character) {
+	var supplies []SupplyPool
+	for _, supply := range c.Inventory.Supplies() {
+		pool := SupplyPool{Index: supply.Index, Name: supply.Name, Kind: supply.Kind, Max: supply.Count}
+		for _, old := range s.Supplies {
+			if old.Index == supply.Index {
+				pool.Used += old.Used
+			}
+		}
+		pool.Used = min(pool.Used, pool.Max)
+		supplies = append(supplies, pool)
+	}
+	s.Supplies = supplies
+}
+
+// supply returns the pool for a supply by name or index, ignoring case
+func (s *State) supply(name string) (*SupplyPool, bool) {
+	index := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+	for i := range s.Supplies {
+		if strings.EqualFold(s.Supplies[i].Name, name) || s.Supplies[i].Index == index {
+			return &s.Supplies[i], true
+		}
+	}
+	return nil, false
+}
+
+// UseSupply spends count ammunition, thrown weapons or consumable uses
+func (s *State) UseSupply(name string, count int) (string, error) {
+	if count < 1 {
+		return "", fmt.Errorf("must use at least one")
+	}
+	pool, ok := s.supply(name)
+	if !ok {
+		return "", fmt.Errorf("%s carries no ammunition, thrown weapon or consumable named %q", s.Character, name)
+	}
+	if count > pool.Left() {
+		return "", fmt.Errorf("%s only has %d %s left", s.Character, pool.Left(), pool.Name)
+	}
+	before := s.Tracker.clone()
+
+	pool.Used += count
+
+	verb := "used"
+	switch pool.Kind {
+	case inventory.SupplyAmmunition:
+		verb = "fired"
+	case inventory.SupplyThrown:
+		verb = "threw"
+	}
+	summary := fmt.Sprintf("%s %d %s (%d/%d left)", verb, count, pool.Name, pool.Left(), pool.Max)
+	s.record("use", summary, before)
+	return summary, nil
+}
+
+// RecoverSupply picks up spent ammunition or thrown weapons after a fight.
+// A count of 0 recovers half of the spent ammunition, rounded down, or every thrown weapon.
+func (s *State) RecoverSupply(name string, count int) (string, error) {
+	if count < 0 {
+		return "", fmt.Errorf("recovered amount can't be negative")
+	}
+	pool, ok := s.supply(name)
+	if !ok {
+		return "", fmt.Errorf("%s carries no ammunition or thrown weapon named %q", s.Character, name)
+	}
+	if pool.Kind == inventory.SupplyConsumable {
+		return "", fmt.Errorf("%s can't be recovered once used", pool.Name)
+	}
+	if count == 0 {
+		count = pool.Used
+		if pool.Kind == inventory.SupplyAmmunition {
+			count = pool.Used / 2
+		}
+	}
+	if count > pool.Used {
+		return "", fmt.Errorf("only %d %s have been spent", pool.Used, pool.Name)
+	}
+	before := s.Tracker.clone()
+
+	pool.Used -= count
+
+	summary := fmt.Sprintf("recovered %d %s (%d/%d left)", count, pool.Name, pool.Left(), pool.Max)
+	s.record("recover", summary, before)
+	return summary, nil
+}
+
+// ApplySupplies notes how much ammunition or how many thrown weapons an attack has left
+func (s *State) ApplySupplies(check *character.Check) {
+	if check.Supply == "" {
+		return
+	}
+	pool, ok := s.supply(check.Supply)
+	switch {
+	case !ok:
+		check.Notes = append(check.Notes, fmt.Sprintf("no %s carried", strings.ReplaceAll(check.Supply, "-", " ")))
+	case pool.Left() == 0:
+		check.Notes = append(check.Notes, fmt.Sprintf("no %s left", pool.Name))
+	case pool.Kind == inventory.SupplyThrown:
+		check.Notes = append(check.Notes, fmt.Sprintf("%d/%d %s left to throw", pool.Left(), pool.Max, pool.Name))
+	default:
+		check.Notes = append(check.Notes, fmt.Sprintf("%d/%d %s left", pool.Left(), pool.Max, pool.Name))
+	}
+}
@@ -0,0 +1,123 @@
+package play_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/inventory"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// carrySupplies gives the character 20 arrows, 2 javelins and a Healer's Kit
+func (suite *StateTestSuite) carrySupplies() {
+	suite.char.Inventory = inventory.Inventory{
+		Weapons: []inventory.Weapon{{BaseEquipment: inventory.BaseEquipment{
+			Index: "javelin", Name: "Javelin", Count: 2, Properties: []reference.Reference{{Index: "thrown"}},
+		}}},
+		Items: []inventory.Item{
+			{BaseEquipment: inventory.BaseEquipment{Index: "arrow", Name: "Arrow", Count: 20}, GearCategory: &reference.Reference{Index: "ammunition"}},
+			{BaseEquipment: inventory.BaseEquipment{Index: "healers-kit", Name: "Healer's Kit"}},
+		},
+	}
+	suite.state.Sync(suite.char)
+}
+
+func (suite *StateTestSuite) TestUseSupply() {
+	suite.carrySupplies()
+
+	summary, err := suite.state.UseSupply("arrow", 5)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "fired 5 Arrow (15/20 left)", summary)
+
+	summary, err = suite.state.UseSupply("healer's kit", 1)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "used 1 Healer's Kit (9/10 left)", summary)
+	assert.Contains(suite.T(), suite.state.String(), "Supplies: Arrow 15/20, Javelin 2/2, Healer's Kit 9/10")
+
+	_, err = suite.state.UseSupply("javelin", 3)
+	assert.ErrorContains(suite.T(), err, "only has 2 Javelin left")
+	_, err = suite.state.UseSupply("bolt", 1)
+	assert.Error(suite.T(), err)
+	_, err = suite.state.UseSupply("arrow", 0)
+	assert.Error(suite.T(), err)
+
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, suite.state.Supplies[2].Used)
+}
+
+func (suite *StateTestSuite) TestRecoverSupply() {
+	suite.carrySupplies()
+	_, _ = suite.state.UseSupply("arrow", 7)
+	_, _ = suite.state.UseSupply("javelin", 2)
+	_, _ = suite.state.UseSupply("healers-kit", 1)
+
+	// Half of the spent ammunition, rounded down
+	summary, err := suite.state.RecoverSupply("Arrow", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "recovered 3 Arrow (16/20 left)", summary)
+
+	summary, err = suite.state.RecoverSupply("javelin", 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "recovered 2 Javelin (2/2 left)", summary)
+
+	_, err = suite.state.RecoverSupply("arrow", 5)
+	assert.ErrorContains(suite.T(), err, "only 4 Arrow have been spent")
+	_, err = suite.state.RecoverSupply("healer's kit", 1)
+	assert.ErrorContains(suite.T(), err, "can't be recovered")
+}
+
+func (suite *StateTestSuite) TestSync_KeepsSpentSupplies() {
+	suite.carrySupplies()
+	_, _ = suite.state.UseSupply("arrow", 15)
+
+	// Selling arrows lowers the maximum and what was spent is capped to it
+	suite.char.Inventory.Items[0].Count = 10
+	suite.state.Sync(suite.char)
+
+	assert.Equal(suite.T(), play.SupplyPool{Index: "arrow", Name: "Arrow", Kind: inventory.SupplyAmmunition, Max: 10, Used: 10}, suite.state.Supplies[0])
+}
+
+func (suite *StateTestSuite) TestApplySupplies() {
+	suite.carrySupplies()
+	_, _ = suite.state.UseSupply("arrow", 20)
+	_, _ = suite.state.UseSupply("javelin", 1)
+
+	for supply, note := range map[string]string{
+		"arrow":          "no Arrow left",
+		"javelin":        "1/2 Javelin left to throw",
+		"crossbow-bolt":  "no crossbow bolt carried",
+		"blowgun-needle": "no blowgun needle carried",
+	} {
+		check := character.Check{Kind: "attack", HasD20: true, Supply: supply}
+		suite.state.ApplySupplies(&check)
+		assert.Equal(suite.T(), []string{note}, check.Notes, supply)
+	}
+
+	check := character.Check{Kind: "attack", HasD20: true}
+	suite.state.ApplySupplies(&check)
+	assert.Empty(suite.T(), check.Notes)
+}
+
+func (suite *StateTestSuite) TestUseSupply_StackedInSeveralPlaces() {
+	torch := inventory.BaseEquipment{Index: "torch", Name: "Torch", Count: 2}
+	packed := torch
+	packed.Count, packed.Container = 10, "backpack"
+	suite.char.Inventory = inventory.Inventory{Items: []inventory.Item{{BaseEquipment: torch}, {BaseEquipment: packed}}}
+	suite.state.Sync(suite.char)
+	require.Len(suite.T(), suite.state.Supplies, 1)
+
+	// Both stacks can be spent from one pool
+	summary, err := suite.state.UseSupply("torch", 5)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "used 5 Torch (7/12 left)", summary)
+
+	// Pools split by an older state are merged, keeping everything spent
+	suite.state.Supplies = []play.SupplyPool{
+		{Index: "torch", Name: "Torch", Kind: inventory.SupplyConsumable, Max: 2, Used: 1},
+		{Index: "torch", Name: "Torch", Kind: inventory.SupplyConsumable, Max: 10, Used: 4},
+	}
+	suite.state.Sync(suite.char)
+	assert.Equal(suite.T(), []play.SupplyPool{{Index: "torch", Name: "Torch", Kind: inventory.SupplyConsumable, Max: 12, Used: 5}}, suite.state.Supplies)
+}
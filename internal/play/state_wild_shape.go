@@ -0,0 +1,174 @@
+package play
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/character"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+)
+
+// WildShapeForm is the beast a druid has turned into with Wild Shape
+type WildShapeForm struct {
+	Beast         string           `json:"beast"`
+	HP            int              `json:"hp"`
+	MaxHP         int              `json:"max_hp"`
+	AC            int              `json:"ac"`
+	Speed         int              `json:"speed"`
+	Strength      int              `json:"strength"`
+	Dexterity     int              `json:"dexterity"`
+	Constitution  int              `json:"constitution"`
+	Proficiencies map[string]int   `json:"proficiencies,omitempty"` // The beast's save and skill bonuses, e.g. "Skill: Stealth"
+	Attacks       []monster.Action `json:"attacks,omitempty"`
+}
+
+// newWildShapeForm takes the physical statistics, hit points and attacks of a beast
+func newWildShapeForm(beast *monster.Monster) *WildShapeForm {
+	form := &WildShapeForm{
+		Beast:        beast.Name,
+		HP:           beast.HitPoints,
+		MaxHP:        beast.HitPoints,
+		AC:           beast.AC(),
+		Speed:        beast.WalkSpeed(),
+		Strength:     beast.Strength,
+		Dexterity:    beast.Dexterity,
+		Constitution: beast.Constitution,
+	}
+	for _, p := range beast.Proficiencies {
+		if form.Proficiencies == nil {
+			form.Proficiencies = map[string]int{}
+		}
+		form.Proficiencies[p.Proficiency.Name] = p.Value
+	}
+	for _, action := range beast.Actions {
+		if action.AttackBonus > 0 {
+			form.Attacks = append(form.Attacks, action)
+		}
+	}
+	return form
+}
+
+// StartWildShape spends a use of Wild Shape to turn the druid into a beast within their level's limits
+func (s *State) StartWildShape(c *character.Character, beast *monster.Monster) (string, error) {
+	limit, ok := c.Class.WildShape(c.Level, c.Subclass)
+	switch {
+	case !ok:
+		return "", fmt.Errorf("%s can't Wild Shape", s.Character)
+	case s.WildShape != nil:
+		return "", fmt.Errorf("%s is already a %s, end that Wild Shape first", s.Character, s.WildShape.Beast)
+	case s.HP == 0:
+		return "", fmt.Errorf("%s is at 0 HP", s.Character)
+	case !strings.EqualFold(beast.Type, "beast"):
+		return "", fmt.Errorf("%s is a %s, not a beast", beast.Name, beast.Type)
+	case beast.ChallengeRating > limit.MaxCR:
+		return "", fmt.Errorf("%s is CR %s, %s can only become beasts up to CR %s",
+			beast.Name, monster.FormatCR(beast.ChallengeRating), s.Character, monster.FormatCR(limit.MaxCR))
+	case limit.NoFly && beast.Speed.Fly != "":
+		return "", fmt.Errorf("%s has a flying speed, which needs druid level 8", beast.Name)
+	case limit.NoSwim && beast.Speed.Swim != "":
+		return "", fmt.Errorf("%s has a swimming speed, which needs druid level 4", beast.Name)
+	}
+
+	pool, ok := s.resource("Wild Shape")
+	if !ok || pool.Used >= pool.Max {
+		return "", fmt.Errorf("%s has no uses of Wild Shape left", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	pool.Used++
+	s.WildShape = newWildShapeForm(beast)
+
+	summary := fmt.Sprintf("turned into a %s (%d HP, AC %d, %d/%d Wild Shape left)",
+		beast.Name, s.WildShape.HP, s.WildShape.AC, pool.Max-pool.Used, pool.Max)
+	s.record("wild-shape", summary, before)
+	return summary, nil
+}
+
+// EndWildShape reverts the druid to their normal form
+func (s *State) EndWildShape() (string, error) {
+	if s.WildShape == nil {
+		return "", fmt.Errorf("%s isn't in Wild Shape", s.Character)
+	}
+	before := s.Tracker.clone()
+
+	summary := fmt.Sprintf("reverted from a %s", s.WildShape.Beast)
+	s.WildShape = nil
+	s.record("wild-shape", summary, before)
+	return summary, nil
+}
+
+// absorbWildShape takes damage from the beast form's hit points first.
+// When the beast drops to 0 HP the druid reverts and the remaining damage carries over.
+func (s *State) absorbWildShape(amount int) (int, string) {
+	form := s.WildShape
+	if form == nil || amount == 0 {
+		return amount, ""
+	}
+
+	taken := min(form.HP, amount)
+	form.HP -= taken
+	if form.HP > 0 {
+		return 0, fmt.Sprintf(" (%s %d/%d HP)", form.Beast, form.HP, form.MaxHP)
+	}
+
+	s.WildShape = nil
+	return amount - taken, fmt.Sprintf(" (%s dropped to 0 HP and reverted, %d damage carried over)", form.Beast, amount-taken)
+}
+
+// Shaped returns the character with the beast's physical ability scores, AC and, where they're higher,
+// save and skill bonuses. Mental scores and proficiencies are kept. Outside of Wild Shape c is returned.
+func (s *State) Shaped(c *character.Character) *character.Character {
+	form := s.WildShape
+	if form == nil {
+		return c
+	}
+
+	shaped := *c
+	shaped.AbilityScores.Strength = form.Strength
+	shaped.AbilityScores.Dexterity = form.Dexterity
+	shaped.AbilityScores.Constitution = form.Constitution
+	shaped.RefreshDerived()
+	shaped.Stats.AC = form.AC
+
+	for name, bonus := range form.Proficiencies {
+		if save, ok := strings.CutPrefix(name, "Saving Throw: "); ok {
+			if ability, err := core.ParseAbility(save); err == nil && bonus > shaped.SavingThrows.Score(ability) {
+				shaped.SavingThrows.SetScore(ability, bonus)
+			}
+		}
+		if skill, ok := strings.CutPrefix(name, "Skill: "); ok {
+			if found := shaped.Skills.Find(skill); found != nil && bonus > found.Bonus {
+				found.Bonus = bonus
+			}
+		}
+	}
+	return &shaped
+}
+
+// WildShapeAttack returns an attack check for one of the beast form's attacks
+func (s *State) WildShapeAttack(name string) (character.Check, error) {
+	if s.WildShape == nil {
+		return character.Check{}, fmt.Errorf("%s isn't in Wild Shape", s.Character)
+	}
+
+	var names []string
+	for _, attack := range s.WildShape.Attacks {
+		names = append(names, attack.Name)
+		if !strings.EqualFold(attack.Name, strings.TrimSpace(name)) {
+			continue
+		}
+
+		check := character.Check{Kind: "attack", Name: attack.Name, Bonus: attack.AttackBonus, HasD20: true}
+		for i, damage := range attack.Damage {
+			if i == 0 {
+				check.Damage = damage.DamageDice
+				check.DamageType = strings.ToLower(damage.DamageType.Name)
+				continue
+			}
+			check.Notes = append(check.Notes, fmt.Sprintf("plus %s %s damage", damage.DamageDice, strings.ToLower(damage.DamageType.Name)))
+		}
+		return check, nil
+	}
+	return character.Check{}, fmt.Errorf("a %s has no attack %q, it has: %s", s.WildShape.Beast, name, strings.Join(names, ", "))
+}
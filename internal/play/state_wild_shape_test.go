@@ -0,0 +1,153 @@
+package play_test
+
+import (
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/monster"
+	"github.com/kwford18/MKDIRagons/internal/play"
+	"github.com/kwford18/MKDIRagons/internal/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wolf() *monster.Monster {
+	return &monster.Monster{
+		Index: "wolf", Name: "Wolf", Size: "Medium", Type: "beast", ChallengeRating: 0.25,
+		ArmorClass:    []monster.ArmorClass{{Type: "natural", Value: 13}},
+		HitPoints:     11,
+		Speed:         monster.Speed{Walk: "40 ft."},
+		AbilityScores: abilities.AbilityScores{Strength: 12, Dexterity: 15, Constitution: 12, Intelligence: 3, Wisdom: 12, Charisma: 6},
+		Proficiencies: []monster.Proficiency{
+			{Value: 3, Proficiency: reference.Reference{Name: "Skill: Perception"}},
+			{Value: 4, Proficiency: reference.Reference{Name: "Skill: Stealth"}},
+		},
+		Actions: []monster.Action{
+			{Name: "Bite", AttackBonus: 4, Damage: []monster.ActionDamage{{DamageType: reference.Reference{Name: "Piercing"}, DamageDice: "2d4+2"}}},
+		},
+	}
+}
+
+// druid turns the suite's character into a level 4 druid
+func (suite *StateTestSuite) druid() {
+	suite.char.Class = class.Class{Name: "Druid", HitDie: 8}
+	suite.char.Level = 4
+	suite.char.AbilityScores = abilities.AbilityScores{Strength: 8, Dexterity: 12, Constitution: 14, Intelligence: 12, Wisdom: 16, Charisma: 10}
+	suite.state = play.NewState(suite.char)
+}
+
+func (suite *StateTestSuite) TestStartWildShape() {
+	suite.druid()
+
+	summary, err := suite.state.StartWildShape(suite.char, wolf())
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "turned into a Wolf (11 HP, AC 13, 1/2 Wild Shape left)", summary)
+	assert.Equal(suite.T(), 40, suite.state.CurrentSpeed())
+	assert.Contains(suite.T(), suite.state.String(), "Wild Shape: Wolf, HP 11/11, AC 13")
+
+	_, err = suite.state.StartWildShape(suite.char, wolf())
+	assert.ErrorContains(suite.T(), err, "already a Wolf")
+
+	summary, err = suite.state.EndWildShape()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "reverted from a Wolf", summary)
+	assert.Nil(suite.T(), suite.state.WildShape)
+
+	_, _ = suite.state.StartWildShape(suite.char, wolf())
+	_, _ = suite.state.EndWildShape()
+	_, err = suite.state.StartWildShape(suite.char, wolf())
+	assert.ErrorContains(suite.T(), err, "no uses of Wild Shape left")
+	_, err = suite.state.EndWildShape()
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestStartWildShape_Limits() {
+	_, err := suite.state.StartWildShape(suite.char, wolf())
+	assert.ErrorContains(suite.T(), err, "can't Wild Shape")
+
+	suite.druid()
+	tests := map[string]func(m *monster.Monster){
+		"not a beast":         func(m *monster.Monster) { m.Type = "monstrosity" },
+		"up to CR 1/2":        func(m *monster.Monster) { m.ChallengeRating = 1 },
+		"needs druid level 8": func(m *monster.Monster) { m.Speed.Fly = "60 ft." },
+	}
+	for message, change := range tests {
+		beast := wolf()
+		change(beast)
+		_, err := suite.state.StartWildShape(suite.char, beast)
+		assert.ErrorContains(suite.T(), err, message)
+	}
+
+	// Swimming beasts are allowed from level 4
+	beast := wolf()
+	beast.Speed.Swim = "30 ft."
+	_, err = suite.state.StartWildShape(suite.char, beast)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *StateTestSuite) TestWildShape_DamageCarriesOver() {
+	suite.druid()
+	_, _ = suite.state.StartWildShape(suite.char, wolf())
+
+	summary, err := suite.state.Damage(5)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "took 5 damage (Wolf 6/11 HP)", summary)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+
+	summary, err = suite.state.Heal(3)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "healed 3 HP (Wolf 9/11 HP)", summary)
+
+	summary, err = suite.state.Damage(12)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "took 12 damage (Wolf dropped to 0 HP and reverted, 3 damage carried over)", summary)
+	assert.Nil(suite.T(), suite.state.WildShape)
+	assert.Equal(suite.T(), 35, suite.state.HP)
+
+	// Undo brings the beast back
+	_, err = suite.state.Undo()
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), suite.state.WildShape)
+	assert.Equal(suite.T(), 9, suite.state.WildShape.HP)
+	assert.Equal(suite.T(), 38, suite.state.HP)
+}
+
+func (suite *StateTestSuite) TestShaped() {
+	suite.druid()
+	suite.char.Skills.Stealth.Name = "Stealth"
+	suite.char.Skills.Perception.Name = "Perception"
+	assert.Same(suite.T(), suite.char, suite.state.Shaped(suite.char))
+
+	_, _ = suite.state.StartWildShape(suite.char, wolf())
+	shaped := suite.state.Shaped(suite.char)
+
+	// Physical scores come from the wolf, mental scores are the druid's
+	assert.Equal(suite.T(), 12, shaped.AbilityScores.Strength)
+	assert.Equal(suite.T(), 15, shaped.AbilityScores.Dexterity)
+	assert.Equal(suite.T(), 16, shaped.AbilityScores.Wisdom)
+	assert.Equal(suite.T(), 13, shaped.Stats.AC)
+	assert.Equal(suite.T(), 2, shaped.SavingThrows.Score(core.Dexterity))
+
+	// The wolf's Stealth is better, the druid's Perception (Wis +3) ties it
+	assert.Equal(suite.T(), 4, shaped.Skills.Stealth.Bonus)
+	assert.Equal(suite.T(), 3, shaped.Skills.Perception.Bonus)
+	assert.Equal(suite.T(), 8, suite.char.AbilityScores.Strength)
+}
+
+func (suite *StateTestSuite) TestWildShapeAttack() {
+	suite.druid()
+	_, err := suite.state.WildShapeAttack("bite")
+	assert.Error(suite.T(), err)
+
+	_, _ = suite.state.StartWildShape(suite.char, wolf())
+	check, err := suite.state.WildShapeAttack("bite")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, check.Bonus)
+	assert.Equal(suite.T(), "2d4+2", check.Damage)
+	assert.Equal(suite.T(), "piercing", check.DamageType)
+
+	_, err = suite.state.WildShapeAttack("claw")
+	assert.ErrorContains(suite.T(), err, "it has: Bite")
+}
@@ -1,12 +1,15 @@
 package skills
 
 import (
+	"github.com/kwford18/MKDIRagons/internal/abilities"
 	"github.com/kwford18/MKDIRagons/template"
 )
 
-func BuildSkill(base *template.Character, name string) Skill {
+// BuildSkill builds a skill from the character's final ability scores, after racial bonuses and ASIs,
+// and the template's proficiencies and expertise
+func BuildSkill(base *template.Character, scores abilities.AbilityScores, name string) Skill {
 	// Calculate bonus by getting the modifier of input skill
-	bonus := base.AbilityScores.Modifier(base.GetSkillAbility(name))
+	bonus := scores.Modifier(base.GetSkillAbility(name))
 
 	// proficiencies & expertise are false by default
 	proficient := false
@@ -37,25 +40,26 @@ func BuildSkill(base *template.Character, name string) Skill {
 	}
 }
 
-func BuildSkillList(base *template.Character) SkillList {
+// BuildSkillList builds every skill, see BuildSkill
+func BuildSkillList(base *template.Character, scores abilities.AbilityScores) SkillList {
 	return SkillList{
-		Athletics:      BuildSkill(base, "Athletics"),
-		Acrobatics:     BuildSkill(base, "Acrobatics"),
-		SleightOfHand:  BuildSkill(base, "SleightOfHand"),
-		Stealth:        BuildSkill(base, "Stealth"),
-		Arcana:         BuildSkill(base, "Arcana"),
-		History:        BuildSkill(base, "History"),
-		Investigation:  BuildSkill(base, "Investigation"),
-		Nature:         BuildSkill(base, "Nature"),
-		Religion:       BuildSkill(base, "Religion"),
-		AnimalHandling: BuildSkill(base, "AnimalHandling"),
-		Insight:        BuildSkill(base, "Insight"),
-		Medicine:       BuildSkill(base, "Medicine"),
-		Perception:     BuildSkill(base, "Perception"),
-		Survival:       BuildSkill(base, "Survival"),
-		Deception:      BuildSkill(base, "Deception"),
-		Intimidation:   BuildSkill(base, "Intimidation"),
-		Performance:    BuildSkill(base, "Performance"),
-		Persuasion:     BuildSkill(base, "Persuasion"),
+		Athletics:      BuildSkill(base, scores, "Athletics"),
+		Acrobatics:     BuildSkill(base, scores, "Acrobatics"),
+		SleightOfHand:  BuildSkill(base, scores, "SleightOfHand"),
+		Stealth:        BuildSkill(base, scores, "Stealth"),
+		Arcana:         BuildSkill(base, scores, "Arcana"),
+		History:        BuildSkill(base, scores, "History"),
+		Investigation:  BuildSkill(base, scores, "Investigation"),
+		Nature:         BuildSkill(base, scores, "Nature"),
+		Religion:       BuildSkill(base, scores, "Religion"),
+		AnimalHandling: BuildSkill(base, scores, "AnimalHandling"),
+		Insight:        BuildSkill(base, scores, "Insight"),
+		Medicine:       BuildSkill(base, scores, "Medicine"),
+		Perception:     BuildSkill(base, scores, "Perception"),
+		Survival:       BuildSkill(base, scores, "Survival"),
+		Deception:      BuildSkill(base, scores, "Deception"),
+		Intimidation:   BuildSkill(base, scores, "Intimidation"),
+		Performance:    BuildSkill(base, scores, "Performance"),
+		Persuasion:     BuildSkill(base, scores, "Persuasion"),
 	}
 }
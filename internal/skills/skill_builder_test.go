@@ -4,13 +4,15 @@ import (
 	"github.com/kwford18/MKDIRagons/internal/skills"
 	"testing"
 
+	"github.com/kwford18/MKDIRagons/internal/abilities"
+
 	"github.com/kwford18/MKDIRagons/internal/core"
 	"github.com/kwford18/MKDIRagons/template"
 	"github.com/stretchr/testify/assert"
 )
 
-// setupTestCharacter creates a character instance for testing.
-func setupTestCharacter() *template.Character {
+// setupTestCharacter creates a character instance for testing, and its ability scores.
+func setupTestCharacter() (*template.Character, abilities.AbilityScores) {
 	// Creating a character with specific proficiencies for testing
 	ab := template.AbilityScores{
 		Strength:     10,
@@ -28,16 +30,17 @@ func setupTestCharacter() *template.Character {
 		Expertise:     []string{"Acrobatics"},
 	}
 
-	return char
+	scores := abilities.AbilityScores{Strength: 10, Dexterity: 14, Constitution: 10, Wisdom: 10, Intelligence: 10, Charisma: 10}
+	return char, scores
 }
 
 func TestBuildSkill_Logic(t *testing.T) {
-	char := setupTestCharacter()
+	char, scores := setupTestCharacter()
 
 	// Test Base Case (No Proficiency)
 	// Assuming Athletics is Strength based. Let's assume Str score 10 (+0 mod).
 	// Result should be 0.
-	athletics := skills.BuildSkill(char, "Athletics")
+	athletics := skills.BuildSkill(char, scores, "Athletics")
 	assert.Equal(t, "Athletics", athletics.Name)
 	assert.Equal(t, 0, athletics.Bonus)
 	assert.False(t, athletics.Proficient)
@@ -45,7 +48,7 @@ func TestBuildSkill_Logic(t *testing.T) {
 
 	// Test Proficiency
 	// Stealth (Dex): +2 (Mod) + 2 (Prof) = +4
-	stealth := skills.BuildSkill(char, "Stealth")
+	stealth := skills.BuildSkill(char, scores, "Stealth")
 	assert.Equal(t, "Stealth", stealth.Name)
 	assert.Equal(t, 4, stealth.Bonus)
 	assert.True(t, stealth.Proficient)
@@ -53,7 +56,7 @@ func TestBuildSkill_Logic(t *testing.T) {
 
 	// Test Expertise
 	// Acrobatics (Dex): +2 (Mod) + 2 (Prof) + 2 (Expertise) = +6
-	acrobatics := skills.BuildSkill(char, "Acrobatics")
+	acrobatics := skills.BuildSkill(char, scores, "Acrobatics")
 	assert.Equal(t, "Acrobatics", acrobatics.Name)
 	assert.Equal(t, 6, acrobatics.Bonus)
 	assert.True(t, acrobatics.Proficient)
@@ -61,9 +64,9 @@ func TestBuildSkill_Logic(t *testing.T) {
 }
 
 func TestBuildSkillList(t *testing.T) {
-	char := setupTestCharacter()
+	char, scores := setupTestCharacter()
 
-	skillList := skills.BuildSkillList(char)
+	skillList := skills.BuildSkillList(char, scores)
 
 	// Verify the list was populated
 	assert.NotEmpty(t, skillList.Athletics.Name)
@@ -2,6 +2,7 @@ package skills
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kwford18/MKDIRagons/internal/core"
 )
@@ -53,8 +54,9 @@ func (sl *SkillList) GetEndpoint() string {
 	return "skills/"
 }
 
-func (sl *SkillList) Print() {
-	skills := []Skill{
+// All returns every skill in the list in sheet order
+func (sl *SkillList) All() []Skill {
+	return []Skill{
 		sl.Athletics,
 		sl.Acrobatics,
 		sl.SleightOfHand,
@@ -74,8 +76,24 @@ func (sl *SkillList) Print() {
 		sl.Performance,
 		sl.Persuasion,
 	}
+}
+
+// Find returns the skill with a name, ignoring case and spaces, e.g. "Sleight of Hand"
+func (sl *SkillList) Find(name string) *Skill {
+	for _, skill := range []*Skill{
+		&sl.Athletics, &sl.Acrobatics, &sl.SleightOfHand, &sl.Stealth, &sl.Arcana, &sl.History,
+		&sl.Investigation, &sl.Nature, &sl.Religion, &sl.AnimalHandling, &sl.Insight, &sl.Medicine,
+		&sl.Perception, &sl.Survival, &sl.Deception, &sl.Intimidation, &sl.Performance, &sl.Persuasion,
+	} {
+		if strings.EqualFold(skill.Name, strings.ReplaceAll(name, " ", "")) {
+			return skill
+		}
+	}
+	return nil
+}
 
-	for _, skill := range skills {
+func (sl *SkillList) Print() {
+	for _, skill := range sl.All() {
 		// %-20s means left-align the string in a field 20 characters wide
 		fmt.Printf("%-20s %d\n", skill.Name+":", skill.Bonus)
 	}
@@ -108,3 +108,32 @@ func TestSkillList_Print(t *testing.T) {
 	}
 	assert.Equal(t, 18, lineCount, "Should print exactly 18 lines for the 18 skills")
 }
+
+func TestSkillList_All(t *testing.T) {
+	sl := &skills.SkillList{
+		Athletics:  skills.Skill{Name: "Athletics"},
+		Persuasion: skills.Skill{Name: "Persuasion", Expertise: true},
+	}
+
+	all := sl.All()
+
+	assert.Len(t, all, 18)
+	assert.Equal(t, "Athletics", all[0].Name)
+	assert.Equal(t, "Persuasion", all[17].Name)
+	assert.True(t, all[17].Expertise)
+}
+
+func TestSkillList_Find(t *testing.T) {
+	sl := &skills.SkillList{
+		SleightOfHand: skills.Skill{Name: "SleightOfHand", Bonus: 2},
+		Stealth:       skills.Skill{Name: "Stealth", Bonus: 4},
+	}
+
+	found := sl.Find("Sleight of Hand")
+	assert.NotNil(t, found)
+	found.Bonus = 5
+	assert.Equal(t, 5, sl.SleightOfHand.Bonus)
+
+	assert.Equal(t, 4, sl.Find("stealth").Bonus)
+	assert.Nil(t, sl.Find("Juggling"))
+}
@@ -2,6 +2,11 @@ package spells
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/kwford18/MKDIRagons/internal/reference"
 )
 
@@ -21,9 +26,10 @@ type Spell struct {
 	AttackType    string   `json:"attack_type,omitempty"`
 
 	// Complex nested objects (pointers handle null/missing keys)
-	Damage       *SpellDamage       `json:"damage,omitempty"`
-	DC           *SpellDC           `json:"dc,omitempty"`
-	AreaOfEffect *SpellAreaOfEffect `json:"area_of_effect,omitempty"`
+	Damage          *SpellDamage       `json:"damage,omitempty"`
+	HealAtSlotLevel map[string]string  `json:"heal_at_slot_level,omitempty"` // e.g. Cure Wounds' "1d8 + MOD"
+	DC              *SpellDC           `json:"dc,omitempty"`
+	AreaOfEffect    *SpellAreaOfEffect `json:"area_of_effect,omitempty"`
 
 	School     reference.Reference   `json:"school"`
 	Classes    []reference.Reference `json:"classes"`
@@ -32,8 +38,9 @@ type Spell struct {
 }
 
 type SpellDamage struct {
-	DamageType        reference.Reference `json:"damage_type"`
-	DamageAtSlotLevel map[string]string   `json:"damage_at_slot_level"`
+	DamageType             reference.Reference `json:"damage_type"`
+	DamageAtSlotLevel      map[string]string   `json:"damage_at_slot_level"`
+	DamageAtCharacterLevel map[string]string   `json:"damage_at_character_level,omitempty"` // Cantrips scale by character level
 }
 
 type SpellDC struct {
@@ -53,3 +60,55 @@ func (s *Spell) GetEndpoint() string {
 func (s *Spell) Print() {
 	fmt.Printf("Spell: %s", s.Name)
 }
+
+// HasComponent reports whether casting the spell needs a component: "V", "S" or "M"
+func (s *Spell) HasComponent(component string) bool {
+	return slices.ContainsFunc(s.Components, func(c string) bool { return strings.EqualFold(c, component) })
+}
+
+// CostlyMaterial is a material component with a gold cost, which a focus or component pouch can't replace
+type CostlyMaterial struct {
+	Item     string // e.g. "diamond" or "powdered diamond"
+	GP       int
+	AtLeast  bool // A single item worth at least GP, rather than GP worth in total
+	Consumed bool
+}
+
+// String describes the material, e.g. "diamonds worth 300 gp"
+func (m CostlyMaterial) String() string {
+	if m.AtLeast {
+		return fmt.Sprintf("%s worth at least %d gp", m.Item, m.GP)
+	}
+	return fmt.Sprintf("%s worth %d gp", m.Item, m.GP)
+}
+
+var (
+	// materialWorth matches "a diamond worth at least 300 gp" and "diamonds worth 300 gp"
+	materialWorth = regexp.MustCompile(`(?i)([a-z' ]+?) worth (at least )?([\d,]+) ?gp`)
+	// worthOfMaterial matches "10 gp worth of charcoal, incense, and herbs"
+	worthOfMaterial = regexp.MustCompile(`(?i)([\d,]+) ?gp worth of ([a-z' ,]+?)(?: that| which|\.|$)`)
+	// materialArticle strips the start of a material's name, e.g. the "and an" of "and an owl feather"
+	materialArticle = regexp.MustCompile(`(?i)^(?:.*(?:,|;| and| or))?\s*(?:a pair of|an|a|some|the)?\s*`)
+)
+
+// CostlyMaterials parses the material components with a cost out of the spell's material text,
+// e.g. Revivify's "Diamonds worth 300 gp, which the spell consumes."
+func (s *Spell) CostlyMaterials() []CostlyMaterial {
+	var materials []CostlyMaterial
+	for _, part := range strings.Split(s.Material, ";") {
+		consumed := strings.Contains(strings.ToLower(part), "consume")
+		for _, match := range worthOfMaterial.FindAllStringSubmatch(part, -1) {
+			gp, _ := strconv.Atoi(strings.ReplaceAll(match[1], ",", ""))
+			materials = append(materials, CostlyMaterial{Item: strings.TrimSpace(match[2]), GP: gp, Consumed: consumed})
+		}
+		for _, match := range materialWorth.FindAllStringSubmatch(part, -1) {
+			if strings.HasSuffix(strings.TrimSpace(match[1]), " gp") {
+				continue // The "10 gp worth of" form
+			}
+			gp, _ := strconv.Atoi(strings.ReplaceAll(match[3], ",", ""))
+			item := strings.ToLower(strings.TrimSpace(materialArticle.ReplaceAllString(match[1], "")))
+			materials = append(materials, CostlyMaterial{Item: item, GP: gp, AtLeast: match[2] != "", Consumed: consumed})
+		}
+	}
+	return materials
+}
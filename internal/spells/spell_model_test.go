@@ -86,3 +86,40 @@ func TestSpell_JSON_FullSerialization(t *testing.T) {
 	assert.Contains(t, jsonString, `"area_of_effect":`)
 	assert.Contains(t, jsonString, `"size":20`)
 }
+
+func TestSpell_HasComponent(t *testing.T) {
+	s := &spells.Spell{Components: []string{"V", "S", "M"}}
+	assert.True(t, s.HasComponent("m"))
+	assert.False(t, (&spells.Spell{Components: []string{"V"}}).HasComponent("M"))
+}
+
+func TestSpell_CostlyMaterials(t *testing.T) {
+	testCases := []struct {
+		name     string
+		material string
+		expected []spells.CostlyMaterial
+	}{
+		{"Revivify", "Diamonds worth 300 gp, which the spell consumes.",
+			[]spells.CostlyMaterial{{Item: "diamonds", GP: 300, Consumed: true}}},
+		{"Raise Dead", "A diamond worth at least 500 gp, which the spell consumes.",
+			[]spells.CostlyMaterial{{Item: "diamond", GP: 500, AtLeast: true, Consumed: true}}},
+		{"Identify", "A pearl worth at least 100 gp and an owl feather.",
+			[]spells.CostlyMaterial{{Item: "pearl", GP: 100, AtLeast: true}}},
+		{"Glyph of Warding", "Incense and powdered diamond worth at least 200 gp, which the spell consumes.",
+			[]spells.CostlyMaterial{{Item: "powdered diamond", GP: 200, AtLeast: true, Consumed: true}}},
+		{"Resurrection", "A diamond worth at least 1,000 gp, which the spell consumes.",
+			[]spells.CostlyMaterial{{Item: "diamond", GP: 1000, AtLeast: true, Consumed: true}}},
+		{"Find Familiar", "10 gp worth of charcoal, incense, and herbs that must be consumed by fire in a brass brazier.",
+			[]spells.CostlyMaterial{{Item: "charcoal, incense, and herbs", GP: 10, Consumed: true}}},
+		{"Fireball", "A tiny ball of bat guano and sulfur.", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &spells.Spell{Name: tc.name, Material: tc.material}
+			assert.Equal(t, tc.expected, s.CostlyMaterials())
+		})
+	}
+
+	assert.Equal(t, "diamond worth at least 500 gp", spells.CostlyMaterial{Item: "diamond", GP: 500, AtLeast: true}.String())
+}
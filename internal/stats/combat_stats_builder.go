@@ -2,11 +2,12 @@ package stats
 
 import (
 	"fmt"
-	"math/rand/v2"
+	"strings"
 
 	"github.com/kwford18/MKDIRagons/internal/abilities"
 	"github.com/kwford18/MKDIRagons/internal/class"
 	"github.com/kwford18/MKDIRagons/internal/core"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/inventory"
 )
 
@@ -25,55 +26,92 @@ func averageHP(hitDie int) (int, error) {
 	}
 }
 
-// BuildStats generates the combat stats of a character and populates + returns the Stats struct with values
-func BuildStats(level int, abilityScores abilities.AbilityScores, class class.Class, rollHP bool, armor *inventory.Armor) (Stats, error) {
-	var HP int
+// NewHPEntry builds the HP record for a level. Level 1 always takes the maximum of the hit die,
+// later levels are rolled with roller, or use the hit die average when roller is nil.
+func NewHPEntry(level, hitDie, conBonus, bonus int, roller *dice.Roller) (HPEntry, error) {
+	avgHP, err := averageHP(hitDie)
+	if err != nil {
+		return HPEntry{}, err
+	}
 
-	// Ability score bonuses to avoid repeat calls
-	conBonus := abilityScores.Modifier(core.Constitution)
-	dexBonus := abilityScores.Modifier(core.Dexterity)
+	entry := HPEntry{
+		Level:    level,
+		HitDie:   hitDie,
+		Roll:     avgHP, // Average HP if not rolling
+		ConBonus: conBonus,
+		Bonus:    bonus,
+	}
+	if level == 1 {
+		entry.Roll = hitDie
+	} else if roller != nil {
+		result := dice.MustParse(fmt.Sprintf("1d%d", hitDie)).Roll(roller, fmt.Sprintf("HP level %d", level))
+		entry.Roll = result.Total
+		entry.Rolled = true
+	}
 
-	if rollHP {
-		// Build HP based on level
-		for i := 1; i <= level; i++ {
-			HP += rand.IntN(class.HitDie) + 1 + conBonus
-			// fmt.Printf("HP at %d Level: %d\n", i, HP)
-		}
-	} else {
-		avgHP, err := averageHP(class.HitDie) // Average HP if not rolling
-		if err != nil {
-			return Stats{}, err
-		}
-		for i := 1; i <= level; i++ {
-			HP += avgHP + conBonus
+	return entry, nil
+}
+
+// HPBonusPerLevel returns the flat HP gained every level from the Tough feat and Hill Dwarf's Dwarven Toughness
+func HPBonusPerLevel(feats []string, subrace string) int {
+	bonus := 0
+	for _, feat := range feats {
+		if strings.EqualFold(feat, "Tough") {
+			bonus += 2
 		}
 	}
+	switch strings.ToLower(subrace) {
+	case "hill dwarf", "hill-dwarf":
+		bonus++
+	}
+	return bonus
+}
+
+// ArmorClass calculates a character's AC from their armor, class, and ability scores
+func ArmorClass(abilityScores abilities.AbilityScores, class class.Class, armor *inventory.Armor) int {
+	dexBonus := abilityScores.Modifier(core.Dexterity)
 
 	// If character has armor, use that for AC
 	// If they are a Barbarian or Monk, use Unarmored Defense
 	// Otherwise it is 10 + dex bonus
-	// Calculate AC
-	var AC int
 	if armor != nil {
 		// Armor equipped
-		AC = armor.ArmorClass.Base
+		AC := armor.ArmorClass.Base
 		if armor.ArmorClass.DexBonus {
 			AC += dexBonus
 		}
+		return AC
 	} else if class.Name == "Barbarian" {
 		// Unarmored Defense for Barbarian
-		AC = 10 + dexBonus + conBonus
+		return 10 + dexBonus + abilityScores.Modifier(core.Constitution)
 	} else if class.Name == "Monk" {
 		// Unarmored Defense for Monk
-		AC = 10 + dexBonus + abilityScores.Modifier(core.Wisdom) // Only time calculating wisdom mod, no need to pre-compute
-	} else {
-		// Default AC
-		AC = 10 + dexBonus
+		return 10 + dexBonus + abilityScores.Modifier(core.Wisdom)
 	}
-	return Stats{
-		HP:     HP,
+	// Default AC
+	return 10 + dexBonus
+}
+
+// BuildStats generates the combat stats of a character and populates + returns the Stats struct with values.
+// HP is rolled with roller, or uses hit die averages when roller is nil.
+func BuildStats(level int, abilityScores abilities.AbilityScores, class class.Class, roller *dice.Roller, armor *inventory.Armor) (Stats, error) {
+	stats := Stats{
 		TempHP: 0,
-		AC:     AC,
+		AC:     ArmorClass(abilityScores, class, armor),
 		Speed:  30,
-	}, nil
+	}
+
+	// Ability score bonus to avoid repeat calls
+	conBonus := abilityScores.Modifier(core.Constitution)
+
+	// Build HP based on level, keeping each level's hit die in the history
+	for i := 1; i <= level; i++ {
+		entry, err := NewHPEntry(i, class.HitDie, conBonus, 0, roller)
+		if err != nil {
+			return Stats{}, err
+		}
+		stats.AddLevel(entry)
+	}
+
+	return stats, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kwford18/MKDIRagons/internal/abilities"
 	"github.com/kwford18/MKDIRagons/internal/class"
+	"github.com/kwford18/MKDIRagons/internal/dice"
 	"github.com/kwford18/MKDIRagons/internal/inventory"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,42 +41,60 @@ func TestBuildStats_HP_Average(t *testing.T) {
 	level := 3
 
 	// Expected HP Calculation:
+	// Level 1 takes the max die: 10 + 2 = 12
 	// HitDie 10 -> Average is 6 (from switch case)
 	// Modifier is +2
 	// Per Level: 6 + 2 = 8
-	// Total Level 3: 8 * 3 = 24
+	// Total Level 3: 12 + 8 * 2 = 28
 
-	testStats, err := stats.BuildStats(level, scores, cls, false, nil)
+	testStats, err := stats.BuildStats(level, scores, cls, nil, nil)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 24, testStats.HP)
+	assert.Equal(t, 28, testStats.HP)
 }
 
 func TestBuildStats_HP_InvalidHitDie(t *testing.T) {
 	scores := setupAbilities(10, 10, 10, 10, 10, 10)
 	cls := class.Class{Name: "Broken", HitDie: 20} // 20 is not in switch case
 
-	_, err := stats.BuildStats(1, scores, cls, false, nil)
+	_, err := stats.BuildStats(1, scores, cls, nil, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "invalid hit die provided", err.Error())
 }
 
-func TestBuildStats_HP_Rolled_Range(t *testing.T) {
-	// Since rand is non-deterministic, we test that the result falls within possible bounds.
-	// Level 10, HitDie 6, Con 10 (+0)
-	// Min HP (all 1s): 1 * 10 = 10
-	// Max HP (all 6s): 6 * 10 = 60
+func TestBuildStats_HP_Rolled_Seeded(t *testing.T) {
+	// A seeded roller makes rolled HP reproducible.
+	// Level 10, HitDie 6, Con 10 (+0), level 1 always takes the max of 6
+	// Seed 42 rolls 4, 3, 4, 4, 6, 2, 2, 5, 5 for levels 2-10: 6 + 35 = 41
 
 	scores := setupAbilities(10, 10, 10, 10, 10, 10)
 	cls := class.Class{Name: "Wizard", HitDie: 6}
 	level := 10
 
-	testStats, err := stats.BuildStats(level, scores, cls, true, nil)
+	testStats, err := stats.BuildStats(level, scores, cls, dice.NewRoller(42), nil)
 
 	assert.NoError(t, err)
-	assert.GreaterOrEqual(t, testStats.HP, 10)
-	assert.LessOrEqual(t, testStats.HP, 60)
+	assert.Equal(t, 41, testStats.HP)
+
+	// The same seed always produces the same HP
+	again, _ := stats.BuildStats(level, scores, cls, dice.NewRoller(42), nil)
+	assert.Equal(t, testStats.HPHistory, again.HPHistory)
+}
+
+func TestBuildStats_HP_RollsAreLogged(t *testing.T) {
+	scores := setupAbilities(10, 10, 10, 10, 10, 10)
+	cls := class.Class{Name: "Wizard", HitDie: 6}
+	roller := dice.NewRoller(42)
+
+	testStats, err := stats.BuildStats(3, scores, cls, roller, nil)
+
+	assert.NoError(t, err)
+	log := roller.Log()
+	assert.Len(t, log.Rolls, 2, "level 1 takes the max die and is not rolled")
+	assert.Equal(t, "HP level 2", log.Rolls[0].Label)
+	assert.Equal(t, testStats.HPHistory[1].Roll, log.Rolls[0].Result)
+	assert.Equal(t, testStats.HPHistory[2].Roll, log.Rolls[1].Result)
 }
 
 func TestBuildStats_AC_Default(t *testing.T) {
@@ -86,7 +105,7 @@ func TestBuildStats_AC_Default(t *testing.T) {
 	scores := setupAbilities(10, 14, 10, 10, 10, 10)
 	cls := class.Class{Name: "Fighter", HitDie: 10}
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, nil)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, nil)
 	assert.Equal(t, 12, testStats.AC)
 }
 
@@ -97,7 +116,7 @@ func TestBuildStats_AC_Barbarian_Unarmored(t *testing.T) {
 	scores := setupAbilities(10, 14, 16, 10, 10, 10)
 	cls := class.Class{Name: "Barbarian", HitDie: 12}
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, nil)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, nil)
 	assert.Equal(t, 15, testStats.AC)
 }
 
@@ -108,7 +127,7 @@ func TestBuildStats_AC_Monk_Unarmored(t *testing.T) {
 	scores := setupAbilities(10, 14, 10, 10, 16, 10)
 	cls := class.Class{Name: "Monk", HitDie: 8}
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, nil)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, nil)
 	assert.Equal(t, 15, testStats.AC)
 }
 
@@ -120,7 +139,7 @@ func TestBuildStats_AC_WithArmor_NoDex(t *testing.T) {
 	cls := class.Class{Name: "Fighter", HitDie: 10}
 	armor := setupArmor(18, false)
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, armor)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, armor)
 	assert.Equal(t, 18, testStats.AC)
 }
 
@@ -132,7 +151,7 @@ func TestBuildStats_AC_WithArmor_WithDex(t *testing.T) {
 	cls := class.Class{Name: "Rogue", HitDie: 8}
 	armor := setupArmor(11, true)
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, armor)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, armor)
 	assert.Equal(t, 13, testStats.AC)
 }
 
@@ -141,6 +160,108 @@ func TestBuildStats_Speed(t *testing.T) {
 	scores := setupAbilities(10, 10, 10, 10, 10, 10)
 	cls := class.Class{Name: "Fighter", HitDie: 10}
 
-	testStats, _ := stats.BuildStats(1, scores, cls, false, nil)
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, nil)
 	assert.Equal(t, 30, testStats.Speed)
 }
+
+func TestNewHPEntry_FirstLevelIsMaxDie(t *testing.T) {
+	// Level 1 takes the max die even when rolling
+	entry, err := stats.NewHPEntry(1, 8, 2, 0, dice.NewRoller(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, entry.Roll)
+	assert.False(t, entry.Rolled)
+	assert.Equal(t, 10, entry.Total())
+}
+
+func TestNewHPEntry_Average(t *testing.T) {
+	// d8 average is 5, plus Con +2 and Tough +2
+	entry, err := stats.NewHPEntry(2, 8, 2, 2, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, entry.Roll)
+	assert.Equal(t, 9, entry.Total())
+}
+
+func TestNewHPEntry_Rolled_Seeded(t *testing.T) {
+	// Seed 42 rolls a 3 on the first d8
+	entry, err := stats.NewHPEntry(3, 8, 1, 0, dice.NewRoller(42))
+
+	assert.NoError(t, err)
+	assert.True(t, entry.Rolled)
+	assert.Equal(t, 3, entry.Roll)
+	assert.Equal(t, 4, entry.Total())
+}
+
+func TestNewHPEntry_InvalidHitDie(t *testing.T) {
+	_, err := stats.NewHPEntry(2, 4, 0, 0, dice.NewRoller(1))
+	assert.Error(t, err)
+}
+
+func TestHPEntry_TotalMinimumOne(t *testing.T) {
+	// A roll of 1 with Con -3 still grants 1 HP
+	entry := stats.HPEntry{Level: 2, HitDie: 6, Roll: 1, ConBonus: -3}
+	assert.Equal(t, 1, entry.Total())
+}
+
+func TestHPBonusPerLevel(t *testing.T) {
+	assert.Equal(t, 0, stats.HPBonusPerLevel(nil, ""))
+	assert.Equal(t, 2, stats.HPBonusPerLevel([]string{"Alert", "tough"}, ""))
+	assert.Equal(t, 1, stats.HPBonusPerLevel(nil, "Hill Dwarf"))
+	assert.Equal(t, 3, stats.HPBonusPerLevel([]string{"Tough"}, "hill-dwarf"))
+	assert.Equal(t, 0, stats.HPBonusPerLevel(nil, "Mountain Dwarf"))
+}
+
+func TestBuildStats_HPHistoryAndHitDice(t *testing.T) {
+	scores := setupAbilities(10, 10, 14, 10, 10, 10)
+	cls := class.Class{Name: "Cleric", HitDie: 8}
+
+	testStats, err := stats.BuildStats(4, scores, cls, dice.NewRoller(42), nil)
+
+	assert.NoError(t, err)
+	// Seed 42 rolls 3, 8, 7 on d8: (8 + 2) + (3 + 2) + (8 + 2) + (7 + 2) = 34
+	assert.Equal(t, 34, testStats.HP)
+	assert.Len(t, testStats.HPHistory, 4)
+	assert.Equal(t, []stats.HitDicePool{{Die: 8, Max: 4, Remaining: 4}}, testStats.HitDice)
+
+	total := 0
+	for i, entry := range testStats.HPHistory {
+		assert.Equal(t, i+1, entry.Level)
+		assert.Equal(t, 2, entry.ConBonus)
+		total += entry.Total()
+	}
+	assert.Equal(t, testStats.HP, total)
+}
+
+func TestStats_RecomputeHP(t *testing.T) {
+	// Level 3 wizard, Con +0: 6 + 4 + 4 = 14
+	scores := setupAbilities(10, 10, 10, 10, 10, 10)
+	cls := class.Class{Name: "Wizard", HitDie: 6}
+	testStats, _ := stats.BuildStats(3, scores, cls, nil, nil)
+	assert.Equal(t, 14, testStats.HP)
+
+	// Con rises to +1 and the Tough feat adds 2 per level
+	testStats.RecomputeHP(1, 2)
+	assert.Equal(t, 23, testStats.HP)
+	for _, entry := range testStats.HPHistory {
+		assert.Equal(t, 1, entry.ConBonus)
+		assert.Equal(t, 2, entry.Bonus)
+	}
+}
+
+func TestStats_RecomputeHP_NoHistory(t *testing.T) {
+	testStats := stats.Stats{HP: 30}
+	testStats.RecomputeHP(3, 2)
+	assert.Equal(t, 30, testStats.HP)
+}
+
+func TestArmorClass_MatchesBuildStats(t *testing.T) {
+	// ArmorClass should agree with the AC BuildStats computes
+	scores := setupAbilities(10, 16, 14, 10, 10, 10)
+	cls := class.Class{Name: "Barbarian", HitDie: 12}
+
+	testStats, _ := stats.BuildStats(1, scores, cls, nil, nil)
+	assert.Equal(t, testStats.AC, stats.ArmorClass(scores, cls, nil))
+	assert.Equal(t, 15, stats.ArmorClass(scores, cls, nil))
+	assert.Equal(t, 14, stats.ArmorClass(scores, cls, setupArmor(11, true)))
+}
@@ -1,9 +1,67 @@
 package stats
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
+
+// HPEntry records the hit points gained at a single level
+type HPEntry struct {
+	Level    int
+	HitDie   int
+	Roll     int  // Hit die result: max die at level 1, the average when not rolling
+	Rolled   bool // Whether Roll came from an actual die roll
+	ConBonus int
+	Bonus    int // Flat per-level bonus such as the Tough feat or Dwarven Toughness
+}
+
+// Total returns the HP gained at this level, which is never less than 1
+func (e HPEntry) Total() int {
+	return max(1, e.Roll+e.ConBonus) + e.Bonus
+}
+
+// HitDicePool tracks the hit dice of a single die size
+type HitDicePool struct {
+	Die       int
+	Max       int
+	Remaining int
+}
 
 type Stats struct {
 	HP, TempHP, AC, Speed int
+	HPHistory             []HPEntry
+	HitDice               []HitDicePool
+}
+
+// AddLevel records a new level's HP and adds its hit die to the pool
+func (cs *Stats) AddLevel(entry HPEntry) {
+	cs.HPHistory = append(cs.HPHistory, entry)
+	cs.HP += entry.Total()
+
+	for i := range cs.HitDice {
+		if cs.HitDice[i].Die == entry.HitDie {
+			cs.HitDice[i].Max++
+			cs.HitDice[i].Remaining++
+			return
+		}
+	}
+	cs.HitDice = append(cs.HitDice, HitDicePool{Die: entry.HitDie, Max: 1, Remaining: 1})
+}
+
+// RecomputeHP re-derives max HP from the history, e.g. after Constitution or a per-level bonus changes.
+// Characters without a history keep their HP unchanged.
+func (cs *Stats) RecomputeHP(conBonus, bonus int) {
+	if len(cs.HPHistory) == 0 {
+		return
+	}
+
+	HP := 0
+	for i := range cs.HPHistory {
+		cs.HPHistory[i].ConBonus = conBonus
+		cs.HPHistory[i].Bonus = bonus
+		HP += cs.HPHistory[i].Total()
+	}
+	cs.HP = HP
 }
 
 func (cs *Stats) Print() {
@@ -11,4 +69,26 @@ func (cs *Stats) Print() {
 	fmt.Printf("Temporary HP: %d\n", cs.TempHP)
 	fmt.Printf("AC: %d\n", cs.AC)
 	fmt.Printf("Speed: %d\n", cs.Speed)
+
+	if len(cs.HitDice) > 0 {
+		dice := make([]string, len(cs.HitDice))
+		for i, pool := range cs.HitDice {
+			dice[i] = fmt.Sprintf("%d/%dd%d", pool.Remaining, pool.Max, pool.Die)
+		}
+		fmt.Printf("Hit Dice: %s\n", strings.Join(dice, ", "))
+	}
+
+	if len(cs.HPHistory) > 0 {
+		fmt.Println("HP by Level:")
+		for _, entry := range cs.HPHistory {
+			source := "average"
+			if entry.Level == 1 {
+				source = "max"
+			} else if entry.Rolled {
+				source = "rolled"
+			}
+			fmt.Printf("    - Level %-2d d%d %s %d, Con %+d, bonus %+d = %d\n",
+				entry.Level, entry.HitDie, source, entry.Roll, entry.ConBonus, entry.Bonus, entry.Total())
+		}
+	}
 }
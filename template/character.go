@@ -2,8 +2,12 @@ package template
 
 import (
 	"fmt"
-	"github.com/kwford18/MKDIRagons/internal/core"
 	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kwford18/MKDIRagons/internal/core"
 )
 
 // Template character parsed from a TOML file
@@ -21,10 +25,43 @@ type Inventory struct {
 	Weapons []string
 	Armor   []string
 	Items   []string
+	Wallet  Wallet `toml:"wallet,omitempty"`
+
+	// Gems and other treasure with their worth, e.g. "Diamond (300 gp)", for costly spell components
+	Valuables []string `toml:"valuables,omitempty"`
 }
 
+// Wallet is the coins a character starts with
+type Wallet struct {
+	CP int `toml:"cp"`
+	SP int `toml:"sp"`
+	EP int `toml:"ep"`
+	GP int `toml:"gp"`
+	PP int `toml:"pp"`
+}
+
+// MagicItems lists magic items, including +N weapons and armor such as "+1 Longsword",
+// and the ones the character is attuned to
+type MagicItems struct {
+	Items   []string `toml:"items"`
+	Attuned []string `toml:"attuned,omitempty"`
+}
+
+// Companion is an SRD monster that belongs to the character, e.g. a familiar or a ranger's beast
+type Companion struct {
+	Monster string `toml:"monster"`
+	Name    string `toml:"name,omitempty"`
+	Kind    string `toml:"kind"` // beast, familiar, steed or summon
+}
+
+// Spells lists the character's spells by level: the spells a Bard, Ranger, Sorcerer or Warlock knows,
+// a Wizard's spellbook, or the spells a Cleric, Druid or Paladin prepares from.
+// Prepared picks the ones ready today (the first spells up to the limit when empty), and AlwaysPrepared
+// the domain or oath spells that don't count against the limit.
 type Spells struct {
-	Level [][]string
+	Level          [][]string
+	Prepared       []string `toml:"prepared,omitempty"`
+	AlwaysPrepared []string `toml:"always_prepared,omitempty"`
 }
 
 type Character struct {
@@ -34,11 +71,20 @@ type Character struct {
 	Subrace       string        `toml:"subrace,omitempty"`
 	Class         string        `toml:"class"`
 	Subclass      string        `toml:"subclass,omitempty"`
+	Pact          string        `toml:"pact,omitempty"` // Warlock's Pact Boon from level 3: "chain", "blade" or "tome"
 	AbilityScores AbilityScores `toml:"ability_scores"`
 	Proficiencies []string      `toml:"proficiencies"`
 	Expertise     []string      `toml:"expertise,omitempty"`
+	Feats         []string      `toml:"feats,omitempty"`
 	Inventory     Inventory     `toml:"inventory"`
+	MagicItems    MagicItems    `toml:"magic_items,omitempty"`
 	Spells        Spells        `toml:"spells"`
+	Companions    []Companion   `toml:"companions,omitempty"`
+
+	// Class starting equipment: the option taken from each group, and the equipment
+	// picked for "any martial weapon" style choices, in order
+	StartingEquipmentChoices []int    `toml:"starting_equipment_choices,omitempty"`
+	StartingEquipmentPicks   []string `toml:"starting_equipment_picks,omitempty"`
 }
 
 func (t *Character) Print() {
@@ -107,3 +153,36 @@ func (t AbilityScores) Modifier(a core.Ability) int {
 	}
 	return int(math.Floor(float64(score-10) / 2.0))
 }
+
+// Increase adds amount to the given ability score, used for ability score improvements
+func (t *AbilityScores) Increase(a core.Ability, amount int) {
+	switch a {
+	case core.Strength:
+		t.Strength += amount
+	case core.Dexterity:
+		t.Dexterity += amount
+	case core.Constitution:
+		t.Constitution += amount
+	case core.Intelligence:
+		t.Intelligence += amount
+	case core.Wisdom:
+		t.Wisdom += amount
+	case core.Charisma:
+		t.Charisma += amount
+	}
+}
+
+// quantitySuffix matches a count after an inventory entry, e.g. the " x20" of "Arrow x20"
+var quantitySuffix = regexp.MustCompile(`^(.+?)\s+[xX×](\d+)$`)
+
+// ParseQuantity splits an inventory entry such as "Arrow x20" into its name and count.
+// Entries without a count are a single item.
+func ParseQuantity(entry string) (string, int) {
+	entry = strings.TrimSpace(entry)
+	if match := quantitySuffix.FindStringSubmatch(entry); match != nil {
+		if count, err := strconv.Atoi(match[2]); err == nil && count > 0 {
+			return match[1], count
+		}
+	}
+	return entry, 1
+}
@@ -377,6 +377,18 @@ func TestMinimalTemplateCharacter(t *testing.T) {
 	assert.Empty(t, char.Subclass)
 }
 
+// TestTemplateAbilityScoresIncrease tests applying ability score improvements
+func TestTemplateAbilityScoresIncrease(t *testing.T) {
+	scores := template.AbilityScores{Strength: 15, Wisdom: 14}
+
+	scores.Increase(core.Strength, 1)
+	scores.Increase(core.Wisdom, 2)
+
+	assert.Equal(t, 16, scores.Strength)
+	assert.Equal(t, 16, scores.Wisdom)
+	assert.Equal(t, 0, scores.Charisma)
+}
+
 // ========== Benchmark Tests ==========
 
 // BenchmarkProficiencyBonus benchmarks proficiency bonus calculation
@@ -433,3 +445,27 @@ func ExampleAbilityScores_Modifier() {
 	modifier := scores.Modifier(core.Strength)
 	_ = modifier // 3
 }
+
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		entry string
+		name  string
+		count int
+	}{
+		{"Arrow x20", "Arrow", 20},
+		{"Arrow X20", "Arrow", 20},
+		{"Dagger ×3", "Dagger", 3},
+		{"  Rope  ", "Rope", 1},
+		{"Arrow x0", "Arrow x0", 1},
+		{"Box", "Box", 1},
+		{"Wax", "Wax", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.entry, func(t *testing.T) {
+			name, count := template.ParseQuantity(tc.entry)
+			assert.Equal(t, tc.name, name)
+			assert.Equal(t, tc.count, count)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package template
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/BurntSushi/toml"
 )
@@ -59,3 +61,40 @@ func GenerateEmptyTOML() error {
 
 	return nil
 }
+
+// WriteTOML encodes a template character back to the TOML file at path, replacing it.
+// The file is rewritten from the struct, so comments and formatting in the original are not kept.
+// It's written to a temporary file first, leaving the original untouched if anything fails.
+func WriteTOML(t *Character, path string) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(t); err != nil {
+		return fmt.Errorf("error encoding toml file: %w", err)
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating TOML file %s: %w", path, err)
+	}
+	defer os.Remove(file.Name()) // No-op once renamed
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing TOML file %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing TOML file %s: %w", path, err)
+	}
+	if err := os.Chmod(file.Name(), mode); err != nil {
+		return fmt.Errorf("error writing TOML file %s: %w", path, err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("error replacing TOML file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kwford18/MKDIRagons/template"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -545,6 +546,59 @@ class = "fighter"
 	assert.NoError(suite.T(), err) // Actually valid - all scores default to 0
 }
 
+// TestWriteTOMLRoundTrip tests that a written template parses back unchanged
+func (suite *TomlParseTestSuite) TestWriteTOMLRoundTrip() {
+	original := template.Character{
+		Name:     "Leki",
+		Level:    6,
+		Race:     "tiefling",
+		Class:    "cleric",
+		Subclass: "Life",
+		AbilityScores: template.AbilityScores{
+			Strength: 10, Dexterity: 10, Constitution: 14,
+			Intelligence: 12, Wisdom: 16, Charisma: 13,
+		},
+		Proficiencies: []string{"Insight"},
+		Feats:         []string{"Alert"},
+		Spells: template.Spells{
+			Level: [][]string{{"Sacred Flame"}, {"Bless"}},
+		},
+	}
+	path := filepath.Join(suite.tempDir, "leki.toml")
+
+	err := template.WriteTOML(&original, path)
+	assert.NoError(suite.T(), err)
+
+	parsed, err := template.TomlParse(path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), original.Name, parsed.Name)
+	assert.Equal(suite.T(), original.Level, parsed.Level)
+	assert.Equal(suite.T(), original.Subclass, parsed.Subclass)
+	assert.Equal(suite.T(), original.AbilityScores, parsed.AbilityScores)
+	assert.Equal(suite.T(), original.Feats, parsed.Feats)
+	assert.Equal(suite.T(), original.Spells, parsed.Spells)
+}
+
+// TestWriteTOMLReplacesFile tests that writing over a template keeps its permissions and leaves no temporary file
+func (suite *TomlParseTestSuite) TestWriteTOMLReplacesFile() {
+	path := filepath.Join(suite.tempDir, "leki.toml")
+	require.NoError(suite.T(), os.WriteFile(path, []byte("# notes\nname = \"Leki\"\n"), 0600))
+
+	err := template.WriteTOML(&template.Character{Name: "Leki", Level: 2, Race: "tiefling", Class: "cleric"}, path)
+	require.NoError(suite.T(), err)
+
+	info, err := os.Stat(path)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), os.FileMode(0600), info.Mode().Perm())
+	entries, err := os.ReadDir(suite.tempDir)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+
+	parsed, err := template.TomlParse(path)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, parsed.Level)
+}
+
 func TestTomlParseTestSuite(t *testing.T) {
 	suite.Run(t, new(TomlParseTestSuite))
 }